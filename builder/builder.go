@@ -57,7 +57,6 @@ type Builder interface {
 	BuildFromTime(ctx *Context, value time.Time, dst reflect.Value) reflect.Value
 	BuildFromCompactTime(ctx *Context, value compact_time.Time, dst reflect.Value) reflect.Value
 	BuildFromReference(ctx *Context, id interface{})
-	BuildConcatenate(ctx *Context)
 
 	// Signals that a new source container has begun.
 	// This gets triggered from a data event.
@@ -84,6 +83,26 @@ type BuilderGeneratorGetter func(reflect.Type) BuilderGenerator
 // ============================================================================
 // Error reporting
 
+// ConversionError is raised when a source value can't be converted to the type
+// being built.
+type ConversionError struct {
+	// Path to the value that failed to convert, such as "Items[2].Price".
+	// Empty if the path is unknown or the value is the top-level object.
+	Path string
+	Err  error
+}
+
+func (_this *ConversionError) Error() string {
+	if _this.Path == "" {
+		return _this.Err.Error()
+	}
+	return fmt.Sprintf("%v: %v", _this.Path, _this.Err)
+}
+
+func (_this *ConversionError) Unwrap() error {
+	return _this.Err
+}
+
 // Report that a builder was given an event that it can't handle.
 // This indicates a bug in the implementation.
 func PanicBadEvent(builder Builder, eventFmt string, args ...interface{}) {
@@ -93,19 +112,25 @@ func PanicBadEvent(builder Builder, eventFmt string, args ...interface{}) {
 // Report that a builder couldn't convert between types. This can happen if
 // source values are out of range, or incompatible with the destination type.
 func PanicCannotConvert(value interface{}, dstType reflect.Type) {
-	panic(fmt.Errorf("cannot convert %v (type %v) to type %v", describe.D(value), reflect.TypeOf(value), dstType))
+	panic(&ConversionError{Err: fmt.Errorf("cannot convert %v (type %v) to type %v", describe.D(value), reflect.TypeOf(value), dstType)})
 }
 
 // Report that a builder couldn't convert between types. This can happen if
 // source values are out of range, or incompatible with the destination type.
 func PanicCannotConvertRV(value reflect.Value, dstType reflect.Type) {
-	panic(fmt.Errorf("cannot convert %v (type %v) to type %v", describe.D(value), value.Type(), dstType))
+	panic(&ConversionError{Err: fmt.Errorf("cannot convert %v (type %v) to type %v", describe.D(value), value.Type(), dstType)})
+}
+
+// Report that a conversion between types would lose precision, and lossy
+// conversions are not allowed.
+func PanicLossyConversion(value interface{}, dstType reflect.Type, result interface{}) {
+	panic(&ConversionError{Err: fmt.Errorf("converting %v (type %v) to type %v would lose precision (result would be %v)", describe.D(value), reflect.TypeOf(value), dstType, describe.D(result))})
 }
 
 // Report that an error occurred while converting between types.
 // This normally indicates a bug.
 func PanicErrorConverting(value interface{}, dstType reflect.Type, err error) {
-	panic(fmt.Errorf("error converting %v (type %v) to type %v: %v", describe.D(value), reflect.TypeOf(value), dstType, err))
+	panic(&ConversionError{Err: fmt.Errorf("error converting %v (type %v) to type %v: %v", describe.D(value), reflect.TypeOf(value), dstType, err)})
 }
 
 // Report that an error occurred while building from custom binary data.
@@ -67,6 +67,14 @@ func (_this *arrayBuilder) advanceElem() reflect.Value {
 	return elem
 }
 
+func (_this *arrayBuilder) describePathElement(isInnermost bool) string {
+	// An element being built directly has already been advanced past.
+	if isInnermost {
+		return fmt.Sprintf("[%v]", _this.elemIndex-1)
+	}
+	return fmt.Sprintf("[%v]", _this.elemIndex)
+}
+
 func (_this *arrayBuilder) BuildFromNil(ctx *Context, _ reflect.Value) reflect.Value {
 	object := _this.advanceElem()
 	_this.elemGenerator(ctx).BuildFromNil(ctx, object)
@@ -174,7 +182,7 @@ func (_this *arrayBuilder) BuildFromReference(ctx *Context, id interface{}) {
 	index := _this.elemIndex
 	_this.elemIndex++
 	ctx.NotifyReference(id, func(object reflect.Value) {
-		setAnythingFromAnything(object, container.Index(index))
+		setFromReferencedObject(ctx, object, container.Index(index))
 	})
 }
 
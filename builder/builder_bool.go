@@ -22,6 +22,8 @@ package builder
 
 import (
 	"reflect"
+
+	"github.com/kstenerud/go-concise-encoding/events"
 )
 
 type boolBuilder struct{}
@@ -35,3 +37,23 @@ func (_this *boolBuilder) BuildFromBool(ctx *Context, value bool, dst reflect.Va
 	dst.SetBool(value)
 	return dst
 }
+
+func (_this *boolBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	return _this.BuildFromStringlikeArray(ctx, arrayType, string(value), dst)
+}
+
+func (_this *boolBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, dst reflect.Value) reflect.Value {
+	if arrayType != events.ArrayTypeString {
+		PanicBadEvent(_this, "BuildFromStringlikeArray(%v)", arrayType)
+	}
+	ctx.NotifyCoercion(value, dst.Type())
+	switch value {
+	case "true":
+		dst.SetBool(true)
+	case "false":
+		dst.SetBool(false)
+	default:
+		PanicCannotConvert(value, dst.Type())
+	}
+	return dst
+}
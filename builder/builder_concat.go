@@ -0,0 +1,86 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package builder
+
+import (
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/kstenerud/go-concise-encoding/events"
+	"github.com/kstenerud/go-concise-encoding/internal/common"
+)
+
+// Builds a concatenated resource ID by collecting the resource ID and its
+// suffix, then passing the joined resource ID to the builder below it.
+type concatBuilder struct {
+	prefix    string
+	hasPrefix bool
+}
+
+func newConcatBuilder() *concatBuilder      { return &concatBuilder{} }
+func (_this *concatBuilder) String() string { return reflect.TypeOf(_this).String() }
+
+func (_this *concatBuilder) finish(ctx *Context, suffix string, dst reflect.Value) reflect.Value {
+	if !_this.hasPrefix {
+		PanicBadEvent(_this, "Suffix without a resource ID")
+	}
+	ctx.UnstackBuilder()
+	ctx.isConcatenation = true
+	defer func() { ctx.isConcatenation = false }()
+	return ctx.CurrentBuilder.BuildFromStringlikeArray(ctx, events.ArrayTypeResourceID, _this.prefix+suffix, dst)
+}
+
+func (_this *concatBuilder) BuildFromInt(ctx *Context, value int64, dst reflect.Value) reflect.Value {
+	if value < 0 {
+		PanicBadEvent(_this, "Negative Int")
+	}
+	return _this.finish(ctx, strconv.FormatInt(value, 10), dst)
+}
+
+func (_this *concatBuilder) BuildFromUint(ctx *Context, value uint64, dst reflect.Value) reflect.Value {
+	return _this.finish(ctx, strconv.FormatUint(value, 10), dst)
+}
+
+func (_this *concatBuilder) BuildFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) reflect.Value {
+	if common.IsBigIntNegative(value) {
+		PanicBadEvent(_this, "Negative BigInt")
+	}
+	return _this.finish(ctx, value.String(), dst)
+}
+
+func (_this *concatBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	return _this.BuildFromStringlikeArray(ctx, arrayType, string(value), dst)
+}
+
+func (_this *concatBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, dst reflect.Value) reflect.Value {
+	switch {
+	case !_this.hasPrefix && arrayType == events.ArrayTypeResourceID:
+		_this.prefix = value
+		_this.hasPrefix = true
+		return dst
+	case _this.hasPrefix && arrayType == events.ArrayTypeString:
+		return _this.finish(ctx, value, dst)
+	default:
+		PanicBadEvent(_this, "BuildFromArray(%v)", arrayType)
+		return dst
+	}
+}
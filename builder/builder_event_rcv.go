@@ -33,6 +33,7 @@ import (
 	"github.com/cockroachdb/apd/v2"
 	"github.com/kstenerud/go-compact-float"
 	"github.com/kstenerud/go-compact-time"
+	"golang.org/x/text/unicode/norm"
 )
 
 // BuilderEventReceiver adapts DataEventReceiver to ObjectBuilder, passing
@@ -60,19 +61,42 @@ func NewBuilder(session *Session, dstType reflect.Type, opts *options.BuilderOpt
 // Initialize to build objects of dstType.
 // If opts is nil, default options will be used.
 func (_this *BuilderEventReceiver) Init(session *Session, dstType reflect.Type, opts *options.BuilderOptions) {
-	_this.context.Init(opts,
+	_this.init(session.GetBuilderGeneratorForType,
 		dstType,
+		opts,
 		session.opts.CustomBinaryBuildFunction,
-		session.opts.CustomTextBuildFunction,
-		session.GetBuilderGeneratorForType)
+		session.opts.CustomTextBuildFunction)
+	_this.context.polymorphicTypes = session.polymorphicTypes
+	_this.context.polymorphicTypeKey = session.opts.PolymorphicTypeKey
+}
+
+func (_this *BuilderEventReceiver) init(getBuilderGeneratorForType BuilderGeneratorGetter,
+	dstType reflect.Type,
+	opts *options.BuilderOptions,
+	customBinaryBuildFunction options.CustomBuildFunction,
+	customTextBuildFunction options.CustomBuildFunction) {
+
+	_this.context.Init(opts,
+		dstType,
+		customBinaryBuildFunction,
+		customTextBuildFunction,
+		getBuilderGeneratorForType)
+	_this.context.eventReceiver = _this
 
 	_this.object = reflect.New(dstType).Elem()
-	generator := session.GetBuilderGeneratorForType(dstType)
+	generator := getBuilderGeneratorForType(dstType)
 	_this.context.StackBuilder(newTopLevelBuilder(generator, func(value reflect.Value) {
 		_this.object = value
 	}))
 }
 
+// Set the function that creates the rules that documents loaded via resource
+// ID references are checked by (see BuilderOptions.ReferenceResolver). If not
+// set, referenced documents are checked by rules with default options.
+func (_this *BuilderEventReceiver) SetReferencedDocumentRules(newRules func(nextReceiver events.DataEventReceiver) events.DataEventReceiver) {
+	_this.context.newReferencedDocumentRules = newRules
+}
+
 func (_this *BuilderEventReceiver) String() string {
 	return fmt.Sprintf("%v<%v>", reflect.TypeOf(_this), _this.context.CurrentBuilder)
 }
@@ -96,6 +120,15 @@ func (_this *BuilderEventReceiver) GetBuiltObject() interface{} {
 	}
 }
 
+// If err is a ConversionError raised while building, annotate it with the
+// path to the value that failed to convert. Other errors are returned as-is.
+func (_this *BuilderEventReceiver) AnnotateError(err error) error {
+	if convErr, ok := err.(*ConversionError); ok && convErr.Path == "" {
+		convErr.Path = _this.context.describeCurrentPath()
+	}
+	return err
+}
+
 // ---------------------------
 // DataEventReceiver Callbacks
 // ---------------------------
@@ -119,13 +152,14 @@ func (_this *BuilderEventReceiver) OnPositiveInt(value uint64) {
 	_this.context.CurrentBuilder.BuildFromUint(&_this.context, value, _this.object)
 }
 func (_this *BuilderEventReceiver) OnNegativeInt(value uint64) {
-	if value <= 0x7fffffffffffffff {
+	if value <= 0x8000000000000000 {
+		// -int64(0x8000000000000000) wraps around to math.MinInt64
 		_this.OnInt(-int64(value))
 		return
 	}
 	bi := big.Int{}
 	bi.SetUint64(value)
-	_this.OnBigInt(&bi)
+	_this.OnBigInt(bi.Neg(&bi))
 }
 func (_this *BuilderEventReceiver) OnInt(value int64) {
 	_this.context.CurrentBuilder.BuildFromInt(&_this.context, value, _this.object)
@@ -162,14 +196,24 @@ func (_this *BuilderEventReceiver) OnCompactTime(value compact_time.Time) {
 	_this.context.CurrentBuilder.BuildFromCompactTime(&_this.context, value, _this.object)
 }
 func (_this *BuilderEventReceiver) OnArray(arrayType events.ArrayType, elementCount uint64, value []byte) {
+	arrayType = _this.beginConcatIfRIDCat(arrayType)
+	if _this.shouldNormalize(arrayType) {
+		value = norm.NFC.Bytes(value)
+		elementCount = uint64(len(value))
+	}
+	_this.context.arrayElementCount = elementCount
 	_this.context.CurrentBuilder.BuildFromArray(&_this.context, arrayType, value, _this.object)
 }
 func (_this *BuilderEventReceiver) OnStringlikeArray(arrayType events.ArrayType, value string) {
+	arrayType = _this.beginConcatIfRIDCat(arrayType)
+	if _this.shouldNormalize(arrayType) {
+		value = norm.NFC.String(value)
+	}
 	_this.context.CurrentBuilder.BuildFromStringlikeArray(&_this.context, arrayType, value, _this.object)
 }
 func (_this *BuilderEventReceiver) OnArrayBegin(arrayType events.ArrayType) {
-	_this.context.BeginArray(func(bytes []byte) {
-		elementCount := common.ByteCountToElementCount(arrayType.ElementSize(), uint64(len(bytes)))
+	arrayType = _this.beginConcatIfRIDCat(arrayType)
+	_this.context.BeginArray(arrayType, func(elementCount uint64, bytes []byte) {
 		_this.OnArray(arrayType, elementCount, bytes)
 	})
 }
@@ -204,11 +248,32 @@ func (_this *BuilderEventReceiver) OnReference() {
 	_this.context.StackBuilder(newReferenceIDBuilder())
 }
 func (_this *BuilderEventReceiver) OnConcatenate() {
-	_this.context.CurrentBuilder.BuildConcatenate(&_this.context)
+	_this.context.StackBuilder(newConcatBuilder())
 }
 func (_this *BuilderEventReceiver) OnConstant(name []byte, explicitValue bool) {
 	if !explicitValue {
+		if value, ok := _this.context.Options.Constants[string(name)]; ok {
+			events.IterateConstantValue(value, _this)
+			return
+		}
 		panic(fmt.Errorf("Cannot build from constant %s without explicit value", string(name)))
 	}
 }
 func (_this *BuilderEventReceiver) OnEndDocument() {}
+
+// A concatenated resource ID array is built as a concatenation followed by a
+// regular resource ID.
+func (_this *BuilderEventReceiver) beginConcatIfRIDCat(arrayType events.ArrayType) events.ArrayType {
+	if arrayType == events.ArrayTypeResourceIDConcat {
+		_this.OnConcatenate()
+		return events.ArrayTypeResourceID
+	}
+	return arrayType
+}
+
+func (_this *BuilderEventReceiver) shouldNormalize(arrayType events.ArrayType) bool {
+	if !_this.context.Options.NormalizeToNFC {
+		return false
+	}
+	return arrayType == events.ArrayTypeString || arrayType == events.ArrayTypeResourceID
+}
@@ -0,0 +1,215 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package builder
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+
+	"github.com/kstenerud/go-concise-encoding/events"
+	"github.com/kstenerud/go-concise-encoding/internal/common"
+	"github.com/kstenerud/go-concise-encoding/types"
+
+	"github.com/cockroachdb/apd/v2"
+	"github.com/kstenerud/go-compact-float"
+)
+
+// Builders for types.Float16 and types.BFloat16, and arrays & slices of them.
+
+type halfFloatBuilder struct{}
+
+var globalHalfFloatBuilder = &halfFloatBuilder{}
+
+func generateHalfFloatBuilder(ctx *Context) Builder { return globalHalfFloatBuilder }
+func (_this *halfFloatBuilder) String() string      { return reflect.TypeOf(_this).String() }
+
+func (_this *halfFloatBuilder) BuildFromInt(ctx *Context, value int64, dst reflect.Value) reflect.Value {
+	var f float64
+	setFloatFromInt(ctx, value, reflect.ValueOf(&f).Elem())
+	setHalfFloatFromFloat(ctx, f, dst)
+	return dst
+}
+func (_this *halfFloatBuilder) BuildFromUint(ctx *Context, value uint64, dst reflect.Value) reflect.Value {
+	var f float64
+	setFloatFromUint(ctx, value, reflect.ValueOf(&f).Elem())
+	setHalfFloatFromFloat(ctx, f, dst)
+	return dst
+}
+func (_this *halfFloatBuilder) BuildFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) reflect.Value {
+	var f float64
+	setFloatFromBigInt(ctx, value, reflect.ValueOf(&f).Elem())
+	setHalfFloatFromFloat(ctx, f, dst)
+	return dst
+}
+func (_this *halfFloatBuilder) BuildFromFloat(ctx *Context, value float64, dst reflect.Value) reflect.Value {
+	setHalfFloatFromFloat(ctx, value, dst)
+	return dst
+}
+func (_this *halfFloatBuilder) BuildFromBigFloat(ctx *Context, value *big.Float, dst reflect.Value) reflect.Value {
+	var f float64
+	setFloatFromBigFloat(ctx, value, reflect.ValueOf(&f).Elem())
+	setHalfFloatFromFloat(ctx, f, dst)
+	return dst
+}
+func (_this *halfFloatBuilder) BuildFromDecimalFloat(ctx *Context, value compact_float.DFloat, dst reflect.Value) reflect.Value {
+	var f float64
+	setFloatFromDecimalFloat(ctx, value, reflect.ValueOf(&f).Elem())
+	setHalfFloatFromFloat(ctx, f, dst)
+	return dst
+}
+func (_this *halfFloatBuilder) BuildFromBigDecimalFloat(ctx *Context, value *apd.Decimal, dst reflect.Value) reflect.Value {
+	var f float64
+	setFloatFromBigDecimalFloat(ctx, value, reflect.ValueOf(&f).Elem())
+	setHalfFloatFromFloat(ctx, f, dst)
+	return dst
+}
+
+// ============================================================================
+
+type halfFloatArrayBuilder struct {
+	dstType reflect.Type
+}
+
+func newHalfFloatArrayBuilderGenerator(dstType reflect.Type) BuilderGenerator {
+	return func(ctx *Context) Builder {
+		return &halfFloatArrayBuilder{
+			dstType: dstType,
+		}
+	}
+}
+
+func (_this *halfFloatArrayBuilder) String() string {
+	return fmt.Sprintf("%v<%v>", reflect.TypeOf(_this), _this.dstType)
+}
+
+func (_this *halfFloatArrayBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	if !setHalfFloatElementsFromArray(ctx, arrayType, value, dst) {
+		PanicBadEvent(_this, "BuildFromArray(%v)", arrayType)
+	}
+	return dst
+}
+
+func (_this *halfFloatArrayBuilder) BuildBeginListContents(ctx *Context) {
+	generator := newArrayBuilderGenerator(ctx.GetBuilderGeneratorForType, _this.dstType)
+	generator(ctx).BuildBeginListContents(ctx)
+}
+
+// ============================================================================
+
+type halfFloatSliceBuilder struct {
+	dstType reflect.Type
+}
+
+func newHalfFloatSliceBuilderGenerator(dstType reflect.Type) BuilderGenerator {
+	return func(ctx *Context) Builder {
+		return &halfFloatSliceBuilder{
+			dstType: dstType,
+		}
+	}
+}
+
+func (_this *halfFloatSliceBuilder) String() string {
+	return fmt.Sprintf("%v<%v>", reflect.TypeOf(_this), _this.dstType)
+}
+
+func (_this *halfFloatSliceBuilder) BuildFromNil(ctx *Context, dst reflect.Value) reflect.Value {
+	dst.Set(reflect.Zero(dst.Type()))
+	return dst
+}
+
+func (_this *halfFloatSliceBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	switch arrayType {
+	case events.ArrayTypeFloat16, events.ArrayTypeFloat32:
+		elemCount := len(value) / (arrayType.ElementSize() / 8)
+		slice := reflect.MakeSlice(_this.dstType, elemCount, elemCount)
+		setHalfFloatElementsFromArray(ctx, arrayType, value, slice)
+		dst.Set(slice)
+	default:
+		PanicBadEvent(_this, "BuildFromArray(%v)", arrayType)
+	}
+	return dst
+}
+
+func (_this *halfFloatSliceBuilder) BuildBeginListContents(ctx *Context) {
+	generator := newSliceBuilderGenerator(ctx.GetBuilderGeneratorForType, _this.dstType)
+	generator(ctx).BuildBeginListContents(ctx)
+}
+
+// ============================================================================
+
+// Set a types.Float16 or types.BFloat16 value, panicking if the value is too
+// large to fit.
+func setHalfFloatFromFloat(ctx *Context, value float64, dst reflect.Value) {
+	asFloat32 := float32(value)
+	var bits uint16
+	var result float32
+	switch dst.Type() {
+	case common.TypeFloat16:
+		f16 := types.Float16FromFloat32(asFloat32)
+		bits, result = uint16(f16), f16.Float32()
+	case common.TypeBFloat16:
+		bf16 := types.BFloat16FromFloat32(asFloat32)
+		bits, result = uint16(bf16), bf16.Float32()
+	default:
+		panic(fmt.Errorf("BUG: %v is not a half precision float type", dst.Type()))
+	}
+	if math.IsInf(float64(result), 0) && !math.IsInf(value, 0) {
+		PanicCannotConvert(value, dst.Type())
+	}
+	if !ctx.Options.AllowLossyFloatConversion && float64(result) != value && !math.IsNaN(value) {
+		PanicLossyConversion(value, dst.Type(), result)
+	}
+	dst.SetUint(uint64(bits))
+}
+
+// Fill the elements of dst (an array or slice of types.Float16 or
+// types.BFloat16) from float16 (bfloat16) or float32 array data.
+// Returns false if arrayType isn't a float16 or float32 array.
+func setHalfFloatElementsFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) bool {
+	switch arrayType {
+	case events.ArrayTypeFloat16:
+		elemCount := len(value) / 2
+		isBFloat16 := dst.Type().Elem() == common.TypeBFloat16
+		for i := 0; i < elemCount; i++ {
+			bits := uint16(value[i*2]) | (uint16(value[i*2+1]) << 8)
+			elem := dst.Index(i)
+			if isBFloat16 {
+				elem.SetUint(uint64(bits))
+			} else {
+				setHalfFloatFromFloat(ctx, float64(types.BFloat16(bits).Float32()), elem)
+			}
+		}
+	case events.ArrayTypeFloat32:
+		elemCount := len(value) / 4
+		for i := 0; i < elemCount; i++ {
+			bits := uint32(value[i*4]) |
+				(uint32(value[i*4+1]) << 8) |
+				(uint32(value[i*4+2]) << 16) |
+				(uint32(value[i*4+3]) << 24)
+			setHalfFloatFromFloat(ctx, float64(math.Float32frombits(bits)), dst.Index(i))
+		}
+	default:
+		return false
+	}
+	return true
+}
@@ -106,6 +106,10 @@ func (_this *ignoreBuilder) BuildInitiateMap(ctx *Context) {
 
 func (_this *ignoreBuilder) BuildEndContainer(ctx *Context) {
 	ctx.UnstackBuilder()
+	if ctx.CurrentBuilder != Builder(_this) {
+		// The outermost ignored container has ended
+		ctx.CurrentBuilder.NotifyChildContainerFinished(ctx, reflect.Value{})
+	}
 }
 
 func (_this *ignoreBuilder) BuildBeginListContents(ctx *Context) {
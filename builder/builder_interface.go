@@ -28,6 +28,8 @@ import (
 
 	"github.com/kstenerud/go-concise-encoding/events"
 	"github.com/kstenerud/go-concise-encoding/internal/common"
+	"github.com/kstenerud/go-concise-encoding/options"
+	"github.com/kstenerud/go-concise-encoding/types"
 
 	"github.com/cockroachdb/apd/v2"
 	"github.com/kstenerud/go-compact-float"
@@ -41,8 +43,44 @@ var globalInterfaceBuilder = &interfaceBuilder{}
 func generateInterfaceBuilder(ctx *Context) Builder { return globalInterfaceBuilder }
 func (_this *interfaceBuilder) String() string      { return reflect.TypeOf(_this).String() }
 
+// The types to build values as when building into an interface{} (nil means
+// the value's natural type). See options.BuilderOptions.
+var interfaceIntegerTypes = [...]reflect.Type{
+	options.InterfaceIntegerTypeNatural: nil,
+	options.InterfaceIntegerTypeInt64:   reflect.TypeOf(int64(0)),
+	options.InterfaceIntegerTypeUint64:  reflect.TypeOf(uint64(0)),
+	options.InterfaceIntegerTypeBigInt:  common.TypePBigInt,
+	options.InterfaceIntegerTypeNumber:  common.TypeNumber,
+}
+
+var interfaceFloatTypes = [...]reflect.Type{
+	options.InterfaceFloatTypeNatural:    nil,
+	options.InterfaceFloatTypeFloat64:    reflect.TypeOf(float64(0)),
+	options.InterfaceFloatTypeDFloat:     common.TypeDFloat,
+	options.InterfaceFloatTypeBigDecimal: common.TypePBigDecimalFloat,
+}
+
+var interfaceTimeTypes = [...]reflect.Type{
+	options.InterfaceTimeTypeNatural:     nil,
+	options.InterfaceTimeTypeGoTime:      common.TypeTime,
+	options.InterfaceTimeTypeCompactTime: common.TypeCompactTime,
+}
+
+// Generate a builder for a map destined for an interface{}, as configured by
+// options.BuilderOptions.InterfaceMapType.
+func generateInterfaceMapBuilder(ctx *Context) Builder {
+	switch ctx.Options.InterfaceMapType {
+	case options.InterfaceMapTypeStringKeys:
+		return ctx.GetBuilderGeneratorForType(common.TypeStringMap)(ctx)
+	case options.InterfaceMapTypeOrdered:
+		return ctx.GetBuilderGeneratorForType(common.TypePOrderedMap)(ctx)
+	default:
+		return interfaceMapBuilderGenerator(ctx)
+	}
+}
+
 func (_this *interfaceBuilder) BuildFromNil(ctx *Context, dst reflect.Value) reflect.Value {
-	dst.Set(reflect.Zero(common.TypeInterface))
+	dst.Set(reflect.Zero(dst.Type()))
 	return dst
 }
 
@@ -52,43 +90,86 @@ func (_this *interfaceBuilder) BuildFromBool(ctx *Context, value bool, dst refle
 }
 
 func (_this *interfaceBuilder) BuildFromInt(ctx *Context, value int64, dst reflect.Value) reflect.Value {
+	if t := interfaceIntegerTypes[ctx.Options.InterfaceIntegerType]; t != nil {
+		v := reflect.New(t).Elem()
+		ctx.GetBuilderGeneratorForType(t)(ctx).BuildFromInt(ctx, value, v)
+		dst.Set(v)
+		return dst
+	}
 	dst.Set(reflect.ValueOf(value))
 	return dst
 }
 
 func (_this *interfaceBuilder) BuildFromUint(ctx *Context, value uint64, dst reflect.Value) reflect.Value {
+	if t := interfaceIntegerTypes[ctx.Options.InterfaceIntegerType]; t != nil {
+		v := reflect.New(t).Elem()
+		ctx.GetBuilderGeneratorForType(t)(ctx).BuildFromUint(ctx, value, v)
+		dst.Set(v)
+		return dst
+	}
 	dst.Set(reflect.ValueOf(value))
 	return dst
 }
 
 func (_this *interfaceBuilder) BuildFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) reflect.Value {
+	if t := interfaceIntegerTypes[ctx.Options.InterfaceIntegerType]; t != nil {
+		v := reflect.New(t).Elem()
+		ctx.GetBuilderGeneratorForType(t)(ctx).BuildFromBigInt(ctx, value, v)
+		dst.Set(v)
+		return dst
+	}
 	dst.Set(reflect.ValueOf(value))
 	return dst
 }
 
 func (_this *interfaceBuilder) BuildFromFloat(ctx *Context, value float64, dst reflect.Value) reflect.Value {
+	if t := interfaceFloatTypes[ctx.Options.InterfaceFloatType]; t != nil {
+		v := reflect.New(t).Elem()
+		ctx.GetBuilderGeneratorForType(t)(ctx).BuildFromFloat(ctx, value, v)
+		dst.Set(v)
+		return dst
+	}
 	dst.Set(reflect.ValueOf(value))
 	return dst
 }
 
 func (_this *interfaceBuilder) BuildFromBigFloat(ctx *Context, value *big.Float, dst reflect.Value) reflect.Value {
+	if t := interfaceFloatTypes[ctx.Options.InterfaceFloatType]; t != nil {
+		v := reflect.New(t).Elem()
+		ctx.GetBuilderGeneratorForType(t)(ctx).BuildFromBigFloat(ctx, value, v)
+		dst.Set(v)
+		return dst
+	}
 	dst.Set(reflect.ValueOf(value))
 	return dst
 }
 
 func (_this *interfaceBuilder) BuildFromDecimalFloat(ctx *Context, value compact_float.DFloat, dst reflect.Value) reflect.Value {
+	if t := interfaceFloatTypes[ctx.Options.InterfaceFloatType]; t != nil {
+		v := reflect.New(t).Elem()
+		ctx.GetBuilderGeneratorForType(t)(ctx).BuildFromDecimalFloat(ctx, value, v)
+		dst.Set(v)
+		return dst
+	}
 	dst.Set(reflect.ValueOf(value))
 	return dst
 }
 
 func (_this *interfaceBuilder) BuildFromBigDecimalFloat(ctx *Context, value *apd.Decimal, dst reflect.Value) reflect.Value {
+	if t := interfaceFloatTypes[ctx.Options.InterfaceFloatType]; t != nil {
+		v := reflect.New(t).Elem()
+		ctx.GetBuilderGeneratorForType(t)(ctx).BuildFromBigDecimalFloat(ctx, value, v)
+		dst.Set(v)
+		return dst
+	}
 	dst.Set(reflect.ValueOf(value))
 	return dst
 }
 
 func (_this *interfaceBuilder) BuildFromUUID(ctx *Context, value []byte, dst reflect.Value) reflect.Value {
-	value = common.CloneBytes(value)
-	dst.Set(reflect.ValueOf(value))
+	var uuid types.UUID
+	copy(uuid[:], value)
+	dst.Set(reflect.ValueOf(uuid))
 	return dst
 }
 
@@ -109,11 +190,46 @@ func (_this *interfaceBuilder) BuildFromArray(ctx *Context, arrayType events.Arr
 	case events.ArrayTypeResourceID:
 		setPRIDFromString(string(value), dst)
 	default:
-		panic(fmt.Errorf("TODO: Typed array support for %v", arrayType))
+		sliceType := interfaceTypedArraySliceTypes[arrayType]
+		if sliceType == nil {
+			PanicBadEvent(_this, "BuildFromArray(%v)", arrayType)
+		}
+		slice := reflect.New(sliceType).Elem()
+		ctx.GetBuilderGeneratorForType(sliceType)(ctx).BuildFromArray(ctx, arrayType, value, slice)
+		if ctx.Options.TypedArraysAsInterfaceSlices {
+			slice = toInterfaceSlice(slice)
+		}
+		dst.Set(slice)
 	}
 	return dst
 }
 
+// The natural go slice type for each typed array type when building into an
+// interface{}.
+var interfaceTypedArraySliceTypes = [...]reflect.Type{
+	events.ArrayTypeBoolean: reflect.TypeOf([]bool{}),
+	events.ArrayTypeUint16:  reflect.TypeOf([]uint16{}),
+	events.ArrayTypeUint32:  reflect.TypeOf([]uint32{}),
+	events.ArrayTypeUint64:  reflect.TypeOf([]uint64{}),
+	events.ArrayTypeInt8:    reflect.TypeOf([]int8{}),
+	events.ArrayTypeInt16:   reflect.TypeOf([]int16{}),
+	events.ArrayTypeInt32:   reflect.TypeOf([]int32{}),
+	events.ArrayTypeInt64:   reflect.TypeOf([]int64{}),
+	events.ArrayTypeFloat16: reflect.TypeOf([]types.BFloat16{}),
+	events.ArrayTypeFloat32: reflect.TypeOf([]float32{}),
+	events.ArrayTypeFloat64: reflect.TypeOf([]float64{}),
+	events.ArrayTypeUUID:    reflect.TypeOf([]types.UUID{}),
+}
+
+func toInterfaceSlice(slice reflect.Value) reflect.Value {
+	elemCount := slice.Len()
+	result := make([]interface{}, elemCount, elemCount)
+	for i := 0; i < elemCount; i++ {
+		result[i] = slice.Index(i).Interface()
+	}
+	return reflect.ValueOf(result)
+}
+
 func (_this *interfaceBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, dst reflect.Value) reflect.Value {
 	switch arrayType {
 	case events.ArrayTypeCustomText:
@@ -131,29 +247,49 @@ func (_this *interfaceBuilder) BuildFromStringlikeArray(ctx *Context, arrayType
 }
 
 func (_this *interfaceBuilder) BuildFromTime(ctx *Context, value time.Time, dst reflect.Value) reflect.Value {
+	if t := interfaceTimeTypes[ctx.Options.InterfaceTimeType]; t != nil {
+		v := reflect.New(t).Elem()
+		ctx.GetBuilderGeneratorForType(t)(ctx).BuildFromTime(ctx, value, v)
+		dst.Set(v)
+		return dst
+	}
 	dst.Set(reflect.ValueOf(value))
 	return dst
 }
 
 func (_this *interfaceBuilder) BuildFromCompactTime(ctx *Context, value compact_time.Time, dst reflect.Value) reflect.Value {
+	if t := interfaceTimeTypes[ctx.Options.InterfaceTimeType]; t != nil {
+		v := reflect.New(t).Elem()
+		ctx.GetBuilderGeneratorForType(t)(ctx).BuildFromCompactTime(ctx, value, v)
+		dst.Set(v)
+		return dst
+	}
 	dst.Set(reflect.ValueOf(value))
 	return dst
 }
 
 func (_this *interfaceBuilder) BuildInitiateList(ctx *Context) {
-	ctx.StackBuilder(interfaceSliceBuilderGenerator(ctx))
+	interfaceSliceBuilderGenerator(ctx).BuildBeginListContents(ctx)
 }
 
 func (_this *interfaceBuilder) BuildInitiateMap(ctx *Context) {
-	ctx.StackBuilder(interfaceMapBuilderGenerator(ctx))
+	if len(ctx.polymorphicTypes) > 0 {
+		ctx.StackBuilder(newPolymorphicBuilder())
+		return
+	}
+	generateInterfaceMapBuilder(ctx).BuildBeginMapContents(ctx)
 }
 
 func (_this *interfaceBuilder) BuildBeginListContents(ctx *Context) {
-	ctx.StackBuilder(interfaceSliceBuilderGenerator(ctx))
+	interfaceSliceBuilderGenerator(ctx).BuildBeginListContents(ctx)
 }
 
 func (_this *interfaceBuilder) BuildBeginMapContents(ctx *Context) {
-	ctx.StackBuilder(interfaceMapBuilderGenerator(ctx))
+	if len(ctx.polymorphicTypes) > 0 {
+		ctx.StackBuilder(newPolymorphicBuilder())
+		return
+	}
+	generateInterfaceMapBuilder(ctx).BuildBeginMapContents(ctx)
 }
 
 func (_this *interfaceBuilder) BuildBeginMarker(ctx *Context, id interface{}) {
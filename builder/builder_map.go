@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/apd/v2"
 	"github.com/kstenerud/go-compact-float"
 	"github.com/kstenerud/go-compact-time"
+	"github.com/kstenerud/go-describe"
 )
 
 const (
@@ -44,6 +45,7 @@ type mapBuilder struct {
 	kvGenerators    [2]BuilderGenerator
 	container       reflect.Value
 	key             reflect.Value
+	pendingKey      *pendingMapKey
 	builderIndex    int
 	nextGenerator   BuilderGenerator
 	nextStoreMethod func(*mapBuilder, reflect.Value)
@@ -71,6 +73,7 @@ func (_this *mapBuilder) String() string {
 func (_this *mapBuilder) reset() {
 	_this.container = reflect.MakeMap(_this.mapType)
 	_this.key = reflect.Value{}
+	_this.pendingKey = nil
 	_this.builderIndex = kvBuilderKey
 	_this.nextGenerator = _this.kvGenerators[_this.builderIndex]
 	_this.nextStoreMethod = mapBuilderKVStoreMethods[_this.builderIndex]
@@ -78,10 +81,53 @@ func (_this *mapBuilder) reset() {
 
 func (_this *mapBuilder) storeKey(value reflect.Value) {
 	_this.key = value
+	_this.pendingKey = nil
 }
 
 func (_this *mapBuilder) storeValue(value reflect.Value) {
-	_this.container.SetMapIndex(_this.key, value)
+	_this.entrySetter()(value)
+}
+
+// Get a function that stores a value under the current key. If the key is a
+// reference that hasn't been resolved yet, storing is deferred until it is.
+func (_this *mapBuilder) entrySetter() func(value reflect.Value) {
+	container := _this.container
+	key := _this.key
+	if pendingKey := _this.pendingKey; pendingKey != nil {
+		return func(value reflect.Value) {
+			pendingKey.setEntry(func() {
+				container.SetMapIndex(key, value)
+			})
+		}
+	}
+	return func(value reflect.Value) {
+		container.SetMapIndex(key, value)
+	}
+}
+
+// A map key that was built from a reference, and which might not be resolved
+// until after its value has been built.
+type pendingMapKey struct {
+	isResolved    bool
+	deferredEntry func()
+}
+
+// Store an entry under this key using setEntry, either now or once the key
+// has been resolved. Only the most recent entry is kept.
+func (_this *pendingMapKey) setEntry(setEntry func()) {
+	if _this.isResolved {
+		setEntry()
+		return
+	}
+	_this.deferredEntry = setEntry
+}
+
+func (_this *pendingMapKey) resolve() {
+	_this.isResolved = true
+	if _this.deferredEntry != nil {
+		_this.deferredEntry()
+		_this.deferredEntry = nil
+	}
 }
 
 var mapBuilderKVStoreMethods = []func(*mapBuilder, reflect.Value){
@@ -104,6 +150,13 @@ func (_this *mapBuilder) newElem() reflect.Value {
 	return reflect.New(_this.kvTypes[_this.builderIndex]).Elem()
 }
 
+func (_this *mapBuilder) describePathElement(_ bool) string {
+	if _this.builderIndex == kvBuilderValue && _this.key.IsValid() {
+		return fmt.Sprintf("[%v]", describe.D(_this.key))
+	}
+	return ""
+}
+
 func (_this *mapBuilder) BuildFromNil(ctx *Context, _ reflect.Value) reflect.Value {
 	object := _this.newElem()
 	_this.nextGenerator(ctx).BuildFromNil(ctx, object)
@@ -225,17 +278,30 @@ func (_this *mapBuilder) BuildBeginMapContents(ctx *Context) {
 }
 
 func (_this *mapBuilder) BuildFromReference(ctx *Context, id interface{}) {
-	container := _this.container
-	key := _this.key
+	if _this.builderIndex == kvBuilderKey {
+		key := _this.newElem()
+		pendingKey := &pendingMapKey{}
+		_this.key = key
+		_this.pendingKey = pendingKey
+		_this.swapKeyValue()
+		ctx.NotifyReference(id, func(object reflect.Value) {
+			setFromReferencedObject(ctx, object, key)
+			pendingKey.resolve()
+		})
+		return
+	}
+
+	elemType := _this.container.Type().Elem()
 	tempValue := _this.newElem()
+	setEntry := _this.entrySetter()
 	_this.swapKeyValue()
 	ctx.NotifyReference(id, func(object reflect.Value) {
-		if container.Type().Elem().Kind() == reflect.Interface || object.Type() == container.Type().Elem() {
+		if elemType.Kind() == reflect.Interface || object.Type() == elemType {
 			// In case of self-referencing pointers, we need to pass the original container, not a copy.
-			container.SetMapIndex(key, object)
+			setEntry(object)
 		} else {
-			setAnythingFromAnything(object, tempValue)
-			container.SetMapIndex(key, tempValue)
+			setFromReferencedObject(ctx, object, tempValue)
+			setEntry(tempValue)
 		}
 	})
 }
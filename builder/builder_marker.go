@@ -62,6 +62,19 @@ func (_this *referenceIDBuilder) BuildFromBigInt(ctx *Context, value *big.Int, _
 	return reflect.ValueOf(value)
 }
 
+func (_this *referenceIDBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	return _this.BuildFromStringlikeArray(ctx, arrayType, string(value), dst)
+}
+
+func (_this *referenceIDBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, dst reflect.Value) reflect.Value {
+	if arrayType != events.ArrayTypeResourceID {
+		PanicBadEvent(_this, "BuildFromArray(%v)", arrayType)
+	}
+	ctx.UnstackBuilder()
+	ctx.BuildFromReferencedDocument(value)
+	return dst
+}
+
 // ============================================================================
 
 type markerIDBuilder struct{}
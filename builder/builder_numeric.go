@@ -23,6 +23,9 @@ package builder
 import (
 	"math/big"
 	"reflect"
+	"strconv"
+
+	"github.com/kstenerud/go-concise-encoding/events"
 
 	"github.com/cockroachdb/apd/v2"
 	"github.com/kstenerud/go-compact-float"
@@ -190,31 +193,42 @@ func generateFloatBuilder(ctx *Context) Builder { return globalFloatBuilder }
 func (_this *floatBuilder) String() string      { return reflect.TypeOf(_this).String() }
 
 func (_this *floatBuilder) BuildFromInt(ctx *Context, value int64, dst reflect.Value) reflect.Value {
-	setFloatFromInt(value, dst)
+	setFloatFromInt(ctx, value, dst)
 	return dst
 }
 func (_this *floatBuilder) BuildFromUint(ctx *Context, value uint64, dst reflect.Value) reflect.Value {
-	setFloatFromUint(value, dst)
+	setFloatFromUint(ctx, value, dst)
 	return dst
 }
 func (_this *floatBuilder) BuildFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) reflect.Value {
-	setFloatFromBigInt(value, dst)
+	setFloatFromBigInt(ctx, value, dst)
 	return dst
 }
 func (_this *floatBuilder) BuildFromFloat(ctx *Context, value float64, dst reflect.Value) reflect.Value {
-	setFloatFromFloat(value, dst)
+	setFloatFromFloat(ctx, value, dst)
 	return dst
 }
 func (_this *floatBuilder) BuildFromDecimalFloat(ctx *Context, value compact_float.DFloat, dst reflect.Value) reflect.Value {
-	setFloatFromDecimalFloat(value, dst)
+	setFloatFromDecimalFloat(ctx, value, dst)
 	return dst
 }
 func (_this *floatBuilder) BuildFromBigFloat(ctx *Context, value *big.Float, dst reflect.Value) reflect.Value {
-	setFloatFromBigFloat(value, dst)
+	setFloatFromBigFloat(ctx, value, dst)
 	return dst
 }
 func (_this *floatBuilder) BuildFromBigDecimalFloat(ctx *Context, value *apd.Decimal, dst reflect.Value) reflect.Value {
-	setFloatFromBigDecimalFloat(value, dst)
+	setFloatFromBigDecimalFloat(ctx, value, dst)
+	return dst
+}
+func (_this *floatBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	return _this.BuildFromStringlikeArray(ctx, arrayType, string(value), dst)
+}
+func (_this *floatBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, dst reflect.Value) reflect.Value {
+	if arrayType != events.ArrayTypeString {
+		PanicBadEvent(_this, "BuildFromStringlikeArray(%v)", arrayType)
+	}
+	ctx.NotifyCoercion(value, dst.Type())
+	setFloatFromString(ctx, value, dst)
 	return dst
 }
 
@@ -228,31 +242,42 @@ func generateIntBuilder(ctx *Context) Builder { return globalIntBuilder }
 func (_this *intBuilder) String() string      { return reflect.TypeOf(_this).String() }
 
 func (_this *intBuilder) BuildFromInt(ctx *Context, value int64, dst reflect.Value) reflect.Value {
-	setIntFromInt(value, dst)
+	setIntFromInt(ctx, value, dst)
 	return dst
 }
 func (_this *intBuilder) BuildFromUint(ctx *Context, value uint64, dst reflect.Value) reflect.Value {
-	setIntFromUint(value, dst)
+	setIntFromUint(ctx, value, dst)
 	return dst
 }
 func (_this *intBuilder) BuildFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) reflect.Value {
-	setIntFromBigInt(value, dst)
+	setIntFromBigInt(ctx, value, dst)
 	return dst
 }
 func (_this *intBuilder) BuildFromFloat(ctx *Context, value float64, dst reflect.Value) reflect.Value {
-	setIntFromFloat(value, dst)
+	setIntFromFloat(ctx, value, dst)
 	return dst
 }
 func (_this *intBuilder) BuildFromDecimalFloat(ctx *Context, value compact_float.DFloat, dst reflect.Value) reflect.Value {
-	setIntFromDecimalFloat(value, dst)
+	setIntFromDecimalFloat(ctx, value, dst)
 	return dst
 }
 func (_this *intBuilder) BuildFromBigFloat(ctx *Context, value *big.Float, dst reflect.Value) reflect.Value {
-	setIntFromBigFloat(value, dst)
+	setIntFromBigFloat(ctx, value, dst)
 	return dst
 }
 func (_this *intBuilder) BuildFromBigDecimalFloat(ctx *Context, value *apd.Decimal, dst reflect.Value) reflect.Value {
-	setIntFromBigDecimalFloat(value, dst)
+	setIntFromBigDecimalFloat(ctx, value, dst)
+	return dst
+}
+func (_this *intBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	return _this.BuildFromStringlikeArray(ctx, arrayType, string(value), dst)
+}
+func (_this *intBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, dst reflect.Value) reflect.Value {
+	if arrayType != events.ArrayTypeString {
+		PanicBadEvent(_this, "BuildFromStringlikeArray(%v)", arrayType)
+	}
+	ctx.NotifyCoercion(value, dst.Type())
+	setIntFromString(ctx, value, dst)
 	return dst
 }
 
@@ -395,30 +420,79 @@ func generateUintBuilder(ctx *Context) Builder { return globalUintBuilder }
 func (_this *uintBuilder) String() string      { return reflect.TypeOf(_this).String() }
 
 func (_this *uintBuilder) BuildFromInt(ctx *Context, value int64, dst reflect.Value) reflect.Value {
-	setUintFromInt(value, dst)
+	setUintFromInt(ctx, value, dst)
 	return dst
 }
 func (_this *uintBuilder) BuildFromUint(ctx *Context, value uint64, dst reflect.Value) reflect.Value {
-	setUintFromUint(value, dst)
+	setUintFromUint(ctx, value, dst)
 	return dst
 }
 func (_this *uintBuilder) BuildFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) reflect.Value {
-	setUintFromBigInt(value, dst)
+	setUintFromBigInt(ctx, value, dst)
 	return dst
 }
 func (_this *uintBuilder) BuildFromFloat(ctx *Context, value float64, dst reflect.Value) reflect.Value {
-	setUintFromFloat(value, dst)
+	setUintFromFloat(ctx, value, dst)
 	return dst
 }
 func (_this *uintBuilder) BuildFromDecimalFloat(ctx *Context, value compact_float.DFloat, dst reflect.Value) reflect.Value {
-	setUintFromDecimalFloat(value, dst)
+	setUintFromDecimalFloat(ctx, value, dst)
 	return dst
 }
 func (_this *uintBuilder) BuildFromBigFloat(ctx *Context, value *big.Float, dst reflect.Value) reflect.Value {
-	setUintFromBigFloat(value, dst)
+	setUintFromBigFloat(ctx, value, dst)
 	return dst
 }
 func (_this *uintBuilder) BuildFromBigDecimalFloat(ctx *Context, value *apd.Decimal, dst reflect.Value) reflect.Value {
-	setUintFromBigDecimalFloat(value, dst)
+	setUintFromBigDecimalFloat(ctx, value, dst)
+	return dst
+}
+func (_this *uintBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	return _this.BuildFromStringlikeArray(ctx, arrayType, string(value), dst)
+}
+func (_this *uintBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, dst reflect.Value) reflect.Value {
+	if arrayType != events.ArrayTypeString {
+		PanicBadEvent(_this, "BuildFromStringlikeArray(%v)", arrayType)
+	}
+	ctx.NotifyCoercion(value, dst.Type())
+	setUintFromString(ctx, value, dst)
+	return dst
+}
+
+// ============================================================================
+
+type numberBuilder struct{}
+
+var globalNumberBuilder = &numberBuilder{}
+
+func generateNumberBuilder(ctx *Context) Builder { return globalNumberBuilder }
+func (_this *numberBuilder) String() string      { return reflect.TypeOf(_this).String() }
+
+func (_this *numberBuilder) BuildFromInt(ctx *Context, value int64, dst reflect.Value) reflect.Value {
+	dst.SetString(strconv.FormatInt(value, 10))
+	return dst
+}
+func (_this *numberBuilder) BuildFromUint(ctx *Context, value uint64, dst reflect.Value) reflect.Value {
+	dst.SetString(strconv.FormatUint(value, 10))
+	return dst
+}
+func (_this *numberBuilder) BuildFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) reflect.Value {
+	dst.SetString(value.String())
+	return dst
+}
+func (_this *numberBuilder) BuildFromFloat(ctx *Context, value float64, dst reflect.Value) reflect.Value {
+	dst.SetString(strconv.FormatFloat(value, 'g', -1, 64))
+	return dst
+}
+func (_this *numberBuilder) BuildFromBigFloat(ctx *Context, value *big.Float, dst reflect.Value) reflect.Value {
+	dst.SetString(value.Text('g', -1))
+	return dst
+}
+func (_this *numberBuilder) BuildFromDecimalFloat(ctx *Context, value compact_float.DFloat, dst reflect.Value) reflect.Value {
+	dst.SetString(value.String())
+	return dst
+}
+func (_this *numberBuilder) BuildFromBigDecimalFloat(ctx *Context, value *apd.Decimal, dst reflect.Value) reflect.Value {
+	dst.SetString(value.String())
 	return dst
 }
@@ -0,0 +1,239 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package builder
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/kstenerud/go-concise-encoding/events"
+	"github.com/kstenerud/go-concise-encoding/internal/common"
+	"github.com/kstenerud/go-concise-encoding/types"
+
+	"github.com/cockroachdb/apd/v2"
+	"github.com/kstenerud/go-compact-float"
+	"github.com/kstenerud/go-compact-time"
+	"github.com/kstenerud/go-describe"
+)
+
+// Builds a map into a types.OrderedMap, preserving the order of its keys.
+// Keys and values are built as if their destinations were interface{}.
+type orderedMapBuilder struct {
+	container    *types.OrderedMap
+	key          reflect.Value
+	pendingKey   *pendingMapKey
+	builderIndex int
+}
+
+func generateOrderedMapBuilder(ctx *Context) Builder {
+	return &orderedMapBuilder{
+		container: types.NewOrderedMap(),
+	}
+}
+
+func (_this *orderedMapBuilder) String() string { return reflect.TypeOf(_this).String() }
+
+func (_this *orderedMapBuilder) store(value reflect.Value) {
+	if _this.builderIndex == kvBuilderKey {
+		_this.key = value
+		_this.pendingKey = nil
+	} else {
+		_this.entrySetter()(value.Interface())
+	}
+	_this.builderIndex = (_this.builderIndex + 1) & 1
+}
+
+// Get a function that stores a value under the current key. If the key is a
+// reference that hasn't been resolved yet, storing is deferred until it is
+// (which also means that the entry is added to the map at that point).
+func (_this *orderedMapBuilder) entrySetter() func(value interface{}) {
+	container := _this.container
+	key := _this.key
+	if pendingKey := _this.pendingKey; pendingKey != nil {
+		return func(value interface{}) {
+			pendingKey.setEntry(func() {
+				container.Set(key.Interface(), value)
+			})
+		}
+	}
+	return func(value interface{}) {
+		container.Set(key.Interface(), value)
+	}
+}
+
+func (_this *orderedMapBuilder) newElem() reflect.Value {
+	return reflect.New(common.TypeInterface).Elem()
+}
+
+func (_this *orderedMapBuilder) next(ctx *Context) Builder {
+	return ctx.GetBuilderGeneratorForType(common.TypeInterface)(ctx)
+}
+
+func (_this *orderedMapBuilder) describePathElement(_ bool) string {
+	if _this.builderIndex == kvBuilderValue {
+		return fmt.Sprintf("[%v]", describe.D(_this.key.Interface()))
+	}
+	return ""
+}
+
+func (_this *orderedMapBuilder) BuildFromNil(ctx *Context, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromNil(ctx, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromBool(ctx *Context, value bool, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromBool(ctx, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromInt(ctx *Context, value int64, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromInt(ctx, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromUint(ctx *Context, value uint64, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromUint(ctx, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromBigInt(ctx *Context, value *big.Int, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromBigInt(ctx, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromFloat(ctx *Context, value float64, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromFloat(ctx, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromBigFloat(ctx *Context, value *big.Float, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromBigFloat(ctx, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromDecimalFloat(ctx *Context, value compact_float.DFloat, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromDecimalFloat(ctx, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromBigDecimalFloat(ctx *Context, value *apd.Decimal, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromBigDecimalFloat(ctx, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromUUID(ctx *Context, value []byte, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromUUID(ctx, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromArray(ctx, arrayType, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromStringlikeArray(ctx, arrayType, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromTime(ctx *Context, value time.Time, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromTime(ctx, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildFromCompactTime(ctx *Context, value compact_time.Time, _ reflect.Value) reflect.Value {
+	object := _this.newElem()
+	_this.next(ctx).BuildFromCompactTime(ctx, value, object)
+	_this.store(object)
+	return object
+}
+
+func (_this *orderedMapBuilder) BuildInitiateList(ctx *Context) {
+	_this.next(ctx).BuildBeginListContents(ctx)
+}
+
+func (_this *orderedMapBuilder) BuildInitiateMap(ctx *Context) {
+	_this.next(ctx).BuildBeginMapContents(ctx)
+}
+
+func (_this *orderedMapBuilder) BuildEndContainer(ctx *Context) {
+	// Pass an addressable value so that pointer builders can take its address.
+	ctx.UnstackBuilderAndNotifyChildFinished(reflect.ValueOf(_this.container).Elem())
+}
+
+func (_this *orderedMapBuilder) BuildBeginMapContents(ctx *Context) {
+	ctx.StackBuilder(_this)
+}
+
+func (_this *orderedMapBuilder) BuildFromReference(ctx *Context, id interface{}) {
+	if _this.builderIndex == kvBuilderKey {
+		key := _this.newElem()
+		pendingKey := &pendingMapKey{}
+		_this.key = key
+		_this.pendingKey = pendingKey
+		_this.builderIndex = kvBuilderValue
+		ctx.NotifyReference(id, func(object reflect.Value) {
+			setFromReferencedObject(ctx, object, key)
+			pendingKey.resolve()
+		})
+		return
+	}
+
+	setEntry := _this.entrySetter()
+	// Reserve the key's position in case the reference is resolved later.
+	setEntry(nil)
+	_this.builderIndex = kvBuilderKey
+	ctx.NotifyReference(id, func(object reflect.Value) {
+		setEntry(object.Interface())
+	})
+}
+
+func (_this *orderedMapBuilder) NotifyChildContainerFinished(ctx *Context, value reflect.Value) {
+	_this.store(value)
+}
@@ -0,0 +1,168 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package builder
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/kstenerud/go-concise-encoding/events"
+
+	"github.com/cockroachdb/apd/v2"
+	"github.com/kstenerud/go-compact-float"
+	"github.com/kstenerud/go-compact-time"
+)
+
+// Builds a map destined for an interface. If the map's first entry is a type
+// discriminator naming a registered polymorphic type, the rest of the map is
+// built as that type. Otherwise it's built as a generic map.
+type polymorphicBuilder struct {
+	state        polymorphicBuilderState
+	concreteType reflect.Type
+}
+
+type polymorphicBuilderState int
+
+const (
+	polymorphicAwaitingKey polymorphicBuilderState = iota
+	polymorphicAwaitingName
+	polymorphicBuildingConcreteType
+)
+
+func newPolymorphicBuilder() *polymorphicBuilder {
+	return &polymorphicBuilder{}
+}
+
+func (_this *polymorphicBuilder) String() string { return reflect.TypeOf(_this).String() }
+
+// Replace this builder with a generic map builder, returning it so that the
+// current event can be passed on.
+func (_this *polymorphicBuilder) fallBackToGenericMap(ctx *Context) Builder {
+	if _this.state != polymorphicAwaitingKey {
+		panic(fmt.Errorf("type discriminator %v must be a string", ctx.polymorphicTypeKey))
+	}
+	ctx.UnstackBuilder()
+	builder := generateInterfaceMapBuilder(ctx)
+	builder.BuildBeginMapContents(ctx)
+	return builder
+}
+
+func (_this *polymorphicBuilder) beginConcreteType(ctx *Context, name string) {
+	concreteType, ok := ctx.polymorphicTypes[name]
+	if !ok {
+		panic(fmt.Errorf("%v: unknown polymorphic type name", name))
+	}
+	_this.concreteType = concreteType
+	_this.state = polymorphicBuildingConcreteType
+	structType := concreteType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	ctx.GetBuilderGeneratorForType(structType)(ctx).BuildBeginMapContents(ctx)
+}
+
+func (_this *polymorphicBuilder) BuildFromBool(ctx *Context, value bool, dst reflect.Value) reflect.Value {
+	return _this.fallBackToGenericMap(ctx).BuildFromBool(ctx, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildFromInt(ctx *Context, value int64, dst reflect.Value) reflect.Value {
+	return _this.fallBackToGenericMap(ctx).BuildFromInt(ctx, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildFromUint(ctx *Context, value uint64, dst reflect.Value) reflect.Value {
+	return _this.fallBackToGenericMap(ctx).BuildFromUint(ctx, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) reflect.Value {
+	return _this.fallBackToGenericMap(ctx).BuildFromBigInt(ctx, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildFromFloat(ctx *Context, value float64, dst reflect.Value) reflect.Value {
+	return _this.fallBackToGenericMap(ctx).BuildFromFloat(ctx, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildFromBigFloat(ctx *Context, value *big.Float, dst reflect.Value) reflect.Value {
+	return _this.fallBackToGenericMap(ctx).BuildFromBigFloat(ctx, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildFromDecimalFloat(ctx *Context, value compact_float.DFloat, dst reflect.Value) reflect.Value {
+	return _this.fallBackToGenericMap(ctx).BuildFromDecimalFloat(ctx, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildFromBigDecimalFloat(ctx *Context, value *apd.Decimal, dst reflect.Value) reflect.Value {
+	return _this.fallBackToGenericMap(ctx).BuildFromBigDecimalFloat(ctx, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildFromUUID(ctx *Context, value []byte, dst reflect.Value) reflect.Value {
+	return _this.fallBackToGenericMap(ctx).BuildFromUUID(ctx, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	if arrayType == events.ArrayTypeString {
+		return _this.BuildFromStringlikeArray(ctx, arrayType, string(value), dst)
+	}
+	return _this.fallBackToGenericMap(ctx).BuildFromArray(ctx, arrayType, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, dst reflect.Value) reflect.Value {
+	if arrayType == events.ArrayTypeString {
+		switch _this.state {
+		case polymorphicAwaitingKey:
+			if value == ctx.polymorphicTypeKey {
+				_this.state = polymorphicAwaitingName
+				return dst
+			}
+		case polymorphicAwaitingName:
+			_this.beginConcreteType(ctx, value)
+			return dst
+		}
+	}
+	return _this.fallBackToGenericMap(ctx).BuildFromStringlikeArray(ctx, arrayType, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildFromTime(ctx *Context, value time.Time, dst reflect.Value) reflect.Value {
+	return _this.fallBackToGenericMap(ctx).BuildFromTime(ctx, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildFromCompactTime(ctx *Context, value compact_time.Time, dst reflect.Value) reflect.Value {
+	return _this.fallBackToGenericMap(ctx).BuildFromCompactTime(ctx, value, dst)
+}
+
+func (_this *polymorphicBuilder) BuildEndContainer(ctx *Context) {
+	_this.fallBackToGenericMap(ctx).BuildEndContainer(ctx)
+}
+
+func (_this *polymorphicBuilder) NotifyChildContainerFinished(ctx *Context, value reflect.Value) {
+	if _this.concreteType.Kind() == reflect.Ptr {
+		if value.CanAddr() {
+			// Keep the built struct itself so that any references into it
+			// that are resolved later refer to the same object.
+			value = value.Addr()
+		} else {
+			ptr := reflect.New(value.Type())
+			ptr.Elem().Set(value)
+			value = ptr
+		}
+	}
+	ctx.UnstackBuilderAndNotifyChildFinished(value)
+}
@@ -163,5 +163,10 @@ func (_this *ptrBuilder) BuildBeginMapContents(ctx *Context) {
 }
 
 func (_this *ptrBuilder) NotifyChildContainerFinished(ctx *Context, value reflect.Value) {
+	if !value.CanAddr() {
+		ptr := _this.newElem()
+		ptr.Elem().Set(value)
+		value = ptr.Elem()
+	}
 	ctx.UnstackBuilderAndNotifyChildFinished(value.Addr())
 }
@@ -36,9 +36,14 @@ import (
 const defaultSliceCap = 4
 
 type sliceBuilder struct {
+	singleValueSliceBuilder
 	dstType       reflect.Type
 	elemGenerator BuilderGenerator
 	ppContainer   **reflect.Value
+
+	// False if this builder is building a single value into a slice rather
+	// than building the contents of a list.
+	isContainer bool
 }
 
 func newSliceBuilderGenerator(getBuilderGeneratorForType BuilderGeneratorGetter, dstType reflect.Type) BuilderGenerator {
@@ -72,6 +77,10 @@ func (_this *sliceBuilder) storeValue(value reflect.Value) {
 	**_this.ppContainer = reflect.Append(**_this.ppContainer, value)
 }
 
+func (_this *sliceBuilder) describePathElement(_ bool) string {
+	return fmt.Sprintf("[%v]", (**_this.ppContainer).Len())
+}
+
 func (_this *sliceBuilder) BuildFromNil(ctx *Context, _ reflect.Value) reflect.Value {
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromNil(ctx, object)
@@ -79,91 +88,130 @@ func (_this *sliceBuilder) BuildFromNil(ctx *Context, _ reflect.Value) reflect.V
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromBool(ctx *Context, value bool, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromBool(ctx *Context, value bool, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromBool(ctx, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromBool(ctx, value, object)
 	_this.storeValue(object)
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromInt(ctx *Context, value int64, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromInt(ctx *Context, value int64, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromInt(ctx, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromInt(ctx, value, object)
 	_this.storeValue(object)
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromUint(ctx *Context, value uint64, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromUint(ctx *Context, value uint64, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromUint(ctx, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromUint(ctx, value, object)
 	_this.storeValue(object)
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromBigInt(ctx *Context, value *big.Int, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromBigInt(ctx, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromBigInt(ctx, value, object)
 	_this.storeValue(object)
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromFloat(ctx *Context, value float64, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromFloat(ctx *Context, value float64, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromFloat(ctx, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromFloat(ctx, value, object)
 	_this.storeValue(object)
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromBigFloat(ctx *Context, value *big.Float, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromBigFloat(ctx *Context, value *big.Float, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromBigFloat(ctx, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromBigFloat(ctx, value, object)
 	_this.storeValue(object)
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromDecimalFloat(ctx *Context, value compact_float.DFloat, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromDecimalFloat(ctx *Context, value compact_float.DFloat, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromDecimalFloat(ctx, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromDecimalFloat(ctx, value, object)
 	_this.storeValue(object)
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromBigDecimalFloat(ctx *Context, value *apd.Decimal, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromBigDecimalFloat(ctx *Context, value *apd.Decimal, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromBigDecimalFloat(ctx, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromBigDecimalFloat(ctx, value, object)
 	_this.storeValue(object)
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromUUID(ctx *Context, value []byte, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromUUID(ctx *Context, value []byte, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromUUID(ctx, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromUUID(ctx, value, object)
 	_this.storeValue(object)
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromArray(ctx, arrayType, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromArray(ctx, arrayType, value, object)
 	_this.storeValue(object)
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromStringlikeArray(ctx, arrayType, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromStringlikeArray(ctx, arrayType, value, object)
 	_this.storeValue(object)
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromTime(ctx *Context, value time.Time, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromTime(ctx *Context, value time.Time, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromTime(ctx, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromTime(ctx, value, object)
 	_this.storeValue(object)
 	return object
 }
 
-func (_this *sliceBuilder) BuildFromCompactTime(ctx *Context, value compact_time.Time, _ reflect.Value) reflect.Value {
+func (_this *sliceBuilder) BuildFromCompactTime(ctx *Context, value compact_time.Time, dst reflect.Value) reflect.Value {
+	if !_this.isContainer {
+		return _this.singleValueSliceBuilder.BuildFromCompactTime(ctx, value, dst)
+	}
 	object := _this.newElem()
 	_this.elemGenerator(ctx).BuildFromCompactTime(ctx, value, object)
 	_this.storeValue(object)
@@ -185,6 +233,7 @@ func (_this *sliceBuilder) BuildEndContainer(ctx *Context) {
 }
 
 func (_this *sliceBuilder) BuildBeginListContents(ctx *Context) {
+	_this.isContainer = true
 	ctx.StackBuilder(_this)
 }
 
@@ -194,10 +243,103 @@ func (_this *sliceBuilder) BuildFromReference(ctx *Context, id interface{}) {
 	elem := _this.newElem()
 	_this.storeValue(elem)
 	ctx.NotifyReference(id, func(object reflect.Value) {
-		setAnythingFromAnything(object, (**ppContainer).Index(index))
+		setFromReferencedObject(ctx, object, (**ppContainer).Index(index))
 	})
 }
 
 func (_this *sliceBuilder) NotifyChildContainerFinished(ctx *Context, value reflect.Value) {
 	_this.storeValue(value)
 }
+
+// ============================================================================
+
+// Builds a one-element slice from a single (non-list) value. This is a lenient
+// coercion, and so will panic unless lenient coercion is allowed.
+// Slice builders embed this to handle single values.
+type singleValueSliceBuilder struct{}
+
+func (_this *singleValueSliceBuilder) beginSlice(ctx *Context, value interface{}, dst reflect.Value) (Builder, reflect.Value) {
+	ctx.NotifyCoercion(value, dst.Type())
+	slice := reflect.MakeSlice(dst.Type(), 1, 1)
+	dst.Set(slice)
+	elem := slice.Index(0)
+	return ctx.GetBuilderGeneratorForType(elem.Type())(ctx), elem
+}
+
+func (_this *singleValueSliceBuilder) BuildFromBool(ctx *Context, value bool, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromBool(ctx, value, elem)
+	return dst
+}
+
+func (_this *singleValueSliceBuilder) BuildFromInt(ctx *Context, value int64, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromInt(ctx, value, elem)
+	return dst
+}
+
+func (_this *singleValueSliceBuilder) BuildFromUint(ctx *Context, value uint64, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromUint(ctx, value, elem)
+	return dst
+}
+
+func (_this *singleValueSliceBuilder) BuildFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromBigInt(ctx, value, elem)
+	return dst
+}
+
+func (_this *singleValueSliceBuilder) BuildFromFloat(ctx *Context, value float64, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromFloat(ctx, value, elem)
+	return dst
+}
+
+func (_this *singleValueSliceBuilder) BuildFromBigFloat(ctx *Context, value *big.Float, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromBigFloat(ctx, value, elem)
+	return dst
+}
+
+func (_this *singleValueSliceBuilder) BuildFromDecimalFloat(ctx *Context, value compact_float.DFloat, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromDecimalFloat(ctx, value, elem)
+	return dst
+}
+
+func (_this *singleValueSliceBuilder) BuildFromBigDecimalFloat(ctx *Context, value *apd.Decimal, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromBigDecimalFloat(ctx, value, elem)
+	return dst
+}
+
+func (_this *singleValueSliceBuilder) BuildFromUUID(ctx *Context, value []byte, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromUUID(ctx, value, elem)
+	return dst
+}
+
+func (_this *singleValueSliceBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromArray(ctx, arrayType, value, elem)
+	return dst
+}
+
+func (_this *singleValueSliceBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromStringlikeArray(ctx, arrayType, value, elem)
+	return dst
+}
+
+func (_this *singleValueSliceBuilder) BuildFromTime(ctx *Context, value time.Time, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromTime(ctx, value, elem)
+	return dst
+}
+
+func (_this *singleValueSliceBuilder) BuildFromCompactTime(ctx *Context, value compact_time.Time, dst reflect.Value) reflect.Value {
+	builder, elem := _this.beginSlice(ctx, value, dst)
+	builder.BuildFromCompactTime(ctx, value, elem)
+	return dst
+}
@@ -24,7 +24,9 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kstenerud/go-concise-encoding/events"
@@ -36,11 +38,21 @@ import (
 )
 
 type structBuilderField struct {
-	Name      string
-	Index     int
-	Omit      bool
-	OmitEmpty bool
-	OmitValue string
+	Name         string
+	Index        int
+	Omit         bool
+	OmitEmpty    bool
+	OmitValue    string
+	Required     bool
+	HasDefault   bool
+	DefaultValue string
+	HasID        bool
+	ID           uint64
+	AsString     bool
+
+	defaultOnce  sync.Once
+	defaultValue reflect.Value
+	defaultError error
 }
 
 func (_this *structBuilderField) applyTags(tags string) {
@@ -54,8 +66,8 @@ func (_this *structBuilderField) applyTags(tags string) {
 		}
 	}
 
-	for _, entry := range strings.Split(tags, ",") {
-		kv := strings.Split(entry, "=")
+	for _, entry := range common.SplitCETag(tags) {
+		kv := strings.SplitN(entry, "=", 2)
 		switch strings.TrimSpace(kv[0]) {
 		// TODO: lossy/nolossy
 		// TODO: lowercase/origcase
@@ -73,20 +85,102 @@ func (_this *structBuilderField) applyTags(tags string) {
 		case "name":
 			requiresValue(kv, "name")
 			_this.Name = strings.TrimSpace(kv[1])
+		case "id":
+			requiresValue(kv, "id")
+			id, err := strconv.ParseUint(strings.TrimSpace(kv[1]), 0, 64)
+			if err != nil {
+				panic(fmt.Errorf(`tag key "id" requires a non-negative integer value, not "%v"`, strings.TrimSpace(kv[1])))
+			}
+			_this.HasID = true
+			_this.ID = id
+		case "required":
+			_this.Required = true
+		case "default":
+			requiresValue(kv, "default")
+			_this.HasDefault = true
+			_this.DefaultValue = strings.TrimSpace(kv[1])
 		default:
 			panic(fmt.Errorf("%v: Unknown Concise Encoding struct tag field", entry))
 		}
 	}
+
+	if _this.Required && _this.HasDefault {
+		panic(fmt.Errorf("field %v: a required field cannot have a default value", _this.Name))
+	}
+}
+
+// Apply tags in the format used by encoding/json
+func (_this *structBuilderField) applyJSONTags(tags string) {
+	if tags == "" {
+		return
+	}
+	if tags == "-" {
+		_this.Omit = true
+		return
+	}
+
+	entries := strings.Split(tags, ",")
+	if entries[0] != "" {
+		_this.Name = entries[0]
+	}
+	for _, entry := range entries[1:] {
+		switch entry {
+		case "omitempty":
+			_this.OmitEmpty = true
+		case "string":
+			_this.AsString = true
+		}
+	}
+}
+
+// Get the default value for this field, built from its CTE literal. Values of
+// immutable kinds are built once and cached; anything that could share
+// underlying memory is built anew on each call.
+func (_this *structBuilderField) getDefaultValue(session *Session, dstType reflect.Type) reflect.Value {
+	if !isImmutableKind(dstType.Kind()) {
+		value, err := buildFromLiteral(session, dstType, _this.DefaultValue)
+		_this.panicIfBadDefault(err)
+		return value
+	}
+
+	_this.defaultOnce.Do(func() {
+		_this.defaultValue, _this.defaultError = buildFromLiteral(session, dstType, _this.DefaultValue)
+	})
+	_this.panicIfBadDefault(_this.defaultError)
+	return _this.defaultValue
+}
+
+func isImmutableKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func (_this *structBuilderField) panicIfBadDefault(err error) {
+	if err != nil {
+		panic(fmt.Errorf("field %v: invalid default value [%v]: %v", _this.Name, _this.DefaultValue, err))
+	}
 }
 
 type structBuilder struct {
 	dstType                reflect.Type
+	session                *Session
 	generatorDescs         map[string]*structBuilderGeneratorDesc
+	generatorDescsByID     map[uint64]*structBuilderGeneratorDesc
+	checkedFields          []*structBuilderGeneratorDesc
+	foundFields            []bool
 	nameBuilderGenerator   BuilderGenerator
 	ignoreBuilderGenerator BuilderGenerator
 	nextBuilderGenerator   BuilderGenerator
 	container              reflect.Value
 	nextValue              reflect.Value
+	nextField              *structBuilderField
 	nextIsKey              bool
 	nextIsIgnored          bool
 }
@@ -96,23 +190,43 @@ type structBuilderGeneratorDesc struct {
 	builderGenerator BuilderGenerator
 }
 
-func newStructBuilderGenerator(getBuilderGeneratorForType BuilderGeneratorGetter, dstType reflect.Type) BuilderGenerator {
+func newStructBuilderGenerator(session *Session, dstType reflect.Type) BuilderGenerator {
+	getBuilderGeneratorForType := session.GetBuilderGeneratorForType
 	nameBuilderGenerator := getBuilderGeneratorForType(reflect.TypeOf(""))
 	ignoreBuilderGenerator := generateIgnoreBuilder
 	generatorDescs := make(map[string]*structBuilderGeneratorDesc)
+	generatorDescsByID := make(map[uint64]*structBuilderGeneratorDesc)
+	// Fields that must be checked for presence once the container ends
+	checkedFields := []*structBuilderGeneratorDesc{}
 
 	for i := 0; i < dstType.NumField(); i++ {
 		reflectField := dstType.Field(i)
 		if reflectField.PkgPath == "" {
-			builderGenerator := getBuilderGeneratorForType(reflectField.Type)
 			structField := &structBuilderField{
 				Name:  reflectField.Name,
 				Index: i,
 			}
-			structField.applyTags(reflectField.Tag.Get("ce"))
-			generatorDescs[structField.Name] = &structBuilderGeneratorDesc{
+			if tags, ok := reflectField.Tag.Lookup("ce"); ok || !session.opts.FallbackToJSONTags {
+				structField.applyTags(tags)
+			} else {
+				structField.applyJSONTags(reflectField.Tag.Get("json"))
+			}
+			if structField.Omit {
+				continue
+			}
+			desc := &structBuilderGeneratorDesc{
 				field:            structField,
-				builderGenerator: builderGenerator,
+				builderGenerator: getBuilderGeneratorForType(reflectField.Type),
+			}
+			generatorDescs[structField.Name] = desc
+			if structField.HasID {
+				if existing, exists := generatorDescsByID[structField.ID]; exists {
+					panic(fmt.Errorf("%v: fields %v and %v both have id %v", dstType, existing.field.Name, structField.Name, structField.ID))
+				}
+				generatorDescsByID[structField.ID] = desc
+			}
+			if structField.Required || structField.HasDefault {
+				checkedFields = append(checkedFields, desc)
 			}
 		}
 	}
@@ -128,7 +242,10 @@ func newStructBuilderGenerator(getBuilderGeneratorForType BuilderGeneratorGetter
 	return func(ctx *Context) Builder {
 		builder := &structBuilder{
 			dstType:                dstType,
+			session:                session,
 			generatorDescs:         generatorDescs,
+			generatorDescsByID:     generatorDescsByID,
+			checkedFields:          checkedFields,
 			nameBuilderGenerator:   nameBuilderGenerator,
 			ignoreBuilderGenerator: ignoreBuilderGenerator,
 		}
@@ -145,14 +262,112 @@ func (_this *structBuilder) reset() {
 	_this.nextBuilderGenerator = _this.nameBuilderGenerator
 	_this.container = reflect.New(_this.dstType).Elem()
 	_this.nextValue = reflect.Value{}
+	_this.nextField = nil
 	_this.nextIsKey = true
 	_this.nextIsIgnored = false
+	if len(_this.checkedFields) > 0 {
+		_this.foundFields = make([]bool, _this.dstType.NumField())
+	}
+}
+
+func (_this *structBuilder) beginField(desc *structBuilderGeneratorDesc) {
+	_this.nextIsIgnored = false
+	_this.nextBuilderGenerator = desc.builderGenerator
+	_this.nextValue = _this.container.Field(desc.field.Index)
+	_this.nextField = desc.field
+	if _this.foundFields != nil {
+		_this.foundFields[desc.field.Index] = true
+	}
+}
+
+func (_this *structBuilder) beginFieldByID(id uint64) {
+	if generatorDesc, ok := _this.generatorDescsByID[id]; ok {
+		_this.beginField(generatorDesc)
+	} else {
+		_this.ignoreNextField()
+	}
+}
+
+func (_this *structBuilder) ignoreNextField() {
+	_this.nextBuilderGenerator = _this.ignoreBuilderGenerator
+	_this.nextField = nil
+	_this.nextIsIgnored = true
+}
+
+// Build a boolean or numeric field from its string representation (as per
+// the json "string" tag option). Returns false if the field isn't eligible.
+func (_this *structBuilder) tryBuildFromStringifiedValue(ctx *Context, value string) bool {
+	if _this.nextField == nil || !_this.nextField.AsString {
+		return false
+	}
+
+	dst := _this.nextValue
+	builder := _this.nextBuilderGenerator(ctx)
+	var err error
+	switch dst.Kind() {
+	case reflect.Bool:
+		var v bool
+		if v, err = strconv.ParseBool(value); err == nil {
+			builder.BuildFromBool(ctx, v, dst)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		if v, err = strconv.ParseInt(value, 10, 64); err == nil {
+			builder.BuildFromInt(ctx, v, dst)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var v uint64
+		if v, err = strconv.ParseUint(value, 10, 64); err == nil {
+			builder.BuildFromUint(ctx, v, dst)
+		}
+	case reflect.Float32, reflect.Float64:
+		var v float64
+		if v, err = strconv.ParseFloat(value, 64); err == nil {
+			builder.BuildFromFloat(ctx, v, dst)
+		}
+	default:
+		return false
+	}
+
+	if err != nil {
+		PanicErrorConverting(value, dst.Type(), err)
+	}
+	return true
+}
+
+// Fill in default values for absent fields, and report all absent required
+// fields in a single error.
+func (_this *structBuilder) applyFieldRules() {
+	var missingFields []string
+	for _, desc := range _this.checkedFields {
+		field := desc.field
+		if _this.foundFields[field.Index] {
+			continue
+		}
+		if field.Required {
+			missingFields = append(missingFields, field.Name)
+			continue
+		}
+		dst := _this.container.Field(field.Index)
+		dst.Set(field.getDefaultValue(_this.session, dst.Type()))
+	}
+
+	if len(missingFields) > 0 {
+		panic(fmt.Errorf("%v: missing required fields: %v", _this.dstType, strings.Join(missingFields, ", ")))
+	}
 }
 
 func (_this *structBuilder) swapKeyValue() {
 	_this.nextIsKey = !_this.nextIsKey
 }
 
+func (_this *structBuilder) describePathElement(_ bool) string {
+	if _this.nextIsKey || _this.nextField == nil {
+		return ""
+	}
+	return "." + _this.nextField.Name
+}
+
 func (_this *structBuilder) BuildFromNil(ctx *Context, _ reflect.Value) reflect.Value {
 	_this.nextBuilderGenerator(ctx).BuildFromNil(ctx, _this.nextValue)
 	object := _this.nextValue
@@ -168,6 +383,15 @@ func (_this *structBuilder) BuildFromBool(ctx *Context, value bool, _ reflect.Va
 }
 
 func (_this *structBuilder) BuildFromInt(ctx *Context, value int64, _ reflect.Value) reflect.Value {
+	if _this.nextIsKey {
+		if value < 0 {
+			_this.ignoreNextField()
+		} else {
+			_this.beginFieldByID(uint64(value))
+		}
+		_this.swapKeyValue()
+		return reflect.ValueOf(value)
+	}
 	_this.nextBuilderGenerator(ctx).BuildFromInt(ctx, value, _this.nextValue)
 	object := _this.nextValue
 	_this.swapKeyValue()
@@ -175,6 +399,11 @@ func (_this *structBuilder) BuildFromInt(ctx *Context, value int64, _ reflect.Va
 }
 
 func (_this *structBuilder) BuildFromUint(ctx *Context, value uint64, _ reflect.Value) reflect.Value {
+	if _this.nextIsKey {
+		_this.beginFieldByID(value)
+		_this.swapKeyValue()
+		return reflect.ValueOf(value)
+	}
 	_this.nextBuilderGenerator(ctx).BuildFromUint(ctx, value, _this.nextValue)
 	object := _this.nextValue
 	_this.swapKeyValue()
@@ -224,26 +453,13 @@ func (_this *structBuilder) BuildFromUUID(ctx *Context, value []byte, _ reflect.
 }
 
 func (_this *structBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, _ reflect.Value) reflect.Value {
-	switch arrayType {
-	case events.ArrayTypeString:
-		if _this.nextIsKey {
-			if ctx.Options.CaseInsensitiveStructFieldNames {
-				common.ASCIIBytesToLower(value)
-			}
-			name := string(value)
-
-			if generatorDesc, ok := _this.generatorDescs[name]; ok {
-				_this.nextBuilderGenerator = generatorDesc.builderGenerator
-				_this.nextValue = _this.container.Field(generatorDesc.field.Index)
-			} else {
-				_this.nextBuilderGenerator = _this.ignoreBuilderGenerator
-				_this.nextIsIgnored = true
-				break
-			}
-		} else {
-			_this.nextBuilderGenerator(ctx).BuildFromArray(ctx, arrayType, value, _this.nextValue)
+	if arrayType == events.ArrayTypeString && _this.nextIsKey {
+		if ctx.Options.CaseInsensitiveStructFieldNames {
+			common.ASCIIBytesToLower(value)
 		}
-	default:
+		return _this.beginFieldByName(string(value))
+	}
+	if arrayType != events.ArrayTypeString || !_this.tryBuildFromStringifiedValue(ctx, string(value)) {
 		_this.nextBuilderGenerator(ctx).BuildFromArray(ctx, arrayType, value, _this.nextValue)
 	}
 	object := _this.nextValue
@@ -252,27 +468,13 @@ func (_this *structBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayT
 }
 
 func (_this *structBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, _ reflect.Value) reflect.Value {
-	switch arrayType {
-	case events.ArrayTypeString:
-		if _this.nextIsKey {
-			if ctx.Options.CaseInsensitiveStructFieldNames {
-				bytes := []byte(value)
-				common.ASCIIBytesToLower(bytes)
-				value = string(bytes)
-			}
-
-			if generatorDesc, ok := _this.generatorDescs[value]; ok {
-				_this.nextBuilderGenerator = generatorDesc.builderGenerator
-				_this.nextValue = _this.container.Field(generatorDesc.field.Index)
-			} else {
-				_this.nextBuilderGenerator = _this.ignoreBuilderGenerator
-				_this.nextIsIgnored = true
-				break
-			}
-		} else {
-			_this.nextBuilderGenerator(ctx).BuildFromStringlikeArray(ctx, arrayType, value, _this.nextValue)
+	if arrayType == events.ArrayTypeString && _this.nextIsKey {
+		if ctx.Options.CaseInsensitiveStructFieldNames {
+			value = common.ASCIIToLower(value)
 		}
-	default:
+		return _this.beginFieldByName(value)
+	}
+	if arrayType != events.ArrayTypeString || !_this.tryBuildFromStringifiedValue(ctx, value) {
 		_this.nextBuilderGenerator(ctx).BuildFromStringlikeArray(ctx, arrayType, value, _this.nextValue)
 	}
 	object := _this.nextValue
@@ -280,6 +482,18 @@ func (_this *structBuilder) BuildFromStringlikeArray(ctx *Context, arrayType eve
 	return object
 }
 
+// Begin the field with the specified name, returning the name as the built
+// key (so that it can be marked and referenced).
+func (_this *structBuilder) beginFieldByName(name string) reflect.Value {
+	if generatorDesc, ok := _this.generatorDescs[name]; ok {
+		_this.beginField(generatorDesc)
+	} else {
+		_this.ignoreNextField()
+	}
+	_this.swapKeyValue()
+	return reflect.ValueOf(name)
+}
+
 func (_this *structBuilder) BuildFromTime(ctx *Context, value time.Time, _ reflect.Value) reflect.Value {
 	_this.nextBuilderGenerator(ctx).BuildFromTime(ctx, value, _this.nextValue)
 	object := _this.nextValue
@@ -303,26 +517,81 @@ func (_this *structBuilder) BuildInitiateMap(ctx *Context) {
 }
 
 func (_this *structBuilder) BuildEndContainer(ctx *Context) {
+	if _this.foundFields != nil {
+		_this.applyFieldRules()
+	}
 	object := _this.container
 	_this.reset()
 	ctx.UnstackBuilderAndNotifyChildFinished(object)
 }
 
+// Look up a field by name or ID, returning nil if no such field exists.
+func (_this *structBuilder) lookupFieldDesc(ctx *Context, key reflect.Value) *structBuilderGeneratorDesc {
+	for key.Kind() == reflect.Interface {
+		key = key.Elem()
+	}
+	switch key.Kind() {
+	case reflect.String:
+		name := key.String()
+		if ctx.Options.CaseInsensitiveStructFieldNames {
+			name = common.ASCIIToLower(name)
+		}
+		return _this.generatorDescs[name]
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if key.Int() >= 0 {
+			return _this.generatorDescsByID[uint64(key.Int())]
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return _this.generatorDescsByID[key.Uint()]
+	}
+	return nil
+}
+
 func (_this *structBuilder) BuildBeginMapContents(ctx *Context) {
 	ctx.StackBuilder(_this)
 }
 
 func (_this *structBuilder) BuildFromReference(ctx *Context, id interface{}) {
+	if _this.nextIsKey {
+		_this.beginFieldFromReference(ctx, id)
+		_this.swapKeyValue()
+		return
+	}
+
+	if _this.nextIsIgnored {
+		_this.nextIsIgnored = false
+		_this.swapKeyValue()
+		return
+	}
+
 	nextValue := _this.nextValue
 	_this.swapKeyValue()
 	ctx.NotifyReference(id, func(object reflect.Value) {
-		setAnythingFromAnything(object, nextValue)
+		setFromReferencedObject(ctx, object, nextValue)
 	})
 }
 
+// Begin the field named by a referenced key. The field must be known right
+// away, so the key's marker must come before the reference.
+func (_this *structBuilder) beginFieldFromReference(ctx *Context, id interface{}) {
+	isResolved := false
+	ctx.NotifyReference(id, func(key reflect.Value) {
+		isResolved = true
+		if desc := _this.lookupFieldDesc(ctx, key); desc != nil {
+			_this.beginField(desc)
+		} else {
+			_this.ignoreNextField()
+		}
+	})
+	if !isResolved {
+		panic(fmt.Errorf("%v: struct field name reference [%v] must refer to a previously marked name", _this.dstType, id))
+	}
+}
+
 func (_this *structBuilder) NotifyChildContainerFinished(ctx *Context, value reflect.Value) {
 	if _this.nextIsIgnored {
 		_this.nextIsIgnored = false
+		_this.swapKeyValue()
 		return
 	}
 
@@ -21,8 +21,12 @@
 package builder
 
 import (
+	"io/ioutil"
+	"math"
 	"math/big"
 	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"testing"
@@ -32,6 +36,7 @@ import (
 
 	"github.com/kstenerud/go-concise-encoding/internal/common"
 	"github.com/kstenerud/go-concise-encoding/options"
+	"github.com/kstenerud/go-concise-encoding/types"
 
 	"github.com/cockroachdb/apd/v2"
 	"github.com/kstenerud/go-compact-time"
@@ -150,6 +155,9 @@ func TestBuilderBasicTypes(t *testing.T) {
 	assertBuild(t, pBigIntN, BI(pBigIntN))
 	assertBuild(t, *pBigIntN, BI(pBigIntN))
 	assertBuild(t, (*big.Int)(nil), NA())
+	assertBuild(t, NewBigInt("18446744073709551615", 10), PI(0xffffffffffffffff))
+	assertBuild(t, *NewBigInt("18446744073709551615", 10), PI(0xffffffffffffffff))
+	assertBuild(t, NewBDF("18446744073709551615"), PI(0xffffffffffffffff))
 	assertBuild(t, float32(-1.25), F(-1.25))
 	assertBuild(t, float64(-9.5e50), F(-9.5e50))
 	assertBuild(t, pBigFloat, BF(pBigFloat))
@@ -397,6 +405,8 @@ func TestBuilderConvertToFloatFail(t *testing.T) {
 func TestBuilderConvertToInt(t *testing.T) {
 	assertBuild(t, 1, PI(1))
 	assertBuild(t, -1, NI(1))
+	assertBuild(t, int64(math.MinInt64), NI(0x8000000000000000))
+	assertBuild(t, NewBigInt("-18446744073709551615", 10), NI(0xffffffffffffffff))
 	assertBuild(t, 1, BI(NewBigInt("1", 10)))
 	assertBuild(t, 1, F(1))
 	assertBuild(t, 1, BF(NewBigFloat("1", 10, 1)))
@@ -673,6 +683,21 @@ func TestBuilderConstant(t *testing.T) {
 	assertBuildPanics(t, "a", CONST("a", false))
 }
 
+func TestBuilderConstantSubstitution(t *testing.T) {
+	opts := options.DefaultBuilderOptions()
+	opts.Constants = options.Constants{
+		"DEFAULT_TIMEOUT": 30,
+		"HOSTS":           []string{"a", "b"},
+	}
+	assertBuildWithOptions(t, opts, 30, CONST("DEFAULT_TIMEOUT", false))
+	assertBuildWithOptions(t, opts, map[string]interface{}{
+		"timeout": 30,
+		"hosts":   []interface{}{"a", "b"},
+	}, M(), S("timeout"), CONST("DEFAULT_TIMEOUT", false), S("hosts"), CONST("HOSTS", false), E())
+	assertBuildWithOptions(t, opts, []int{1, 30}, L(), PI(1), CONST("DEFAULT_TIMEOUT", false), E())
+	assertBuildPanicsWithOptions(t, opts, 1, CONST("OTHER", false))
+}
+
 func TestBuilderSlice(t *testing.T) {
 	loc, err := time.LoadLocation("Europe/Berlin")
 	if err != nil {
@@ -755,6 +780,135 @@ func TestBuilderByteArrayFail(t *testing.T) {
 	assertBuildPanics(t, [1]byte{}, E())
 }
 
+func TestBuilderUUID(t *testing.T) {
+	uuid := types.UUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	assertBuild(t, uuid, UUID(uuid[:]))
+	assertBuild(t, uuid, S("123e4567-e89b-12d3-a456-426614174000"))
+	assertBuild(t, uuid, AU8(uuid[:]))
+	assertBuild(t, []interface{}{uuid}, L(), UUID(uuid[:]), E())
+
+	assertBuildPanics(t, uuid, S("123e4567-e89b-12d3-a456"))
+	assertBuildPanics(t, uuid, AU8([]byte{1}))
+	assertBuildPanics(t, uuid, I(1))
+}
+
+type CustomUUID [16]byte
+
+func TestBuilderUUIDTypes(t *testing.T) {
+	sOpts := options.DefaultBuilderSessionOptions()
+	sOpts.UUIDTypes = []reflect.Type{reflect.TypeOf(CustomUUID{})}
+	session := NewSession(nil, sOpts)
+
+	uuid := CustomUUID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	assertBuildWithSession(t, session, uuid, UUID(uuid[:]))
+
+	sOpts.UUIDTypes = []reflect.Type{reflect.TypeOf([8]byte{})}
+	test.AssertPanics(t, "bad UUID type", func() {
+		NewSession(nil, sOpts)
+	})
+}
+
+func TestBuilderHalfFloat(t *testing.T) {
+	one16 := types.Float16FromFloat32(1)
+	oneAndHalf16 := types.Float16FromFloat32(1.5)
+	oneB16 := types.BFloat16FromFloat32(1)
+	oneAndHalfB16 := types.BFloat16FromFloat32(1.5)
+	// bfloat16 1, 1.5
+	af16 := AF16([]byte{0x80, 0x3f, 0xc0, 0x3f})
+
+	assertBuild(t, one16, F(1))
+	assertBuild(t, oneAndHalf16, DF(NewDFloat("1.5")))
+	assertBuild(t, oneB16, I(1))
+	assertBuild(t, oneAndHalfB16, BF(NewBigFloat("1.5", 10, 2)))
+
+	assertBuild(t, []types.Float16{one16, oneAndHalf16}, af16)
+	assertBuild(t, []types.Float16{one16, oneAndHalf16}, AF32([]float32{1, 1.5}))
+	assertBuild(t, []types.Float16{one16, oneAndHalf16}, L(), F(1), F(1.5), E())
+	assertBuild(t, [2]types.Float16{one16, oneAndHalf16}, af16)
+	assertBuild(t, []types.BFloat16{oneB16, oneAndHalfB16}, af16)
+	assertBuild(t, [2]types.BFloat16{oneB16, oneAndHalfB16}, L(), I(1), F(1.5), E())
+	assertBuild(t, []float32{1, 1.5}, af16)
+	assertBuild(t, [2]float32{1, 1.5}, af16)
+	assertBuild(t, []interface{}{[]types.BFloat16{oneB16, oneAndHalfB16}}, L(), af16, E())
+
+	assertBuildPanics(t, one16, F(100000))
+	assertBuildPanics(t, []types.Float16{}, AF32([]float32{100000}))
+	assertBuildPanics(t, []types.Float16{}, AI16([]int16{1}))
+	assertBuildPanics(t, one16, S("1"))
+}
+
+func TestBuilderBoolArray(t *testing.T) {
+	assertBuild(t, []bool{true, false, true}, AB(3, []byte{0x05}))
+	assertBuild(t, [9]bool{false, true, true, false, false, false, false, false, true}, AB(9, []byte{0x06, 0x01}))
+	assertBuild(t, []bool{true, false}, L(), B(true), B(false), E())
+	assertBuild(t, [2]bool{true, false}, L(), B(true), B(false), E())
+	assertBuild(t, []bool{false, true}, ABB(), AC(2, false), AD([]byte{0x02}))
+}
+
+func TestBuilderInterfaceTypedArrays(t *testing.T) {
+	uuid := types.UUID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+	assertBuild(t, []interface{}{
+		[]bool{true, false, true},
+		[]byte{1, 2},
+		[]uint16{1, 2},
+		[]uint32{1, 2},
+		[]uint64{1, 2},
+		[]int8{-1, 2},
+		[]int16{-1, 2},
+		[]int32{-1, 2},
+		[]int64{-1, 2},
+		[]types.BFloat16{types.BFloat16FromFloat32(1)},
+		[]float32{1.5, 2},
+		[]float64{1.5, 2},
+		[]types.UUID{uuid},
+	}, L(),
+		AB(3, []byte{0x05}),
+		AU8([]byte{1, 2}),
+		AU16([]uint16{1, 2}),
+		AU32([]uint32{1, 2}),
+		AU64([]uint64{1, 2}),
+		AI8([]int8{-1, 2}),
+		AI16([]int16{-1, 2}),
+		AI32([]int32{-1, 2}),
+		AI64([]int64{-1, 2}),
+		AF16([]byte{0x80, 0x3f}),
+		AF32([]float32{1.5, 2}),
+		AF64([]float64{1.5, 2}),
+		AUU(uuid[:]),
+		E())
+
+	assertBuild(t, map[interface{}]interface{}{"a": []int16{-1, 2}}, M(), S("a"), AI16([]int16{-1, 2}), E())
+}
+
+func TestBuilderInterfaceTypedArraysChunked(t *testing.T) {
+	assertBuild(t, []interface{}{
+		[]int16{-1, 2, 3},
+		[]float64{1.5},
+		[]bool{true, false},
+	}, L(),
+		AI16B(), AC(2, true), AD([]byte{0xff, 0xff, 0x02, 0x00}), AC(1, false), AD([]byte{0x03, 0x00}),
+		AF64B(), AC(1, false), AD([]byte{0, 0, 0, 0, 0, 0, 0xf8, 0x3f}),
+		ABB(), AC(2, false), AD([]byte{0x01}),
+		E())
+}
+
+func TestBuilderInterfaceTypedArraysAsInterfaceSlices(t *testing.T) {
+	opts := options.DefaultBuilderOptions()
+	opts.TypedArraysAsInterfaceSlices = true
+	builder := NewSession(nil, nil).NewBuilderFor([]interface{}{}, opts)
+	test.InvokeEvents(builder, L(), AI16([]int16{-1, 2}), AU8([]byte{1}), E())
+
+	expected := []interface{}{
+		[]interface{}{int16(-1), int16(2)},
+		[]byte{1},
+	}
+	actual := builder.GetBuiltObject()
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v but got %v", describe.D(expected), describe.D(actual))
+	}
+}
+
 func TestBuilderMap(t *testing.T) {
 	loc, err := time.LoadLocation("Europe/Berlin")
 	if err != nil {
@@ -829,7 +983,7 @@ func TestBuilderInterfaceSlice(t *testing.T) {
 		NewBigInt("100000000000000000000", 10),
 		"test",
 		NewRID("http://example.com"),
-		[]byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		types.UUID{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
 		gtime,
 		ctime,
 		[]float64{1},
@@ -1188,6 +1342,119 @@ func TestBuilderNilPURLContainer(t *testing.T) {
 		E())
 }
 
+func TestBuilderConcatenate(t *testing.T) {
+	assertBuild(t, NewRID("http://x.com/a"), CAT(), RID("http://x.com/"), S("a"))
+	assertBuild(t, *NewRID("http://x.com/1"), CAT(), RID("http://x.com/"), PI(1))
+	assertBuild(t, "http://x.com/a", CAT(), RID("http://x.com/"), S("a"))
+	assertBuild(t, interface{}(NewRID("http://x.com/a")), CAT(), RID("http://x.com/"), S("a"))
+	assertBuild(t, []*url.URL{NewRID("http://x.com/a")}, L(), CAT(), RID("http://x.com/"), S("a"), E())
+	assertBuild(t, []string{"http://x.com/1", "http://x.com/b"},
+		L(), CAT(), RID("http://x.com/"), PI(1), CAT(), RID("http://x.com/"), S("b"), E())
+	assertBuild(t, PURLContainer{NewRID("http://x.com/a")}, M(), S("URL"), CAT(), RID("http://x.com/"), S("a"), E())
+	assertBuild(t, map[string]int{"http://x.com/a": 1}, M(), CAT(), RID("http://x.com/"), S("a"), PI(1), E())
+}
+
+func TestBuilderConcatenateFail(t *testing.T) {
+	assertBuildPanics(t, NewRID("http://x.com"), CAT(), S("a"))
+	assertBuildPanics(t, NewRID("http://x.com"), CAT(), RID("http://x.com/"), NI(1))
+	assertBuildPanics(t, 1, CAT(), RID("http://x.com/"), S("a"))
+}
+
+func TestBuilderReferenceWithoutResolver(t *testing.T) {
+	assertBuildPanics(t, 1, REF(), RID("other.cte"))
+	assertBuildPanics(t, []int{}, L(), REF(), RID("other.cte"), E())
+}
+
+func TestBuilderResolveRelativeResourceID(t *testing.T) {
+	assertResolved := func(baseID, resourceID, expected string) {
+		if actual := ResolveRelativeResourceID(baseID, resourceID); actual != expected {
+			t.Errorf("Expected [%v] relative to [%v] to resolve to [%v] but got [%v]", resourceID, baseID, expected, actual)
+		}
+	}
+	assertResolved("", "a.cte", "a.cte")
+	assertResolved("main.cte", "a.cte", "a.cte")
+	assertResolved("configs/main.cte", "common/a.cte", "configs/common/a.cte")
+	assertResolved("configs/main.cte", "../a.cte", "a.cte")
+	assertResolved("configs/main.cte", "/a.cte", "/a.cte")
+	assertResolved("configs/main.cte", "http://x.com/a.cte", "http://x.com/a.cte")
+	assertResolved("http://x.com/configs/main.cte", "../a.cte", "http://x.com/a.cte")
+}
+
+func TestBuilderMemoryReferenceResolver(t *testing.T) {
+	resolver := NewMemoryReferenceResolver(map[string][]byte{
+		"configs/a.cte": []byte("c0 1"),
+	})
+	resolvedID, document, err := resolver.ResolveReference("configs/main.cte", "a.cte")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolvedID != "configs/a.cte" || string(document) != "c0 1" {
+		t.Errorf("Unexpected resolution to [%v]: [%v]", resolvedID, string(document))
+	}
+	if _, _, err = resolver.ResolveReference("", "a.cte"); err == nil {
+		t.Errorf("Expected unregistered resource ID to fail")
+	}
+}
+
+func TestBuilderFileReferenceResolver(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "references")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+	if err = os.Mkdir(filepath.Join(rootDir, "configs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(rootDir, "configs", "a.cte"), []byte("c0 1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Backslashes are only separators on Windows, but resource IDs containing
+	// them are rejected everywhere.
+	if err = ioutil.WriteFile(filepath.Join(rootDir, `configs\a.cte`), []byte("c0 1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := NewFileReferenceResolver(rootDir)
+	for _, resourceID := range []string{"configs/a.cte", "/configs/a.cte", "file:///configs/a.cte"} {
+		resolvedID, document, err := resolver.ResolveReference("", resourceID)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if resolvedID != "configs/a.cte" || string(document) != "c0 1" {
+			t.Errorf("Unexpected resolution of [%v] to [%v]: [%v]", resourceID, resolvedID, string(document))
+		}
+	}
+	if _, _, err = resolver.ResolveReference("configs/main.cte", "a.cte"); err != nil {
+		t.Error(err)
+	}
+
+	for _, resourceID := range []string{
+		"../a.cte",
+		"configs/../../a.cte",
+		"http://x.com/a.cte",
+		"configs/b.cte",
+		"..%5Ca.cte",
+		"configs%5C..%5C..%5Ca.cte",
+		"configs%5Ca.cte",
+		`configs\a.cte`,
+	} {
+		if _, _, err = resolver.ResolveReference("", resourceID); err == nil {
+			t.Errorf("Expected resolution of [%v] to fail", resourceID)
+		}
+	}
+}
+
+func TestBuilderNormalizeToNFC(t *testing.T) {
+	opts := options.DefaultBuilderOptions()
+	opts.NormalizeToNFC = true
+	assertBuildWithOptions(t, opts, "\u00e9", S("e\u0301"))
+	assertBuildWithOptions(t, opts, []interface{}{"\u00e9"}, L(), SB(), AC(3, false), AD([]byte("e\u0301")), E())
+	assertBuildWithOptions(t, opts, NewRID("http://x.com/\u00e9"), RID("http://x.com/e\u0301"))
+	assertBuildWithOptions(t, opts, map[string]int{"\u00e9": 1}, M(), S("e\u0301"), PI(1), E())
+	assertBuild(t, "e\u0301", S("e\u0301"))
+}
+
 func TestBuilderByteArrayBytes(t *testing.T) {
 	assertBuild(t, [2]byte{1, 2},
 		AU8([]byte{1, 2}))
@@ -1259,6 +1526,101 @@ func TestBuilderSelfReferential(t *testing.T) {
 		E())
 }
 
+type LinkedNode struct {
+	Value int
+	Prev  *LinkedNode
+	Next  *LinkedNode
+}
+
+func TestBuilderReferenceDoublyLinked(t *testing.T) {
+	v := runBuild(NewSession(nil, nil), &LinkedNode{},
+		MARK(), PI(0), M(),
+		S("Value"), PI(1),
+		S("Next"), M(),
+		S("Value"), PI(2),
+		S("Prev"), REF(), PI(0),
+		E(),
+		E()).(*LinkedNode)
+
+	if v.Next == nil || v.Next.Prev != v {
+		t.Errorf("Expected back pointer to refer to the first node")
+	}
+}
+
+type SharedPointers struct {
+	A     *LinkedNode
+	B     *LinkedNode
+	I1    *int
+	I2    *int
+	Any   interface{}
+	Slice *[]string
+	Refs  *[]string
+	Keys  map[*int]string
+}
+
+func TestBuilderReferenceSharedPointers(t *testing.T) {
+	v := runBuild(NewSession(nil, nil), &SharedPointers{},
+		M(),
+		S("B"), REF(), PI(0),
+		S("A"), MARK(), PI(0), M(), S("Value"), PI(1), S("Next"), REF(), PI(0), E(),
+		S("I1"), MARK(), PI(1), PI(5),
+		S("I2"), REF(), PI(1),
+		S("Any"), REF(), PI(0),
+		S("Slice"), MARK(), PI(2), L(), S("x"), E(),
+		S("Refs"), REF(), PI(2),
+		S("Keys"), M(), REF(), PI(1), S("five"), E(),
+		E()).(*SharedPointers)
+
+	if v.A == nil || v.A != v.B || v.A.Next != v.A {
+		t.Errorf("Expected struct pointers to be shared")
+	}
+	if v.I1 != v.I2 || *v.I1 != 5 {
+		t.Errorf("Expected int pointers to be shared")
+	}
+	if v.Any != interface{}(v.A) {
+		t.Errorf("Expected interface to hold the shared pointer, but got %v", v.Any)
+	}
+	if v.Slice != v.Refs {
+		t.Errorf("Expected slice pointers to be shared")
+	}
+	if len(v.Keys) != 1 {
+		t.Fatalf("Expected 1 key but got %v", v.Keys)
+	}
+	for k, value := range v.Keys {
+		if k != v.I1 || value != "five" {
+			t.Errorf("Expected map key to be the shared pointer")
+		}
+	}
+}
+
+func TestBuilderReferenceMapKey(t *testing.T) {
+	assertBuild(t, map[string]int{"a": 1, "b": 1},
+		M(), MARK(), PI(0), S("a"), MARK(), PI(1), PI(1), S("b"), REF(), PI(1), E())
+
+	// Forward references to both key and value
+	assertBuild(t, map[string]string{"a": "k", "b": "v", "k": "v"},
+		M(),
+		REF(), PI(1), REF(), PI(2),
+		S("a"), MARK(), PI(1), S("k"),
+		S("b"), MARK(), PI(2), S("v"),
+		E())
+}
+
+func TestBuilderReferenceStructKey(t *testing.T) {
+	assertBuild(t, []RefStruct{RefStruct{S1: "S2", S2: "x"}, RefStruct{S2: "y"}},
+		L(),
+		M(), S("S1"), MARK(), PI(0), S("S2"), REF(), PI(0), S("x"), E(),
+		M(), REF(), PI(0), S("y"), E(),
+		E())
+
+	// References to unknown fields are ignored
+	assertBuild(t, &RefStruct{S1: "a"},
+		M(), S("S1"), MARK(), PI(0), S("a"), S("Unknown"), REF(), PI(0), E())
+
+	// Struct keys must refer to markers that have already been seen
+	assertBuildPanics(t, &RefStruct{}, M(), REF(), PI(0), S("x"), S("S1"), MARK(), PI(0), S("S2"), E())
+}
+
 type RefStruct struct {
 	I16 int16
 	F32 float32
@@ -1280,6 +1642,124 @@ func TestBuilderRefStruct(t *testing.T) {
 		E())
 }
 
+type ConvertPoint struct {
+	X int
+	Y float32
+}
+
+type ConvertStruct struct {
+	Any    interface{}
+	AnyMap interface{}
+	Ints   []int
+	Fixed  [3]int8
+	Floats map[string]float64
+	Point  ConvertPoint
+	PPoint *ConvertPoint
+}
+
+func TestBuilderRefContainerConversion(t *testing.T) {
+	assertBuild(t, &ConvertStruct{
+		Any:    []interface{}{1, 2},
+		AnyMap: map[interface{}]interface{}{"X": 5, "y": -1},
+		Ints:   []int{1, 2},
+		Fixed:  [3]int8{1, 2, 0},
+		Floats: map[string]float64{"X": 5, "y": -1},
+		Point:  ConvertPoint{X: 5, Y: -1},
+		PPoint: &ConvertPoint{X: 5, Y: -1},
+	}, M(),
+		S("Any"), MARK(), PI(1), L(), PI(1), PI(2), E(),
+		S("AnyMap"), MARK(), PI(2), M(), S("X"), PI(5), S("y"), I(-1), E(),
+		S("Ints"), REF(), PI(1),
+		S("Fixed"), REF(), PI(1),
+		S("Floats"), REF(), PI(2),
+		S("Point"), REF(), PI(2),
+		S("PPoint"), REF(), PI(2),
+		E())
+
+	// Forward reference
+	assertBuild(t, &ConvertStruct{
+		Any:   []interface{}{1, 2},
+		Ints:  []int{1, 2},
+		Fixed: [3]int8{1, 2, 0},
+	}, M(),
+		S("Ints"), REF(), PI(1),
+		S("Fixed"), REF(), PI(1),
+		S("Any"), MARK(), PI(1), L(), PI(1), PI(2), E(),
+		E())
+}
+
+type ConvertFieldRules struct {
+	X int `ce:"required"`
+	Y int
+}
+
+type ConvertNode struct {
+	Value int
+	Next  *ConvertNode
+}
+
+func TestBuilderRefContainerConversionFieldRules(t *testing.T) {
+	type Container struct {
+		Any   interface{}
+		Rules ConvertFieldRules
+	}
+	assertBuild(t, &Container{
+		Any:   map[interface{}]interface{}{"X": 5},
+		Rules: ConvertFieldRules{X: 5},
+	}, M(),
+		S("Any"), MARK(), PI(1), M(), S("X"), PI(5), E(),
+		S("Rules"), REF(), PI(1),
+		E())
+
+	assertBuildPanics(t, &Container{}, M(),
+		S("Any"), MARK(), PI(1), M(), S("Y"), PI(5), E(),
+		S("Rules"), REF(), PI(1),
+		E())
+}
+
+func TestBuilderRefContainerConversionCycle(t *testing.T) {
+	type Container struct {
+		Any  interface{}
+		Node *ConvertNode
+	}
+	v := runBuild(NewSession(nil, nil), &Container{}, M(),
+		S("Any"), MARK(), PI(1), M(), S("Value"), PI(5), S("Next"), REF(), PI(1), E(),
+		S("Node"), REF(), PI(1),
+		E()).(*Container)
+	if v.Node == nil || v.Node.Value != 5 || v.Node.Next != v.Node {
+		t.Errorf("Expected a node that refers to itself but got %+v", v.Node)
+	}
+}
+
+func TestBuilderRefContainerConversionFail(t *testing.T) {
+	type ShortArray struct {
+		Any   interface{}
+		Fixed [1]int
+	}
+	assertBuildPanics(t, &ShortArray{}, M(),
+		S("Any"), MARK(), PI(1), L(), PI(1), PI(2), E(),
+		S("Fixed"), REF(), PI(1),
+		E())
+
+	type Overflow struct {
+		Any  interface{}
+		Ints []int8
+	}
+	assertBuildPanics(t, &Overflow{}, M(),
+		S("Any"), MARK(), PI(1), L(), PI(1), PI(1000), E(),
+		S("Ints"), REF(), PI(1),
+		E())
+
+	type MapToSlice struct {
+		Any  interface{}
+		Ints []int
+	}
+	assertBuildPanics(t, &MapToSlice{}, M(),
+		S("Any"), MARK(), PI(1), M(), PI(1), PI(2), E(),
+		S("Ints"), REF(), PI(1),
+		E())
+}
+
 type TagStruct struct {
 	Omit1 string `ce:"-"`
 	Omit2 string `ce:"omit"`
@@ -1305,3 +1785,510 @@ func TestBuilderStructTags(t *testing.T) {
 		S("test"), S("Something"),
 		E())
 }
+
+type RequiredStruct struct {
+	A int    `ce:"required"`
+	B string `ce:"required"`
+	C int
+}
+
+func TestBuilderStructRequired(t *testing.T) {
+	assertBuild(t, &RequiredStruct{
+		A: 1,
+		B: "x",
+	}, M(),
+		S("A"), PI(1),
+		S("B"), S("x"),
+		E())
+
+	assertBuildPanics(t, &RequiredStruct{}, M(), S("A"), PI(1), E())
+	assertBuildPanics(t, &RequiredStruct{}, M(), S("C"), PI(1), E())
+}
+
+func TestBuilderStructRequiredAndDefault(t *testing.T) {
+	type RequiredDefaultStruct struct {
+		A int `ce:"required,default=1"`
+	}
+	assertBuildPanics(t, &RequiredDefaultStruct{}, M(), E())
+}
+
+type IDStruct struct {
+	A int    `ce:"id=1"`
+	B string `ce:"id=2"`
+	C []int
+}
+
+func TestBuilderStructFieldIDs(t *testing.T) {
+	expected := &IDStruct{
+		A: 100,
+		B: "test",
+		C: []int{1},
+	}
+	assertBuild(t, expected, M(), PI(1), PI(100), PI(2), S("test"), S("C"), L(), PI(1), E(), E())
+	assertBuild(t, expected, M(), S("A"), PI(100), S("B"), S("test"), S("C"), L(), PI(1), E(), E())
+	assertBuild(t, expected, M(),
+		PI(50), L(), PI(1), E(),
+		I(-1), M(), PI(1), PI(1), E(),
+		PI(1), PI(100),
+		PI(2), S("test"),
+		S("C"), L(), PI(1), E(),
+		E())
+}
+
+func TestBuilderStructDuplicateFieldIDs(t *testing.T) {
+	type DuplicateIDStruct struct {
+		A int `ce:"id=1"`
+		B int `ce:"id=1"`
+	}
+	assertBuildPanics(t, &DuplicateIDStruct{}, M(), E())
+}
+
+type JSONTagStruct struct {
+	Named    string  `json:"the_name"`
+	Omitted  string  `json:"-"`
+	Stringed float64 `json:"stringed,string"`
+	Flag     bool    `json:",string"`
+	Both     int     `json:"json_name" ce:"name=ce_name"`
+}
+
+func TestBuilderJSONTags(t *testing.T) {
+	sOpts := options.DefaultBuilderSessionOptions()
+	sOpts.FallbackToJSONTags = true
+	session := NewSession(nil, sOpts)
+
+	assertBuildWithSession(t, session, &JSONTagStruct{
+		Named:    "a",
+		Stringed: 1.5,
+		Flag:     true,
+		Both:     1,
+	}, M(),
+		S("the_name"), S("a"),
+		S("Omitted"), S("b"),
+		S("stringed"), S("1.5"),
+		S("Flag"), S("true"),
+		S("ce_name"), I(1),
+		E())
+
+	assertBuildWithSession(t, session, &JSONTagStruct{
+		Stringed: 1.5,
+	}, M(),
+		S("stringed"), F(1.5),
+		E())
+}
+
+func TestBuilderLosslessFloatConversion(t *testing.T) {
+	strict := options.DefaultBuilderOptions()
+	strict.AllowLossyFloatConversion = false
+
+	assertBuildWithOptions(t, strict, float32(1.5), F(1.5))
+	assertBuildWithOptions(t, strict, float64(0.5), DF(NewDFloat("0.5")))
+	assertBuildWithOptions(t, strict, float64(1.25), BDF(NewBDF("1.25")))
+	assertBuildWithOptions(t, strict, float64(1e22), DF(NewDFloat("1e22")))
+	assertBuildWithOptions(t, strict, float32(0.25), BF(NewBigFloat("0.25", 10, 2)))
+
+	assertBuildPanicsWithOptions(t, strict, float32(0), F(0.1))
+	assertBuildPanicsWithOptions(t, strict, float64(0), DF(NewDFloat("0.1")))
+	assertBuildPanicsWithOptions(t, strict, float64(0), DF(NewDFloat("1e23")))
+	assertBuildPanicsWithOptions(t, strict, float64(0), BDF(NewBDF("0.1")))
+	assertBuildPanicsWithOptions(t, strict, float64(0), BF(NewBigFloat("0.1", 10, 100)))
+	assertBuildPanicsWithOptions(t, strict, types.Float16(0), F(0.1))
+
+	// Lossy conversions are allowed by default
+	assertBuild(t, float32(0.1), F(0.1))
+	assertBuild(t, float64(0.1), DF(NewDFloat("0.1")))
+}
+
+func TestBuilderIntegerOverflowPolicy(t *testing.T) {
+	saturate := options.DefaultBuilderOptions()
+	saturate.IntegerOverflowPolicy = options.IntegerOverflowPolicySaturate
+
+	assertBuildWithOptions(t, saturate, int8(127), I(1000))
+	assertBuildWithOptions(t, saturate, int8(-128), I(-1000))
+	assertBuildWithOptions(t, saturate, int16(32767), PI(0xffffffffffffffff))
+	assertBuildWithOptions(t, saturate, int64(math.MaxInt64), PI(0xffffffffffffffff))
+	assertBuildWithOptions(t, saturate, int32(math.MinInt32), BI(NewBigInt("-100000000000000000000", 10)))
+	assertBuildWithOptions(t, saturate, uint8(255), PI(1000))
+	assertBuildWithOptions(t, saturate, uint8(0), I(-1))
+	assertBuildWithOptions(t, saturate, uint64(math.MaxUint64), BI(NewBigInt("100000000000000000000", 10)))
+	assertBuildWithOptions(t, saturate, []uint16{0, 5, 65535}, L(), I(-5), I(5), I(100000), E())
+	assertBuildWithOptions(t, saturate, int8(127), F(1000))
+	assertBuildWithOptions(t, saturate, int64(math.MaxInt64), F(1e20))
+	assertBuildWithOptions(t, saturate, int64(math.MinInt64), F(-1e20))
+	assertBuildWithOptions(t, saturate, uint8(0), F(-1000))
+	assertBuildWithOptions(t, saturate, uint64(math.MaxUint64), F(1e20))
+	assertBuildWithOptions(t, saturate, int16(math.MinInt16), BF(NewBigFloat("-1e30", 10, 4)))
+	assertBuildWithOptions(t, saturate, uint32(math.MaxUint32), BF(NewBigFloat("1e30", 10, 4)))
+	assertBuildWithOptions(t, saturate, int8(127), DF(NewDFloat("1.0e30")))
+	assertBuildWithOptions(t, saturate, int8(-128), DF(NewDFloat("-1.0e30")))
+	assertBuildWithOptions(t, saturate, int64(math.MaxInt64), BDF(NewBDF("1.0e30")))
+	assertBuildWithOptions(t, saturate, uint16(math.MaxUint16), DF(NewDFloat("1.0e30")))
+	assertBuildWithOptions(t, saturate, uint64(math.MaxUint64), BDF(NewBDF("1.0e30")))
+	assertBuildWithOptions(t, saturate, uint64(0), DF(NewDFloat("-5.0")))
+	assertBuildWithOptions(t, saturate, uint8(0), BDF(NewBDF("-5.0")))
+	assertBuildWithOptions(t, saturate, uint64(math.MaxUint64), BDF(NewBDF("18446744073709551615")))
+	assertBuildWithOptions(t, saturate, int16(50), DF(NewDFloat("50.0")))
+
+	assertBuildPanics(t, int8(0), I(1000))
+	assertBuildPanics(t, uint8(0), I(-1))
+	assertBuildPanics(t, int64(0), F(1e20))
+	assertBuildPanics(t, uint64(0), F(-1))
+	assertBuildPanics(t, uint64(0), DF(NewDFloat("-5.0")))
+	assertBuildPanics(t, uint64(0), BDF(NewBDF("-5.0")))
+	assertBuildPanics(t, int8(0), DF(NewDFloat("1.0e30")))
+	assertBuildPanics(t, uint8(0), BDF(NewBDF("1.0e30")))
+	// Saturation doesn't apply to fractional or infinite values
+	assertBuildPanicsWithOptions(t, saturate, int8(0), F(1.5))
+	assertBuildPanicsWithOptions(t, saturate, int64(0), F(math.Inf(1)))
+	assertBuildPanicsWithOptions(t, saturate, uint64(0), F(math.NaN()))
+	assertBuildPanicsWithOptions(t, saturate, int8(0), DF(NewDFloat("1.5")))
+	assertBuildPanicsWithOptions(t, saturate, uint8(0), BDF(NewBDF("-1.5")))
+	assertBuildPanicsWithOptions(t, saturate, uint8(0), BDF(NewBDF("Infinity")))
+}
+
+func TestBuilderConversionErrorPath(t *testing.T) {
+	type Inner struct {
+		Values []int8
+	}
+	type Outer struct {
+		Items map[string]Inner
+	}
+
+	builder := NewSession(nil, nil).NewBuilderFor(Outer{}, nil)
+	err := test.ReportPanic(func() {
+		test.InvokeEvents(builder, M(), S("Items"), M(), S("a"), M(), S("Values"), L(), I(1), I(1000))
+	})
+	if err == nil {
+		t.Fatalf("Expected a conversion error")
+	}
+	err = builder.AnnotateError(err)
+	convErr, ok := err.(*ConversionError)
+	if !ok {
+		t.Fatalf("Expected a ConversionError but got %v", err)
+	}
+	if convErr.Path != `Items["a"].Values[1]` {
+		t.Errorf("Expected path Items[\"a\"].Values[1] but got %v", convErr.Path)
+	}
+}
+
+type CoercionStruct struct {
+	I   int
+	U   uint16
+	F   float64
+	B   bool
+	T   time.Time
+	D   time.Duration
+	S   []string
+	F64 []float64
+}
+
+func TestBuilderLenientCoercion(t *testing.T) {
+	type coercion struct {
+		path    string
+		dstType reflect.Type
+	}
+	var coercions []coercion
+	opts := options.DefaultBuilderOptions()
+	opts.AllowLenientCoercion = true
+	opts.CoercionCallback = func(path string, value interface{}, dstType reflect.Type) {
+		coercions = append(coercions, coercion{path, dstType})
+	}
+
+	expected := &CoercionStruct{
+		I:   -10,
+		U:   500,
+		F:   1.25,
+		B:   true,
+		T:   time.Date(2020, time.Month(1), 15, 10, 30, 0, 0, time.UTC),
+		D:   30 * time.Nanosecond,
+		S:   []string{"abc"},
+		F64: []float64{2.5},
+	}
+	actual := runBuildWithOptions(NewSession(nil, nil), opts, CoercionStruct{},
+		M(),
+		S("I"), S("-10"),
+		S("U"), S("500"),
+		S("F"), S("1.25"),
+		S("B"), S("true"),
+		S("T"), S("2020-01-15T10:30:00Z"),
+		S("D"), I(30),
+		S("S"), S("abc"),
+		S("F64"), F(2.5),
+		E())
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v but got %v", describe.D(expected), describe.D(actual))
+	}
+
+	expectedCoercions := []coercion{
+		{"I", reflect.TypeOf(0)},
+		{"U", reflect.TypeOf(uint16(0))},
+		{"F", reflect.TypeOf(float64(0))},
+		{"B", reflect.TypeOf(false)},
+		{"T", reflect.TypeOf(time.Time{})},
+		{"S", reflect.TypeOf([]string{})},
+		{"F64", reflect.TypeOf([]float64{})},
+	}
+	if !reflect.DeepEqual(expectedCoercions, coercions) {
+		t.Errorf("Expected coercions %v but got %v", expectedCoercions, coercions)
+	}
+
+	assertBuildWithOptions(t, opts, []int{5}, I(5))
+	assertBuildWithOptions(t, opts, []int{5}, S("5"))
+	assertBuildWithOptions(t, opts, []interface{}{"x"}, S("x"))
+	assertBuildWithOptions(t, opts, []bool{false}, S("false"))
+	assertBuildWithOptions(t, opts, []int64{1, 2}, L(), S("1"), I(2), E())
+	assertBuildPanicsWithOptions(t, opts, 0, S("x"))
+	assertBuildPanicsWithOptions(t, opts, int8(0), S("1000"))
+	assertBuildPanicsWithOptions(t, opts, false, S("yes"))
+	assertBuildPanicsWithOptions(t, opts, false, S("1"))
+	assertBuildPanicsWithOptions(t, opts, false, S("T"))
+	assertBuildPanicsWithOptions(t, opts, false, S("FALSE"))
+	assertBuildPanicsWithOptions(t, opts, time.Time{}, S("2020-01-15"))
+}
+
+func TestBuilderLenientCoercionDisabled(t *testing.T) {
+	assertBuild(t, time.Duration(30), I(30))
+	assertBuildPanics(t, 0, S("1"))
+	assertBuildPanics(t, 0.0, S("1.5"))
+	assertBuildPanics(t, false, S("true"))
+	assertBuildPanics(t, time.Time{}, S("2020-01-15T10:30:00Z"))
+	assertBuildPanics(t, []int{}, I(1))
+	assertBuildPanics(t, []float64{}, F(1))
+	assertBuildPanics(t, CoercionStruct{}, M(), S("S"), S("abc"), E())
+}
+
+type PolyShape interface {
+	Area() float64
+}
+
+type PolyCircle struct {
+	Radius float64
+}
+
+func (_this PolyCircle) Area() float64 { return _this.Radius * _this.Radius * 3 }
+
+type PolyRect struct {
+	W, H float64
+}
+
+func (_this *PolyRect) Area() float64 { return _this.W * _this.H }
+
+type PolyDrawing struct {
+	Shapes []PolyShape
+	Extra  interface{}
+}
+
+func TestBuilderPolymorphic(t *testing.T) {
+	sessionOpts := options.DefaultBuilderSessionOptions()
+	sessionOpts.PolymorphicTypes = map[string]reflect.Type{
+		"circle": reflect.TypeOf(PolyCircle{}),
+		"rect":   reflect.TypeOf(&PolyRect{}),
+	}
+	session := NewSession(nil, sessionOpts)
+
+	expected := &PolyDrawing{
+		Shapes: []PolyShape{PolyCircle{Radius: 2}, &PolyRect{W: 1, H: 3}, nil},
+		Extra:  map[interface{}]interface{}{"a": int64(1), "$type": "x"},
+	}
+	actual := runBuild(session, PolyDrawing{},
+		M(),
+		S("Shapes"), L(),
+		M(), S("$type"), S("circle"), S("radius"), I(2), E(),
+		M(), S("$type"), S("rect"), S("w"), I(1), S("h"), I(3), E(),
+		NA(),
+		E(),
+		S("Extra"), M(), S("a"), I(1), S("$type"), S("x"), E(),
+		E())
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v but got %v", describe.D(expected), describe.D(actual))
+	}
+
+	actual = runBuild(session, []interface{}{}, L(), M(), S("$type"), S("circle"), E(), M(), E(), E())
+	if !reflect.DeepEqual([]interface{}{PolyCircle{}, map[interface{}]interface{}{}}, actual) {
+		t.Errorf("Expected a circle and an empty map but got %v", describe.D(actual))
+	}
+
+	test.AssertPanics(t, "unknown type", func() {
+		runBuild(session, []PolyShape{}, L(), M(), S("$type"), S("triangle"), E(), E())
+	})
+	test.AssertPanics(t, "non-string type", func() {
+		runBuild(session, []PolyShape{}, L(), M(), S("$type"), I(1), E(), E())
+	})
+	test.AssertPanics(t, "unregistered session", func() {
+		runBuild(NewSession(nil, nil), []PolyShape{}, L(), M(), S("$type"), S("circle"), E(), E())
+	})
+	test.AssertPanics(t, "bad registration", func() {
+		NewSession(nil, nil).RegisterPolymorphicType("int", reflect.TypeOf(1))
+	})
+}
+
+func assertBuildInterfaceWithOptions(t *testing.T, opts *options.BuilderOptions, expected interface{}, events ...*test.TEvent) {
+	actual := runBuildWithOptions(NewSession(nil, nil), opts, nil, events...)
+	if reflect.TypeOf(actual) != reflect.TypeOf(expected) || !equivalence.IsEquivalent(expected, actual) {
+		t.Errorf("Expected %v (%T) but got %v (%T)", describe.D(expected), expected, describe.D(actual), actual)
+	}
+}
+
+func TestBuilderInterfaceIntegerType(t *testing.T) {
+	opts := options.DefaultBuilderOptions()
+	assertBuildInterfaceWithOptions(t, opts, int64(-1), I(-1))
+	assertBuildInterfaceWithOptions(t, opts, uint64(1), PI(1))
+
+	opts.InterfaceIntegerType = options.InterfaceIntegerTypeInt64
+	assertBuildInterfaceWithOptions(t, opts, int64(1), PI(1))
+	assertBuildInterfaceWithOptions(t, opts, int64(-5), BI(NewBigInt("-5", 10)))
+	assertBuildPanicsWithOptions(t, opts, nil, PI(0xffffffffffffffff))
+
+	opts.InterfaceIntegerType = options.InterfaceIntegerTypeUint64
+	assertBuildInterfaceWithOptions(t, opts, uint64(5), I(5))
+	assertBuildPanicsWithOptions(t, opts, nil, I(-5))
+
+	opts.InterfaceIntegerType = options.InterfaceIntegerTypeBigInt
+	assertBuildInterfaceWithOptions(t, opts, NewBigInt("-5", 10), I(-5))
+	assertBuildInterfaceWithOptions(t, opts, NewBigInt("18446744073709551615", 10), PI(0xffffffffffffffff))
+
+	opts.InterfaceIntegerType = options.InterfaceIntegerTypeNumber
+	assertBuildInterfaceWithOptions(t, opts, types.Number("-5"), I(-5))
+	assertBuildInterfaceWithOptions(t, opts, types.Number("18446744073709551615"), PI(0xffffffffffffffff))
+	assertBuildInterfaceWithOptions(t, opts, types.Number("100000000000000000000"), BI(NewBigInt("100000000000000000000", 10)))
+	assertBuildInterfaceWithOptions(t, opts, []interface{}{types.Number("1"), 1.5}, L(), I(1), F(1.5), E())
+}
+
+func TestBuilderInterfaceFloatType(t *testing.T) {
+	opts := options.DefaultBuilderOptions()
+	opts.InterfaceFloatType = options.InterfaceFloatTypeFloat64
+	assertBuildInterfaceWithOptions(t, opts, 1.5, DF(NewDFloat("1.5")))
+	assertBuildInterfaceWithOptions(t, opts, 1.5, BDF(NewBDF("1.5")))
+
+	opts.InterfaceFloatType = options.InterfaceFloatTypeDFloat
+	assertBuildInterfaceWithOptions(t, opts, NewDFloat("1.5"), F(1.5))
+	assertBuildInterfaceWithOptions(t, opts, NewDFloat("1.5"), BDF(NewBDF("1.5")))
+
+	opts.InterfaceFloatType = options.InterfaceFloatTypeBigDecimal
+	assertBuildInterfaceWithOptions(t, opts, NewBDF("0.1"), DF(NewDFloat("0.1")))
+
+	strict := options.DefaultBuilderOptions()
+	strict.AllowLossyFloatConversion = false
+	strict.InterfaceFloatType = options.InterfaceFloatTypeFloat64
+	assertBuildPanicsWithOptions(t, strict, nil, DF(NewDFloat("0.1")))
+}
+
+func TestBuilderInterfaceTimeType(t *testing.T) {
+	gotime := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	ctime, err := compact_time.AsCompactTime(gotime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := options.DefaultBuilderOptions()
+	opts.InterfaceTimeType = options.InterfaceTimeTypeGoTime
+	assertBuildInterfaceWithOptions(t, opts, gotime, CT(ctime))
+	assertBuildPanicsWithOptions(t, opts, nil, CT(NewTSLL(2020, 1, 1, 1, 1, 1, 0, 100, 100)))
+
+	opts.InterfaceTimeType = options.InterfaceTimeTypeCompactTime
+	assertBuildInterfaceWithOptions(t, opts, ctime, GT(gotime))
+}
+
+func TestBuilderInterfaceMapType(t *testing.T) {
+	opts := options.DefaultBuilderOptions()
+	opts.InterfaceMapType = options.InterfaceMapTypeStringKeys
+	assertBuildInterfaceWithOptions(t, opts,
+		map[string]interface{}{"a": int64(1), "b": map[string]interface{}{"c": true}},
+		M(), S("a"), I(1), S("b"), M(), S("c"), TT(), E(), E())
+	assertBuildPanicsWithOptions(t, opts, nil, M(), I(1), I(1), E())
+	assertBuildInterfaceWithOptions(t, opts,
+		[]interface{}{map[string]interface{}{"a": int64(1)}, map[string]interface{}{}},
+		L(), M(), S("a"), I(1), E(), M(), E(), E())
+	assertBuildInterfaceWithOptions(t, opts,
+		map[string]interface{}{"a": []interface{}{map[string]interface{}{"b": int64(2)}}},
+		M(), S("a"), L(), M(), S("b"), I(2), E(), E(), E())
+
+	opts.InterfaceMapType = options.InterfaceMapTypeOrdered
+	actual := runBuildWithOptions(NewSession(nil, nil), opts, nil,
+		M(), S("z"), I(1), S("a"), L(), I(2), E(), I(5), M(), S("y"), NA(), S("b"), TT(), E(), E())
+	m, ok := actual.(*types.OrderedMap)
+	if !ok {
+		t.Fatalf("Expected *types.OrderedMap but got %T", actual)
+	}
+	expectedKeys := []interface{}{"z", "a", int64(5)}
+	if !reflect.DeepEqual(m.Keys(), expectedKeys) {
+		t.Errorf("Expected keys %v but got %v", expectedKeys, m.Keys())
+	}
+	inner, _ := m.Get(int64(5))
+	innerMap, ok := inner.(*types.OrderedMap)
+	if !ok {
+		t.Fatalf("Expected *types.OrderedMap but got %T", inner)
+	}
+	expectedKeys = []interface{}{"y", "b"}
+	if !reflect.DeepEqual(innerMap.Keys(), expectedKeys) {
+		t.Errorf("Expected keys %v but got %v", expectedKeys, innerMap.Keys())
+	}
+	if v, _ := m.Get("a"); !reflect.DeepEqual(v, []interface{}{int64(2)}) {
+		t.Errorf("Expected [2] but got %v", v)
+	}
+}
+
+func TestBuilderNumber(t *testing.T) {
+	type NumberStruct struct {
+		Value types.Number
+	}
+	assertBuild(t, types.Number("-100"), I(-100))
+	assertBuild(t, types.Number("1.5"), DF(NewDFloat("1.5")))
+	assertBuild(t, NumberStruct{Value: "18446744073709551616"}, M(), S("Value"), BI(NewBigInt("18446744073709551616", 10)), E())
+}
+
+func TestBuilderOrderedMap(t *testing.T) {
+	type OrderedMapStruct struct {
+		Value   types.OrderedMap
+		Pointer *types.OrderedMap
+		Missing *types.OrderedMap
+	}
+
+	// Nested maps are built according to InterfaceMapType
+	opts := options.DefaultBuilderOptions()
+	opts.InterfaceMapType = options.InterfaceMapTypeOrdered
+	actual := runBuildWithOptions(NewSession(nil, nil), opts, OrderedMapStruct{},
+		M(),
+		S("Value"), M(), S("b"), I(1), S("a"), L(), E(), E(),
+		S("Pointer"), M(), I(2), M(), S("z"), TT(), S("y"), FF(), E(), E(),
+		S("Missing"), NA(),
+		E()).(*OrderedMapStruct)
+
+	expectedKeys := []interface{}{"b", "a"}
+	if !reflect.DeepEqual(actual.Value.Keys(), expectedKeys) {
+		t.Errorf("Expected keys %v but got %v", expectedKeys, actual.Value.Keys())
+	}
+	if actual.Missing != nil {
+		t.Errorf("Expected nil but got %v", actual.Missing)
+	}
+	inner, _ := actual.Pointer.Get(int64(2))
+	expectedKeys = []interface{}{"z", "y"}
+	if !reflect.DeepEqual(inner.(*types.OrderedMap).Keys(), expectedKeys) {
+		t.Errorf("Expected keys %v but got %v", expectedKeys, inner.(*types.OrderedMap).Keys())
+	}
+
+	actual = runBuild(NewSession(nil, nil), OrderedMapStruct{},
+		M(), S("Value"), M(), S("a"), M(), E(), E(), E()).(*OrderedMapStruct)
+	inner, _ = actual.Value.Get("a")
+	if _, ok := inner.(map[interface{}]interface{}); !ok {
+		t.Errorf("Expected map[interface{}]interface{} but got %T", inner)
+	}
+
+	// References can be keys. Entries with forward referenced keys are added
+	// once the key is resolved.
+	actual = runBuildWithOptions(NewSession(nil, nil), opts, OrderedMapStruct{},
+		M(), S("Value"), M(),
+		REF(), PI(2), I(3),
+		S("a"), MARK(), PI(1), S("key"),
+		REF(), PI(1), I(2),
+		S("b"), MARK(), PI(2), S("fwd"),
+		E(), E()).(*OrderedMapStruct)
+	expectedEntries := []types.MapEntry{
+		{Key: "a", Value: "key"},
+		{Key: "key", Value: int64(2)},
+		{Key: "b", Value: "fwd"},
+		{Key: "fwd", Value: int64(3)},
+	}
+	if !reflect.DeepEqual(actual.Value.Entries(), expectedEntries) {
+		t.Errorf("Expected entries %v but got %v", expectedEntries, actual.Value.Entries())
+	}
+}
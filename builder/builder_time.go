@@ -24,6 +24,8 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/kstenerud/go-concise-encoding/events"
+
 	"github.com/kstenerud/go-compact-time"
 )
 
@@ -50,6 +52,23 @@ func (_this *timeBuilder) BuildFromCompactTime(ctx *Context, value compact_time.
 	return dst
 }
 
+func (_this *timeBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	return _this.BuildFromStringlikeArray(ctx, arrayType, string(value), dst)
+}
+
+func (_this *timeBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, dst reflect.Value) reflect.Value {
+	if arrayType != events.ArrayTypeString {
+		PanicBadEvent(_this, "BuildFromStringlikeArray(%v)", arrayType)
+	}
+	ctx.NotifyCoercion(value, dst.Type())
+	v, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		PanicErrorConverting(value, dst.Type(), err)
+	}
+	dst.Set(reflect.ValueOf(v))
+	return dst
+}
+
 // ============================================================================
 
 var globalCompactTimeBuilder = &compactTimeBuilder{}
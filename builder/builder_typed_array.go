@@ -26,6 +26,7 @@ import (
 	"reflect"
 
 	"github.com/kstenerud/go-concise-encoding/internal/common"
+	"github.com/kstenerud/go-concise-encoding/types"
 
 	"github.com/kstenerud/go-concise-encoding/events"
 )
@@ -46,6 +47,11 @@ func (_this *stringBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayT
 	switch arrayType {
 	case events.ArrayTypeString:
 		dst.SetString(string(value))
+	case events.ArrayTypeResourceID:
+		if !ctx.isConcatenation {
+			PanicBadEvent(_this, "BuildFromArray(%v)", arrayType)
+		}
+		dst.SetString(string(value))
 	default:
 		PanicBadEvent(_this, "BuildFromArray(%v)", arrayType)
 	}
@@ -56,6 +62,11 @@ func (_this *stringBuilder) BuildFromStringlikeArray(ctx *Context, arrayType eve
 	switch arrayType {
 	case events.ArrayTypeString:
 		dst.SetString(value)
+	case events.ArrayTypeResourceID:
+		if !ctx.isConcatenation {
+			PanicBadEvent(_this, "BuildFromArray(%v)", arrayType)
+		}
+		dst.SetString(value)
 	default:
 		PanicBadEvent(_this, "BuildFromArray(%v)", arrayType)
 	}
@@ -163,7 +174,9 @@ func (_this *uint16ArrayBuilder) BuildBeginListContents(ctx *Context) {
 	generator(ctx).BuildBeginListContents(ctx)
 }
 
-type uint16SliceBuilder struct{}
+type uint16SliceBuilder struct {
+	singleValueSliceBuilder
+}
 
 var globalUint16SliceBuilder = &uint16SliceBuilder{}
 
@@ -236,7 +249,9 @@ func (_this *uint32ArrayBuilder) BuildBeginListContents(ctx *Context) {
 	generator(ctx).BuildBeginListContents(ctx)
 }
 
-type uint32SliceBuilder struct{}
+type uint32SliceBuilder struct {
+	singleValueSliceBuilder
+}
 
 var globalUint32SliceBuilder = &uint32SliceBuilder{}
 
@@ -315,7 +330,9 @@ func (_this *uint64ArrayBuilder) BuildBeginListContents(ctx *Context) {
 	generator(ctx).BuildBeginListContents(ctx)
 }
 
-type uint64SliceBuilder struct{}
+type uint64SliceBuilder struct {
+	singleValueSliceBuilder
+}
 
 var globalUint64SliceBuilder = &uint64SliceBuilder{}
 
@@ -391,7 +408,9 @@ func (_this *int8ArrayBuilder) BuildBeginListContents(ctx *Context) {
 	generator(ctx).BuildBeginListContents(ctx)
 }
 
-type int8SliceBuilder struct{}
+type int8SliceBuilder struct {
+	singleValueSliceBuilder
+}
 
 var globalInt8SliceBuilder = &int8SliceBuilder{}
 
@@ -461,7 +480,9 @@ func (_this *int16ArrayBuilder) BuildBeginListContents(ctx *Context) {
 	generator(ctx).BuildBeginListContents(ctx)
 }
 
-type int16SliceBuilder struct{}
+type int16SliceBuilder struct {
+	singleValueSliceBuilder
+}
 
 var globalInt16SliceBuilder = &int16SliceBuilder{}
 
@@ -534,7 +555,9 @@ func (_this *int32ArrayBuilder) BuildBeginListContents(ctx *Context) {
 	generator(ctx).BuildBeginListContents(ctx)
 }
 
-type int32SliceBuilder struct{}
+type int32SliceBuilder struct {
+	singleValueSliceBuilder
+}
 
 var globalInt32SliceBuilder = &int32SliceBuilder{}
 
@@ -613,7 +636,9 @@ func (_this *int64ArrayBuilder) BuildBeginListContents(ctx *Context) {
 	generator(ctx).BuildBeginListContents(ctx)
 }
 
-type int64SliceBuilder struct{}
+type int64SliceBuilder struct {
+	singleValueSliceBuilder
+}
 
 var globalInt64SliceBuilder = &int64SliceBuilder{}
 
@@ -681,6 +706,13 @@ func (_this *float32ArrayBuilder) BuildFromArray(ctx *Context, arrayType events.
 			elem := dst.Index(i)
 			elem.SetFloat(float64(math.Float32frombits(elemValue)))
 		}
+	case events.ArrayTypeFloat16:
+		elemCount := len(value) / 2
+		for i := 0; i < elemCount; i++ {
+			elemValue := uint16(value[i*2]) | (uint16(value[i*2+1]) << 8)
+			elem := dst.Index(i)
+			elem.SetFloat(float64(types.BFloat16(elemValue).Float32()))
+		}
 	default:
 		PanicBadEvent(_this, "BuildFromArray(%v)", arrayType)
 	}
@@ -692,7 +724,9 @@ func (_this *float32ArrayBuilder) BuildBeginListContents(ctx *Context) {
 	generator(ctx).BuildBeginListContents(ctx)
 }
 
-type float32SliceBuilder struct{}
+type float32SliceBuilder struct {
+	singleValueSliceBuilder
+}
 
 var globalFloat32SliceBuilder = &float32SliceBuilder{}
 
@@ -717,6 +751,14 @@ func (_this *float32SliceBuilder) BuildFromArray(ctx *Context, arrayType events.
 			slice[i] = math.Float32frombits(elemValue)
 		}
 		dst.Set(reflect.ValueOf(slice))
+	case events.ArrayTypeFloat16:
+		elemCount := len(value) / 2
+		slice := make([]float32, elemCount, elemCount)
+		for i := 0; i < elemCount; i++ {
+			elemValue := uint16(value[i*2]) | (uint16(value[i*2+1]) << 8)
+			slice[i] = types.BFloat16(elemValue).Float32()
+		}
+		dst.Set(reflect.ValueOf(slice))
 	default:
 		PanicBadEvent(_this, "BuildFromSlice(%v)", arrayType)
 	}
@@ -772,7 +814,9 @@ func (_this *float64ArrayBuilder) BuildBeginListContents(ctx *Context) {
 	generator(ctx).BuildBeginListContents(ctx)
 }
 
-type float64SliceBuilder struct{}
+type float64SliceBuilder struct {
+	singleValueSliceBuilder
+}
 
 var globalFloat64SliceBuilder = &float64SliceBuilder{}
 
@@ -810,3 +854,73 @@ func (_this *float64SliceBuilder) BuildFromArray(ctx *Context, arrayType events.
 func (_this *float64SliceBuilder) BuildBeginListContents(ctx *Context) {
 	listToFloat64SliceGenerator(ctx).BuildBeginListContents(ctx)
 }
+
+// ============================================================================
+
+type boolArrayBuilder struct {
+	dstType reflect.Type
+}
+
+func newBoolArrayBuilderGenerator(dstType reflect.Type) BuilderGenerator {
+	return func(ctx *Context) Builder {
+		return &boolArrayBuilder{
+			dstType: dstType,
+		}
+	}
+}
+
+func (_this *boolArrayBuilder) String() string {
+	return fmt.Sprintf("%v<%v>", reflect.TypeOf(_this), _this.dstType)
+}
+
+func (_this *boolArrayBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	switch arrayType {
+	case events.ArrayTypeBoolean:
+		elemCount := int(ctx.arrayElementCount)
+		for i := 0; i < elemCount; i++ {
+			elem := dst.Index(i)
+			elem.SetBool((value[i>>3]>>(i&7))&1 == 1)
+		}
+	default:
+		PanicBadEvent(_this, "BuildFromArray(%v)", arrayType)
+	}
+	return dst
+}
+
+func (_this *boolArrayBuilder) BuildBeginListContents(ctx *Context) {
+	generator := newArrayBuilderGenerator(ctx.GetBuilderGeneratorForType, _this.dstType)
+	generator(ctx).BuildBeginListContents(ctx)
+}
+
+type boolSliceBuilder struct {
+	singleValueSliceBuilder
+}
+
+var globalBoolSliceBuilder = &boolSliceBuilder{}
+
+func generateBoolSliceBuilder(ctx *Context) Builder { return globalBoolSliceBuilder }
+func (_this *boolSliceBuilder) String() string      { return nameOf(_this) }
+
+func (_this *boolSliceBuilder) BuildFromNil(ctx *Context, dst reflect.Value) reflect.Value {
+	dst.Set(reflect.Zero(dst.Type()))
+	return dst
+}
+
+func (_this *boolSliceBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	switch arrayType {
+	case events.ArrayTypeBoolean:
+		elemCount := int(ctx.arrayElementCount)
+		slice := make([]bool, elemCount, elemCount)
+		for i := 0; i < elemCount; i++ {
+			slice[i] = (value[i>>3]>>(i&7))&1 == 1
+		}
+		dst.Set(reflect.ValueOf(slice))
+	default:
+		PanicBadEvent(_this, "BuildFromSlice(%v)", arrayType)
+	}
+	return dst
+}
+
+func (_this *boolSliceBuilder) BuildBeginListContents(ctx *Context) {
+	listToBoolSliceGenerator(ctx).BuildBeginListContents(ctx)
+}
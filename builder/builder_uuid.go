@@ -0,0 +1,116 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package builder
+
+import (
+	"reflect"
+
+	"github.com/kstenerud/go-concise-encoding/events"
+	"github.com/kstenerud/go-concise-encoding/types"
+)
+
+type uuidBuilder struct{}
+
+var globalUUIDBuilder = &uuidBuilder{}
+
+func generateUUIDBuilder(ctx *Context) Builder { return globalUUIDBuilder }
+func (_this *uuidBuilder) String() string      { return reflect.TypeOf(_this).String() }
+
+func (_this *uuidBuilder) BuildFromUUID(ctx *Context, value []byte, dst reflect.Value) reflect.Value {
+	setUUIDFromBytes(value, dst)
+	return dst
+}
+
+func (_this *uuidBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	switch arrayType {
+	case events.ArrayTypeUint8:
+		if len(value) != dst.Len() {
+			PanicCannotConvert(value, dst.Type())
+		}
+		setUUIDFromBytes(value, dst)
+	case events.ArrayTypeString:
+		setUUIDFromString(string(value), dst)
+	default:
+		if !ctx.TryBuildFromCustom(_this, arrayType, value, dst) {
+			PanicBadEvent(_this, "TypedArray(%v)", arrayType)
+		}
+	}
+	return dst
+}
+
+func (_this *uuidBuilder) BuildFromStringlikeArray(ctx *Context, arrayType events.ArrayType, value string, dst reflect.Value) reflect.Value {
+	switch arrayType {
+	case events.ArrayTypeString:
+		setUUIDFromString(value, dst)
+	default:
+		PanicBadEvent(_this, "StringlikeArray(%v)", arrayType)
+	}
+	return dst
+}
+
+func setUUIDFromBytes(value []byte, dst reflect.Value) {
+	for i, b := range value {
+		dst.Index(i).SetUint(uint64(b))
+	}
+}
+
+func setUUIDFromString(value string, dst reflect.Value) {
+	uuid, err := types.ParseUUID(value)
+	if err != nil {
+		PanicErrorConverting(value, dst.Type(), err)
+	}
+	setUUIDFromBytes(uuid[:], dst)
+}
+
+// ============================================================================
+
+type uuidSliceBuilder struct {
+	singleValueSliceBuilder
+}
+
+var globalUUIDSliceBuilder = &uuidSliceBuilder{}
+
+func generateUUIDSliceBuilder(ctx *Context) Builder { return globalUUIDSliceBuilder }
+func (_this *uuidSliceBuilder) String() string      { return reflect.TypeOf(_this).String() }
+
+func (_this *uuidSliceBuilder) BuildFromNil(ctx *Context, dst reflect.Value) reflect.Value {
+	dst.Set(reflect.Zero(dst.Type()))
+	return dst
+}
+
+func (_this *uuidSliceBuilder) BuildFromArray(ctx *Context, arrayType events.ArrayType, value []byte, dst reflect.Value) reflect.Value {
+	switch arrayType {
+	case events.ArrayTypeUUID:
+		elemCount := len(value) / 16
+		slice := make([]types.UUID, elemCount, elemCount)
+		for i := 0; i < elemCount; i++ {
+			copy(slice[i][:], value[i*16:])
+		}
+		dst.Set(reflect.ValueOf(slice))
+	default:
+		PanicBadEvent(_this, "BuildFromSlice(%v)", arrayType)
+	}
+	return dst
+}
+
+func (_this *uuidSliceBuilder) BuildBeginListContents(ctx *Context) {
+	listToUUIDSliceGenerator(ctx).BuildBeginListContents(ctx)
+}
@@ -25,10 +25,14 @@
 package builder
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/kstenerud/go-concise-encoding/events"
+	"github.com/kstenerud/go-concise-encoding/internal/common"
 	"github.com/kstenerud/go-concise-encoding/options"
+	"github.com/kstenerud/go-concise-encoding/rules"
 )
 
 type Context struct {
@@ -42,10 +46,45 @@ type Context struct {
 	GetBuilderGeneratorForType func(dstType reflect.Type) BuilderGenerator
 	builderStack               []Builder
 
+	// Concrete types to build into interfaces, by discriminator name
+	polymorphicTypes   map[string]reflect.Type
+	polymorphicTypeKey string
+
+	// Element count of the array being built. Bit arrays need this because
+	// their element count can't be derived from their byte count.
+	arrayElementCount uint64
+
+	// Set while a concatenated resource ID is being built into its
+	// destination. Concatenations can also be built into strings.
+	isConcatenation bool
+
+	// The receiver that feeds events to this context. Documents loaded via
+	// resource ID references are fed to it in place of the reference.
+	eventReceiver events.DataEventReceiver
+
+	// Resolved IDs of the referenced documents currently being built,
+	// outermost first.
+	referencedDocuments []string
+
+	// Creates the rules that referenced documents are checked by before
+	// being fed to eventReceiver.
+	newReferencedDocumentRules func(nextReceiver events.DataEventReceiver) events.DataEventReceiver
+
+	// Number and total size of the documents loaded via resource ID
+	// references so far.
+	referencedDocumentCount     int
+	referencedDocumentByteCount uint64
+
+	// Pointers, maps, and slices already converted while setting a value from
+	// a referenced object (see setFromReferencedObject).
+	convertedObjects map[convertedObjectKey]reflect.Value
+
 	chunkedData             []byte
+	chunkedElementBitWidth  int
+	chunkedElementCount     uint64
 	chunkRemainingLength    uint64
 	moreChunksFollow        bool
-	arrayCompletionCallback func([]byte)
+	arrayCompletionCallback func(elementCount uint64, data []byte)
 }
 
 func (_this *Context) Init(opts *options.BuilderOptions,
@@ -61,6 +100,9 @@ func (_this *Context) Init(opts *options.BuilderOptions,
 	_this.CustomTextBuildFunction = customTextBuildFunction
 	_this.GetBuilderGeneratorForType = getBuilderGeneratorForType
 	_this.builderStack = make([]Builder, 0, 16)
+	_this.newReferencedDocumentRules = func(nextReceiver events.DataEventReceiver) events.DataEventReceiver {
+		return rules.NewRules(nextReceiver, nil)
+	}
 
 	_this.referenceFiller.Init()
 }
@@ -87,6 +129,39 @@ func (_this *Context) UnstackBuilderAndNotifyChildFinished(container reflect.Val
 	return oldTop
 }
 
+// Container builders that can describe where in their container the value
+// currently being built will go.
+type pathElementDescriber interface {
+	// Describe the current element's location (such as ".Name" or "[5]").
+	// isInnermost is true if this builder is building the current value
+	// itself, rather than a container holding it.
+	describePathElement(isInnermost bool) string
+}
+
+// Describe the path from the top-level object to the value currently being
+// built (such as "Items[2].Price").
+func (_this *Context) describeCurrentPath() string {
+	sb := strings.Builder{}
+	innermost := len(_this.builderStack) - 1
+	for i, builder := range _this.builderStack {
+		if describer, ok := builder.(pathElementDescriber); ok {
+			sb.WriteString(describer.describePathElement(i == innermost))
+		}
+	}
+	return strings.TrimPrefix(sb.String(), ".")
+}
+
+// Apply a lenient coercion of value to dstType, reporting it via the coercion
+// callback. Panics if lenient coercion is not allowed.
+func (_this *Context) NotifyCoercion(value interface{}, dstType reflect.Type) {
+	if !_this.Options.AllowLenientCoercion {
+		PanicCannotConvert(value, dstType)
+	}
+	if _this.Options.CoercionCallback != nil {
+		_this.Options.CoercionCallback(_this.describeCurrentPath(), value, dstType)
+	}
+}
+
 func (_this *Context) IgnoreNext() {
 	_this.StackBuilder(globalIgnoreBuilder)
 }
@@ -109,6 +184,86 @@ func (_this *Context) StoreReferencedObject(id interface{}) {
 	_this.CurrentBuilder.BuildFromReference(_this, id)
 }
 
+// Load the document that a resource ID reference refers to, and build it in
+// place of the reference.
+func (_this *Context) BuildFromReferencedDocument(resourceID string) {
+	resolver := _this.Options.ReferenceResolver
+	if resolver == nil {
+		panic(fmt.Errorf("cannot build from reference to resource ID [%v] because no reference resolver was set", resourceID))
+	}
+	depth := len(_this.referencedDocuments)
+	baseID := ""
+	if depth > 0 {
+		baseID = _this.referencedDocuments[depth-1]
+	}
+	resolvedID, document, err := resolver.ResolveReference(baseID, resourceID)
+	if err != nil {
+		panic(fmt.Errorf("error loading referenced document [%v]: %v", resourceID, err))
+	}
+	for i, id := range _this.referencedDocuments {
+		if id == resolvedID {
+			cycle := append(_this.referencedDocuments[i:depth:depth], resolvedID)
+			panic(fmt.Errorf("cyclic resource ID reference: %v", strings.Join(cycle, " -> ")))
+		}
+	}
+	if depth >= _this.Options.MaxReferenceDepth {
+		panic(fmt.Errorf("exceeded max reference depth of %v while loading [%v]", _this.Options.MaxReferenceDepth, resolvedID))
+	}
+	if _this.referencedDocumentCount >= _this.Options.MaxReferencedDocumentCount {
+		panic(fmt.Errorf("exceeded max referenced document count of %v while loading [%v]", _this.Options.MaxReferencedDocumentCount, resolvedID))
+	}
+	if uint64(len(document)) > _this.Options.MaxReferencedDocumentByteCount-_this.referencedDocumentByteCount {
+		panic(fmt.Errorf("exceeded max referenced document byte count of %v while loading [%v]", _this.Options.MaxReferencedDocumentByteCount, resolvedID))
+	}
+	_this.referencedDocumentCount++
+	_this.referencedDocumentByteCount += uint64(len(document))
+
+	// Markers are local to the document they're in.
+	outerReferenceFiller := _this.referenceFiller
+	_this.referenceFiller.Init()
+	_this.referencedDocuments = append(_this.referencedDocuments, resolvedID)
+
+	receiver := _this.newReferencedDocumentRules(events.NewDocumentlessEventReceiver(_this.eventReceiver))
+	if err := events.DecodeDocument(document, receiver); err != nil {
+		panic(fmt.Errorf("error in referenced document [%v]: %v", resolvedID, err))
+	}
+
+	_this.referencedDocuments = _this.referencedDocuments[:depth]
+	_this.referenceFiller = outerReferenceFiller
+}
+
+type convertedObjectKey struct {
+	pointer uintptr
+	length  int
+	dstType reflect.Type
+}
+
+func newConvertedObjectKey(src reflect.Value, dstType reflect.Type) convertedObjectKey {
+	key := convertedObjectKey{pointer: src.Pointer(), dstType: dstType}
+	if src.Kind() == reflect.Slice {
+		key.length = src.Len()
+	}
+	return key
+}
+
+// Record that src (a pointer, map, or slice) was converted to converted.
+func (_this *Context) notifyConvertedObject(src reflect.Value, converted reflect.Value) {
+	if _this.convertedObjects == nil {
+		_this.convertedObjects = make(map[convertedObjectKey]reflect.Value)
+	}
+	_this.convertedObjects[newConvertedObjectKey(src, converted.Type())] = converted
+}
+
+// If src (a pointer, map, or slice) was already converted to dst's type, set
+// dst to the converted object and return true.
+func (_this *Context) trySetConvertedObject(src reflect.Value, dst reflect.Value) bool {
+	if converted, ok := _this.convertedObjects[newConvertedObjectKey(src, dst.Type())]; ok {
+		dst.Set(converted)
+		return true
+	}
+	return false
+}
+
 func (_this *Context) TryBuildFromCustom(builder Builder, arrayType events.ArrayType, value []byte, dst reflect.Value) bool {
 	switch arrayType {
 	case events.ArrayTypeCustomBinary:
@@ -126,23 +281,28 @@ func (_this *Context) TryBuildFromCustom(builder Builder, arrayType events.Array
 	}
 }
 
-func (_this *Context) BeginArray(arrayCompletionCallback func([]byte)) {
+func (_this *Context) BeginArray(arrayType events.ArrayType, arrayCompletionCallback func(elementCount uint64, data []byte)) {
 	_this.arrayCompletionCallback = arrayCompletionCallback
+	_this.chunkedElementBitWidth = arrayType.ElementSize()
+	_this.chunkedElementCount = 0
 	_this.chunkedData = _this.chunkedData[:0]
 }
-func (_this *Context) BeginArrayChunk(length uint64, moreChunksFollow bool) {
-	_this.chunkRemainingLength = length
+func (_this *Context) BeginArrayChunk(elementCount uint64, moreChunksFollow bool) {
+	_this.chunkRemainingLength = common.ElementCountToByteCount(_this.chunkedElementBitWidth, elementCount)
+	_this.chunkedElementCount += elementCount
 	_this.moreChunksFollow = moreChunksFollow
 	if !_this.moreChunksFollow && _this.chunkRemainingLength == 0 {
-		_this.arrayCompletionCallback(_this.chunkedData)
-		_this.chunkedData = _this.chunkedData[:0]
+		_this.completeChunkedArray()
 	}
 }
 func (_this *Context) AddArrayData(data []byte) {
 	_this.chunkedData = append(_this.chunkedData, data...)
 	_this.chunkRemainingLength -= uint64(len(data))
 	if !_this.moreChunksFollow && _this.chunkRemainingLength == 0 {
-		_this.arrayCompletionCallback(_this.chunkedData)
-		_this.chunkedData = _this.chunkedData[:0]
+		_this.completeChunkedArray()
 	}
 }
+func (_this *Context) completeChunkedArray() {
+	_this.arrayCompletionCallback(_this.chunkedElementCount, _this.chunkedData)
+	_this.chunkedData = _this.chunkedData[:0]
+}
@@ -21,11 +21,13 @@
 package builder
 
 import (
+	"math"
 	"math/big"
 	"net/url"
 	"reflect"
 
 	"github.com/kstenerud/go-concise-encoding/internal/common"
+	"github.com/kstenerud/go-concise-encoding/options"
 
 	"github.com/kstenerud/go-concise-encoding/conversions"
 
@@ -35,182 +37,315 @@ import (
 
 // Int
 
-func setIntFromInt(value int64, dst reflect.Value) {
+func setIntFromInt(ctx *Context, value int64, dst reflect.Value) {
 	dst.SetInt(value)
 	if dst.Int() != value {
-		PanicCannotConvert(value, dst.Type())
+		saturateInt(ctx, value, value > 0, dst)
 	}
 }
 
-func setIntFromUint(value uint64, dst reflect.Value) {
-	i, err := conversions.UintToInt(value)
-	if err != nil {
-		PanicErrorConverting(value, dst.Type(), err)
-
+func setIntFromUint(ctx *Context, value uint64, dst reflect.Value) {
+	if value > math.MaxInt64 {
+		saturateInt(ctx, value, true, dst)
+		return
 	}
-	dst.SetInt(i)
+	dst.SetInt(int64(value))
 	if uint64(dst.Int()) != value {
-		PanicCannotConvert(value, dst.Type())
+		saturateInt(ctx, value, true, dst)
 	}
 }
 
-func setIntFromBigInt(value *big.Int, dst reflect.Value) {
-	i, err := conversions.BigIntToInt(value)
-	if err != nil {
-		PanicErrorConverting(value, dst.Type(), err)
-
+func setIntFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) {
+	if !value.IsInt64() {
+		saturateInt(ctx, value, value.Sign() > 0, dst)
+		return
 	}
+	i := value.Int64()
 	dst.SetInt(i)
 	if dst.Int() != i {
-		PanicCannotConvert(value, dst.Type())
+		saturateInt(ctx, value, i > 0, dst)
 	}
 }
 
-func setIntFromFloat(value float64, dst reflect.Value) {
-	dst.SetInt(int64(value))
-	if float64(dst.Int()) != value {
+func setIntFromFloat(ctx *Context, value float64, dst reflect.Value) {
+	if !isWholeFloat(value) {
 		PanicCannotConvert(value, dst.Type())
 	}
+	// Converting an out-of-range float to int64 is implementation-defined,
+	// so check the range first. -2^63 and 2^63 are exactly representable.
+	if value < math.MinInt64 || value >= -math.MinInt64 {
+		saturateInt(ctx, value, value > 0, dst)
+		return
+	}
+	setIntFromInt(ctx, int64(value), dst)
 }
 
-func setIntFromBigFloat(value *big.Float, dst reflect.Value) {
-	i, err := conversions.BigFloatToInt(value)
-	if err != nil {
-		PanicErrorConverting(value, dst.Type(), err)
-	}
-	dst.SetInt(i)
-	if dst.Int() != i {
+func setIntFromBigFloat(ctx *Context, value *big.Float, dst reflect.Value) {
+	if value.IsInf() || !value.IsInt() {
 		PanicCannotConvert(value, dst.Type())
 	}
+	i, accuracy := value.Int64()
+	if accuracy != big.Exact {
+		saturateInt(ctx, value, value.Sign() > 0, dst)
+		return
+	}
+	setIntFromInt(ctx, i, dst)
 }
 
-func setIntFromDecimalFloat(value compact_float.DFloat, dst reflect.Value) {
-	i, err := value.Int()
-	if err != nil {
-		PanicErrorConverting(value, dst.Type(), err)
-	}
-	dst.SetInt(i)
-	if dst.Int() != i {
-		PanicCannotConvert(value, dst.Type())
-	}
+func setIntFromDecimalFloat(ctx *Context, value compact_float.DFloat, dst reflect.Value) {
+	setIntFromBigDecimalFloat(ctx, value.APD(), dst)
 }
 
-func setIntFromBigDecimalFloat(value *apd.Decimal, dst reflect.Value) {
+func setIntFromBigDecimalFloat(ctx *Context, value *apd.Decimal, dst reflect.Value) {
+	if !isWholeBigDecimalFloat(value) {
+		PanicCannotConvert(value, dst.Type())
+	}
+	// A whole number only fails to convert if it's out of range.
 	i, err := value.Int64()
 	if err != nil {
-		PanicErrorConverting(value, dst.Type(), err)
+		saturateInt(ctx, value, value.Sign() > 0, dst)
+		return
 	}
-	dst.SetInt(i)
-	if dst.Int() != i {
+	setIntFromInt(ctx, i, dst)
+}
+
+// Called when an integer value doesn't fit into dst. Either clamps dst to its
+// min or max value, or panics, depending on the integer overflow policy.
+func saturateInt(ctx *Context, value interface{}, isPositive bool, dst reflect.Value) {
+	if ctx.Options.IntegerOverflowPolicy != options.IntegerOverflowPolicySaturate {
 		PanicCannotConvert(value, dst.Type())
 	}
+	bitCount := uint(dst.Type().Bits())
+	if isPositive {
+		dst.SetInt(int64(uint64(math.MaxUint64) >> (65 - bitCount)))
+	} else {
+		dst.SetInt(-1 << (bitCount - 1))
+	}
 }
 
+// Returns true if value is finite and has no fractional part.
+func isWholeFloat(value float64) bool {
+	return !math.IsInf(value, 0) && value == math.Trunc(value)
+}
+
+// Returns true if value is finite and has no fractional part.
+func isWholeBigDecimalFloat(value *apd.Decimal) bool {
+	if value.Form != apd.Finite {
+		return false
+	}
+	integ, frac := new(apd.Decimal), new(apd.Decimal)
+	value.Modf(integ, frac)
+	return frac.IsZero()
+}
+
+var bigDecimalFloatMaxUint64 = apd.NewWithBigInt(new(big.Int).SetUint64(math.MaxUint64), 0)
+
 // UInt
 
-func setUintFromInt(value int64, dst reflect.Value) {
-	u, err := conversions.IntToUint(value)
-	if err != nil {
-		PanicErrorConverting(value, dst.Type(), err)
+func setUintFromInt(ctx *Context, value int64, dst reflect.Value) {
+	if value < 0 {
+		saturateUint(ctx, value, false, dst)
+		return
 	}
-	setUintFromUint(u, dst)
+	setUintFromUint(ctx, uint64(value), dst)
 }
 
-func setUintFromUint(value uint64, dst reflect.Value) {
+func setUintFromUint(ctx *Context, value uint64, dst reflect.Value) {
 	dst.SetUint(value)
 	if dst.Uint() != value {
-		PanicCannotConvert(value, dst.Type())
+		saturateUint(ctx, value, true, dst)
 	}
 }
 
-func setUintFromBigInt(value *big.Int, dst reflect.Value) {
-	u, err := conversions.BigIntToUint(value)
-	if err != nil {
-		PanicErrorConverting(value, dst.Type(), err)
+func setUintFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) {
+	if !value.IsUint64() {
+		saturateUint(ctx, value, value.Sign() > 0, dst)
+		return
 	}
-	setUintFromUint(u, dst)
+	setUintFromUint(ctx, value.Uint64(), dst)
 }
 
-func setUintFromFloat(value float64, dst reflect.Value) {
-	u := uint64(value)
-	if float64(u) != value {
+func setUintFromFloat(ctx *Context, value float64, dst reflect.Value) {
+	if !isWholeFloat(value) {
 		PanicCannotConvert(value, dst.Type())
 	}
-	setUintFromUint(u, dst)
+	// 2^64 is exactly representable, and is the first value out of range.
+	if value < 0 || value >= math.MaxUint64 {
+		saturateUint(ctx, value, value > 0, dst)
+		return
+	}
+	setUintFromUint(ctx, uint64(value), dst)
 }
 
-func setUintFromBigFloat(value *big.Float, dst reflect.Value) {
-	u, err := conversions.BigFloatToUint(value)
-	if err != nil {
-		PanicErrorConverting(value, dst.Type(), err)
+func setUintFromBigFloat(ctx *Context, value *big.Float, dst reflect.Value) {
+	if value.IsInf() || !value.IsInt() {
+		PanicCannotConvert(value, dst.Type())
+	}
+	u, accuracy := value.Uint64()
+	if accuracy != big.Exact {
+		saturateUint(ctx, value, value.Sign() > 0, dst)
+		return
 	}
-	setUintFromUint(u, dst)
+	setUintFromUint(ctx, u, dst)
 }
 
-func setUintFromDecimalFloat(value compact_float.DFloat, dst reflect.Value) {
-	u, err := value.Uint()
-	if err != nil {
-		PanicErrorConverting(value, dst.Type(), err)
-	}
-	setUintFromUint(u, dst)
+func setUintFromDecimalFloat(ctx *Context, value compact_float.DFloat, dst reflect.Value) {
+	setUintFromBigDecimalFloat(ctx, value.APD(), dst)
 }
 
-func setUintFromBigDecimalFloat(value *apd.Decimal, dst reflect.Value) {
+func setUintFromBigDecimalFloat(ctx *Context, value *apd.Decimal, dst reflect.Value) {
+	if !isWholeBigDecimalFloat(value) {
+		PanicCannotConvert(value, dst.Type())
+	}
+	if value.Sign() < 0 || value.Cmp(bigDecimalFloatMaxUint64) > 0 {
+		saturateUint(ctx, value, value.Sign() > 0, dst)
+		return
+	}
 	u, err := conversions.BigDecimalFloatToUint(value)
 	if err != nil {
 		PanicErrorConverting(value, dst.Type(), err)
 	}
-	setUintFromUint(u, dst)
+	setUintFromUint(ctx, u, dst)
 }
 
-// Float
+// Called when an unsigned integer value doesn't fit into dst. Either clamps
+// dst to its min or max value, or panics, depending on the integer overflow
+// policy.
+func saturateUint(ctx *Context, value interface{}, isPositive bool, dst reflect.Value) {
+	if ctx.Options.IntegerOverflowPolicy != options.IntegerOverflowPolicySaturate {
+		PanicCannotConvert(value, dst.Type())
+	}
+	if isPositive {
+		dst.SetUint(uint64(math.MaxUint64) >> (64 - uint(dst.Type().Bits())))
+	} else {
+		dst.SetUint(0)
+	}
+}
 
-// TODO: When to allow lossy conversions?
+// Float
 
-func setFloatFromInt(value int64, dst reflect.Value) {
+func setFloatFromInt(ctx *Context, value int64, dst reflect.Value) {
 	dst.SetFloat(float64(value))
 	if int64(dst.Float()) != value {
 		PanicCannotConvert(value, dst.Type())
 	}
 }
 
-func setFloatFromUint(value uint64, dst reflect.Value) {
+func setFloatFromUint(ctx *Context, value uint64, dst reflect.Value) {
 	dst.SetFloat(float64(value))
 	if uint64(dst.Float()) != value {
 		PanicCannotConvert(value, dst.Type())
 	}
 }
 
-func setFloatFromFloat(value float64, dst reflect.Value) {
-	dst.SetFloat(value)
+func setFloatFromFloat(ctx *Context, value float64, dst reflect.Value) {
+	setFloatChecked(ctx, value, value, true, dst)
 }
 
-func setFloatFromBigInt(value *big.Int, dst reflect.Value) {
+func setFloatFromBigInt(ctx *Context, value *big.Int, dst reflect.Value) {
 	v, err := conversions.BigIntToFloat(value)
 	if err != nil {
 		PanicErrorConverting(value, dst.Type(), err)
 	}
-	dst.SetFloat(v)
+	setFloatChecked(ctx, value, v, true, dst)
 }
 
-func setFloatFromBigFloat(value *big.Float, dst reflect.Value) {
+func setFloatFromBigFloat(ctx *Context, value *big.Float, dst reflect.Value) {
 	f, err := conversions.BigFloatToFloat(value)
 	if err != nil {
 		PanicErrorConverting(value, dst.Type(), err)
 	}
+	_, accuracy := value.Float64()
+	setFloatChecked(ctx, value, f, accuracy == big.Exact, dst)
+}
+
+func setFloatFromDecimalFloat(ctx *Context, value compact_float.DFloat, dst reflect.Value) {
+	f := value.Float()
+	isExact := true
+	if !ctx.Options.AllowLossyFloatConversion {
+		isExact = isDecimalExactlyFloat(value.APD(), f)
+	}
+	setFloatChecked(ctx, value, f, isExact, dst)
+}
+
+func setFloatFromBigDecimalFloat(ctx *Context, value *apd.Decimal, dst reflect.Value) {
+	f, err := value.Float64()
+	if err != nil {
+		PanicErrorConverting(value, dst.Type(), err)
+	}
+	isExact := true
+	if !ctx.Options.AllowLossyFloatConversion {
+		isExact = isDecimalExactlyFloat(value, f)
+	}
+	setFloatChecked(ctx, value, f, isExact, dst)
+}
+
+// Set dst to f (which was converted from value), and panic if lossy float
+// conversions are disallowed and either the conversion to f was inexact, or
+// dst can't hold f exactly.
+func setFloatChecked(ctx *Context, value interface{}, f float64, isExact bool, dst reflect.Value) {
 	dst.SetFloat(f)
+	if ctx.Options.AllowLossyFloatConversion {
+		return
+	}
+	if !isExact || (dst.Float() != f && !math.IsNaN(f)) {
+		PanicLossyConversion(value, dst.Type(), dst.Interface())
+	}
+}
+
+// Returns true if f has exactly the same value as the decimal value.
+func isDecimalExactlyFloat(value *apd.Decimal, f float64) bool {
+	if value.Form != apd.Finite {
+		return true
+	}
+	if value.IsZero() {
+		return f == 0
+	}
+	if f == 0 || math.IsInf(f, 0) {
+		return false
+	}
+
+	exponent := int64(value.Exponent)
+	if exponent < 0 {
+		exponent = -exponent
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(exponent), nil)
+	rat := new(big.Rat).SetInt(&value.Coeff)
+	if value.Exponent >= 0 {
+		rat.Mul(rat, new(big.Rat).SetInt(scale))
+	} else {
+		rat.Quo(rat, new(big.Rat).SetInt(scale))
+	}
+	if value.Negative {
+		rat.Neg(rat)
+	}
+	return rat.Cmp(new(big.Rat).SetFloat64(f)) == 0
+}
+
+// Strings (lenient coercion)
+
+func setIntFromString(ctx *Context, value string, dst reflect.Value) {
+	i, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		PanicCannotConvert(value, dst.Type())
+	}
+	setIntFromBigInt(ctx, i, dst)
 }
 
-func setFloatFromDecimalFloat(value compact_float.DFloat, dst reflect.Value) {
-	dst.SetFloat(value.Float())
+func setUintFromString(ctx *Context, value string, dst reflect.Value) {
+	u, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		PanicCannotConvert(value, dst.Type())
+	}
+	setUintFromBigInt(ctx, u, dst)
 }
 
-func setFloatFromBigDecimalFloat(value *apd.Decimal, dst reflect.Value) {
-	v, err := value.Float64()
+func setFloatFromString(ctx *Context, value string, dst reflect.Value) {
+	f, _, err := apd.NewFromString(value)
 	if err != nil {
 		PanicErrorConverting(value, dst.Type(), err)
 	}
-	dst.SetFloat(v)
+	setFloatFromBigDecimalFloat(ctx, f, dst)
 }
 
 // BigInt
@@ -513,121 +648,137 @@ func setPRIDFromString(value string, dst reflect.Value) {
 
 // Anything
 
-func setUintFromAnything(src reflect.Value, dst reflect.Value) {
+func setUintFromAnything(ctx *Context, src reflect.Value, dst reflect.Value) {
 	switch src.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		setUintFromInt(src.Int(), dst)
+		setUintFromInt(ctx, src.Int(), dst)
 		return
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		setUintFromUint(src.Uint(), dst)
+		setUintFromUint(ctx, src.Uint(), dst)
 		return
 	case reflect.Float32, reflect.Float64:
-		setUintFromFloat(src.Float(), dst)
+		setUintFromFloat(ctx, src.Float(), dst)
 		return
 	case reflect.Interface:
-		setUintFromAnything(src.Elem(), dst)
+		setUintFromAnything(ctx, src.Elem(), dst)
 		return
 	case reflect.Struct:
 		switch src.Type() {
 		case common.TypeDFloat:
-			setUintFromDecimalFloat(src.Interface().(compact_float.DFloat), dst)
+			setUintFromDecimalFloat(ctx, src.Interface().(compact_float.DFloat), dst)
 			return
 		}
 	case reflect.Ptr:
 		switch src.Type() {
 		case common.TypePBigInt:
-			setUintFromBigInt(src.Interface().(*big.Int), dst)
+			setUintFromBigInt(ctx, src.Interface().(*big.Int), dst)
 			return
 		case common.TypePBigFloat:
-			setUintFromBigFloat(src.Interface().(*big.Float), dst)
+			setUintFromBigFloat(ctx, src.Interface().(*big.Float), dst)
 			return
 		case common.TypePBigDecimalFloat:
-			setUintFromBigDecimalFloat(src.Interface().(*apd.Decimal), dst)
+			setUintFromBigDecimalFloat(ctx, src.Interface().(*apd.Decimal), dst)
 			return
 		}
-		setUintFromAnything(src.Elem(), dst)
+		setUintFromAnything(ctx, src.Elem(), dst)
 		return
 	}
 	PanicCannotConvertRV(src, dst.Type())
 }
 
-func setIntFromAnything(src reflect.Value, dst reflect.Value) {
+func setIntFromAnything(ctx *Context, src reflect.Value, dst reflect.Value) {
 	switch src.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		setIntFromInt(src.Int(), dst)
+		setIntFromInt(ctx, src.Int(), dst)
 		return
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		setIntFromUint(src.Uint(), dst)
+		setIntFromUint(ctx, src.Uint(), dst)
 		return
 	case reflect.Float32, reflect.Float64:
-		setIntFromFloat(src.Float(), dst)
+		setIntFromFloat(ctx, src.Float(), dst)
 		return
 	case reflect.Interface:
-		setIntFromAnything(src.Elem(), dst)
+		setIntFromAnything(ctx, src.Elem(), dst)
 		return
 	case reflect.Struct:
 		switch src.Type() {
 		case common.TypeDFloat:
-			setIntFromDecimalFloat(src.Interface().(compact_float.DFloat), dst)
+			setIntFromDecimalFloat(ctx, src.Interface().(compact_float.DFloat), dst)
 			return
 		}
 	case reflect.Ptr:
 		switch src.Type() {
 		case common.TypePBigInt:
-			setIntFromBigInt(src.Interface().(*big.Int), dst)
+			setIntFromBigInt(ctx, src.Interface().(*big.Int), dst)
 			return
 		case common.TypePBigFloat:
-			setIntFromBigFloat(src.Interface().(*big.Float), dst)
+			setIntFromBigFloat(ctx, src.Interface().(*big.Float), dst)
 			return
 		case common.TypePBigDecimalFloat:
-			setIntFromBigDecimalFloat(src.Interface().(*apd.Decimal), dst)
+			setIntFromBigDecimalFloat(ctx, src.Interface().(*apd.Decimal), dst)
 			return
 		}
-		setIntFromAnything(src.Elem(), dst)
+		setIntFromAnything(ctx, src.Elem(), dst)
 		return
 	}
 	PanicCannotConvertRV(src, dst.Type())
 }
 
-func setFloatFromAnything(src reflect.Value, dst reflect.Value) {
+func setFloatFromAnything(ctx *Context, src reflect.Value, dst reflect.Value) {
 	switch src.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		setFloatFromInt(src.Int(), dst)
+		setFloatFromInt(ctx, src.Int(), dst)
 		return
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		setFloatFromUint(src.Uint(), dst)
+		setFloatFromUint(ctx, src.Uint(), dst)
 		return
 	case reflect.Float32, reflect.Float64:
-		setFloatFromFloat(src.Float(), dst)
+		setFloatFromFloat(ctx, src.Float(), dst)
 		return
 	case reflect.Interface:
-		setFloatFromAnything(src.Elem(), dst)
+		setFloatFromAnything(ctx, src.Elem(), dst)
 		return
 	case reflect.Struct:
 		switch src.Type() {
 		case common.TypeDFloat:
-			setFloatFromDecimalFloat(src.Interface().(compact_float.DFloat), dst)
+			setFloatFromDecimalFloat(ctx, src.Interface().(compact_float.DFloat), dst)
 			return
 		}
 	case reflect.Ptr:
 		switch src.Type() {
 		case common.TypePBigInt:
-			setFloatFromBigInt(src.Interface().(*big.Int), dst)
+			setFloatFromBigInt(ctx, src.Interface().(*big.Int), dst)
 			return
 		case common.TypePBigFloat:
-			setFloatFromBigFloat(src.Interface().(*big.Float), dst)
+			setFloatFromBigFloat(ctx, src.Interface().(*big.Float), dst)
 			return
 		case common.TypePBigDecimalFloat:
-			setFloatFromBigDecimalFloat(src.Interface().(*apd.Decimal), dst)
+			setFloatFromBigDecimalFloat(ctx, src.Interface().(*apd.Decimal), dst)
 			return
 		}
-		setFloatFromAnything(src.Elem(), dst)
+		setFloatFromAnything(ctx, src.Elem(), dst)
 		return
 	}
 	PanicCannotConvert(src, dst.Type())
 }
 
-func setAnythingFromAnything(src reflect.Value, dst reflect.Value) {
+// Set dst from a referenced object. Pointers, maps, and slices that src
+// refers to more than once (including via cycles) are converted only once.
+func setFromReferencedObject(ctx *Context, src reflect.Value, dst reflect.Value) {
+	ctx.convertedObjects = nil
+	setAnythingFromAnything(ctx, src, dst)
+	ctx.convertedObjects = nil
+}
+
+func setAnythingFromAnything(ctx *Context, src reflect.Value, dst reflect.Value) {
+	if !src.IsValid() {
+		if common.IsNullable(dst) {
+			dst.Set(reflect.Zero(dst.Type()))
+			return
+		}
+		PanicCannotConvert(nil, dst.Type())
+	}
+
 	if src.Type() == dst.Type() {
 		dst.Set(src)
 		return
@@ -648,24 +799,195 @@ func setAnythingFromAnything(src reflect.Value, dst reflect.Value) {
 		dst.Set(src)
 		return
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		setUintFromAnything(src, dst)
+		setUintFromAnything(ctx, src, dst)
 		return
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		setIntFromAnything(src, dst)
+		setIntFromAnything(ctx, src, dst)
 		return
 	case reflect.Float32, reflect.Float64:
-		setFloatFromAnything(src, dst)
+		setFloatFromAnything(ctx, src, dst)
+		return
+	}
+
+	switch src.Kind() {
+	case reflect.Interface:
+		setAnythingFromAnything(ctx, src.Elem(), dst)
 		return
+	case reflect.Ptr:
+		if dst.Kind() != reflect.Ptr {
+			if src.IsNil() {
+				PanicCannotConvertRV(src, dst.Type())
+			}
+			setAnythingFromAnything(ctx, src.Elem(), dst)
+			return
+		}
+	}
+
+	switch dst.Kind() {
 	case reflect.Array:
-		panic("TODO: setAnythingFromAnything: Array")
+		setArrayFromAnything(ctx, src, dst)
+		return
 	case reflect.Slice:
-		panic("TODO: setAnythingFromAnything: Slice")
+		setSliceFromAnything(ctx, src, dst)
+		return
 	case reflect.Map:
-		panic("TODO: setAnythingFromAnything: Map")
+		setMapFromAnything(ctx, src, dst)
+		return
 	case reflect.Struct:
-		panic("TODO: setAnythingFromAnything: Struct")
+		setStructFromAnything(ctx, src, dst)
+		return
 	case reflect.Ptr:
-		panic("TODO: setAnythingFromAnything: Ptr")
+		setPtrFromAnything(ctx, src, dst)
+		return
 	}
-	PanicCannotConvert(src, dst.Type())
+	PanicCannotConvertRV(src, dst.Type())
+}
+
+// Containers
+
+func setArrayFromAnything(ctx *Context, src reflect.Value, dst reflect.Value) {
+	switch src.Kind() {
+	case reflect.Array, reflect.Slice:
+		length := src.Len()
+		if length > dst.Len() {
+			PanicCannotConvertRV(src, dst.Type())
+		}
+		for i := 0; i < length; i++ {
+			setAnythingFromAnything(ctx, src.Index(i), dst.Index(i))
+		}
+		zero := reflect.Zero(dst.Type().Elem())
+		for i := length; i < dst.Len(); i++ {
+			dst.Index(i).Set(zero)
+		}
+		return
+	}
+	PanicCannotConvertRV(src, dst.Type())
+}
+
+func setSliceFromAnything(ctx *Context, src reflect.Value, dst reflect.Value) {
+	switch src.Kind() {
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return
+		}
+		if ctx.trySetConvertedObject(src, dst) {
+			return
+		}
+		length := src.Len()
+		slice := reflect.MakeSlice(dst.Type(), length, length)
+		ctx.notifyConvertedObject(src, slice)
+		for i := 0; i < length; i++ {
+			setAnythingFromAnything(ctx, src.Index(i), slice.Index(i))
+		}
+		dst.Set(slice)
+		return
+	case reflect.Array:
+		length := src.Len()
+		slice := reflect.MakeSlice(dst.Type(), length, length)
+		for i := 0; i < length; i++ {
+			setAnythingFromAnything(ctx, src.Index(i), slice.Index(i))
+		}
+		dst.Set(slice)
+		return
+	}
+	PanicCannotConvertRV(src, dst.Type())
+}
+
+func setMapFromAnything(ctx *Context, src reflect.Value, dst reflect.Value) {
+	if src.Kind() != reflect.Map {
+		PanicCannotConvertRV(src, dst.Type())
+	}
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return
+	}
+	if ctx.trySetConvertedObject(src, dst) {
+		return
+	}
+
+	keyType := dst.Type().Key()
+	valueType := dst.Type().Elem()
+	m := reflect.MakeMapWithSize(dst.Type(), src.Len())
+	ctx.notifyConvertedObject(src, m)
+	iter := src.MapRange()
+	for iter.Next() {
+		key := reflect.New(keyType).Elem()
+		setAnythingFromAnything(ctx, iter.Key(), key)
+		value := reflect.New(valueType).Elem()
+		setAnythingFromAnything(ctx, iter.Value(), value)
+		m.SetMapIndex(key, value)
+	}
+	dst.Set(m)
+}
+
+// Fills a struct from a map or from another struct, matching keys to fields
+// and applying required fields and default values the same way the struct
+// builder does. Unknown keys are ignored.
+func setStructFromAnything(ctx *Context, src reflect.Value, dst reflect.Value) {
+	builder, ok := ctx.GetBuilderGeneratorForType(dst.Type())(ctx).(*structBuilder)
+	if !ok {
+		PanicCannotConvertRV(src, dst.Type())
+	}
+	builder.container = dst
+
+	setField := func(key reflect.Value, value reflect.Value) {
+		if desc := builder.lookupFieldDesc(ctx, key); desc != nil {
+			builder.beginField(desc)
+			setAnythingFromAnything(ctx, value, builder.nextValue)
+		}
+	}
+
+	switch src.Kind() {
+	case reflect.Map:
+		iter := src.MapRange()
+		for iter.Next() {
+			setField(iter.Key(), iter.Value())
+		}
+	case reflect.Struct:
+		srcType := src.Type()
+		for i := 0; i < srcType.NumField(); i++ {
+			if field := srcType.Field(i); field.PkgPath == "" {
+				setField(reflect.ValueOf(field.Name), src.Field(i))
+			}
+		}
+	default:
+		PanicCannotConvertRV(src, dst.Type())
+	}
+	builder.applyFieldRules()
+}
+
+func setPtrFromAnything(ctx *Context, src reflect.Value, dst reflect.Value) {
+	elemType := dst.Type().Elem()
+	if src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return
+		}
+		src = src.Elem()
+	}
+
+	// Point to the original object if possible so that it remains shared.
+	if src.Type() == elemType && src.CanAddr() {
+		dst.Set(src.Addr())
+		return
+	}
+
+	// Convert each shared object only once, so that cycles terminate.
+	var shared reflect.Value
+	switch {
+	case src.CanAddr():
+		shared = src.Addr()
+	case (src.Kind() == reflect.Map || src.Kind() == reflect.Slice) && !src.IsNil():
+		shared = src
+	}
+	if shared.IsValid() && ctx.trySetConvertedObject(shared, dst) {
+		return
+	}
+	ptr := reflect.New(elemType)
+	if shared.IsValid() {
+		ctx.notifyConvertedObject(shared, ptr)
+	}
+	setAnythingFromAnything(ctx, src, ptr.Elem())
+	dst.Set(ptr)
 }
@@ -24,8 +24,6 @@ import (
 	"reflect"
 )
 
-// TODO: Also keep a map of the costs of the references (bytes, depth, etc) (in rules)
-
 // ReferenceFiller tracks markers and references encountered in a document,
 // filling out references when their corresponding markers are found.
 type ReferenceFiller struct {
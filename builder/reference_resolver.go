@@ -0,0 +1,148 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package builder
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Resolve a (possibly relative) resource ID against the resolved ID of the
+// document that contains it. URLs are resolved as per RFC 3986, and anything
+// else is treated as a slash separated path.
+func ResolveRelativeResourceID(baseID string, resourceID string) string {
+	if baseID == "" {
+		return resourceID
+	}
+	if ref, err := url.Parse(resourceID); err != nil || ref.IsAbs() {
+		return resourceID
+	}
+	if base, err := url.Parse(baseID); err == nil && base.IsAbs() {
+		ref, _ := url.Parse(resourceID)
+		return base.ResolveReference(ref).String()
+	}
+	if path.IsAbs(resourceID) {
+		return resourceID
+	}
+	return path.Join(path.Dir(baseID), resourceID)
+}
+
+// ============================================================================
+
+// Fetches the document for an already resolved resource ID.
+type ReferenceFetcherFunc func(resolvedID string) (document []byte, err error)
+
+// Resolve resource IDs relative to their containing document, then fetch them
+// using the function itself.
+func (_this ReferenceFetcherFunc) ResolveReference(baseID string, resourceID string) (resolvedID string, document []byte, err error) {
+	resolvedID = ResolveRelativeResourceID(baseID, resourceID)
+	document, err = _this(resolvedID)
+	return
+}
+
+// ============================================================================
+
+// Resolves resource IDs to documents stored in memory.
+type MemoryReferenceResolver struct {
+	documents map[string][]byte
+}
+
+// Create a resolver that serves documents from a map of resource ID to CTE
+// document.
+func NewMemoryReferenceResolver(documents map[string][]byte) *MemoryReferenceResolver {
+	return &MemoryReferenceResolver{documents: documents}
+}
+
+func (_this *MemoryReferenceResolver) ResolveReference(baseID string, resourceID string) (resolvedID string, document []byte, err error) {
+	resolvedID = ResolveRelativeResourceID(baseID, resourceID)
+	document, ok := _this.documents[resolvedID]
+	if !ok {
+		err = fmt.Errorf("no document registered for resource ID [%v]", resolvedID)
+	}
+	return
+}
+
+// ============================================================================
+
+// Resolves resource IDs to files within a root directory. Resource IDs are
+// slash separated paths (or file URLs), and are relative to the root
+// directory when absolute. Paths leading outside of the root directory are
+// rejected.
+type FileReferenceResolver struct {
+	rootDir string
+}
+
+func NewFileReferenceResolver(rootDir string) *FileReferenceResolver {
+	return &FileReferenceResolver{rootDir: filepath.Clean(rootDir)}
+}
+
+func (_this *FileReferenceResolver) ResolveReference(baseID string, resourceID string) (resolvedID string, document []byte, err error) {
+	resourceID, err = filePathFromResourceID(resourceID)
+	if err != nil {
+		return
+	}
+	if path.IsAbs(resourceID) {
+		resolvedID = path.Clean(resourceID[1:])
+	} else {
+		resolvedID = path.Clean(ResolveRelativeResourceID(baseID, resourceID))
+	}
+	// Backslashes and volume names would be interpreted by filepath on some
+	// platforms, bypassing the path checks.
+	if strings.ContainsRune(resolvedID, '\\') || filepath.VolumeName(filepath.FromSlash(resolvedID)) != "" {
+		err = fmt.Errorf("resource ID [%v] is not a valid file path", resourceID)
+		return
+	}
+
+	filePath := filepath.Join(_this.rootDir, filepath.FromSlash(resolvedID))
+	if !isPathWithinDir(_this.rootDir, filePath) {
+		err = fmt.Errorf("resource ID [%v] leads outside of directory %v", resourceID, _this.rootDir)
+		return
+	}
+	document, err = ioutil.ReadFile(filePath)
+	return
+}
+
+func isPathWithinDir(dir string, filePath string) bool {
+	rel, err := filepath.Rel(dir, filePath)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func filePathFromResourceID(resourceID string) (string, error) {
+	asURL, err := url.Parse(resourceID)
+	if err != nil {
+		return "", err
+	}
+	switch asURL.Scheme {
+	case "":
+		return asURL.Path, nil
+	case "file":
+		return asURL.Path, nil
+	default:
+		return "", fmt.Errorf("resource ID [%v] is not a file path", resourceID)
+	}
+}
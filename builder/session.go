@@ -31,6 +31,7 @@ import (
 
 	"github.com/kstenerud/go-concise-encoding/internal/common"
 	"github.com/kstenerud/go-concise-encoding/options"
+	"github.com/kstenerud/go-concise-encoding/types"
 )
 
 // A builder session holds a cache of known mappings of types to builders.
@@ -39,6 +40,7 @@ import (
 // unintended behavior in codec activity elsewhere in the program.
 type Session struct {
 	builderGenerators sync.Map
+	polymorphicTypes  map[string]reflect.Type
 	opts              options.BuilderSessionOptions
 }
 
@@ -68,10 +70,24 @@ func (_this *Session) Init(parent *Session, opts *options.BuilderSessionOptions)
 		})
 	}
 
+	_this.polymorphicTypes = make(map[string]reflect.Type)
+	for k, v := range parent.polymorphicTypes {
+		_this.polymorphicTypes[k] = v
+	}
+
 	_this.opts = *opts
 	for _, t := range _this.opts.CustomBuiltTypes {
 		_this.RegisterBuilderGeneratorForType(t, generateCustomBuilder)
 	}
+	for _, t := range _this.opts.UUIDTypes {
+		if !common.IsUUIDCompatible(t) {
+			panic(fmt.Errorf("type %v cannot be used as a UUID (must be an array of 16 bytes)", t))
+		}
+		_this.RegisterBuilderGeneratorForType(t, generateUUIDBuilder)
+	}
+	for name, t := range _this.opts.PolymorphicTypes {
+		_this.RegisterPolymorphicType(name, t)
+	}
 }
 
 // NewBuilderFor creates a new builder that builds objects of the same type as
@@ -97,6 +113,16 @@ func (_this *Session) RegisterBuilderGeneratorForType(dstType reflect.Type, buil
 	_this.builderGenerators.Store(dstType, builderGenerator)
 }
 
+// Register a concrete type (a struct or pointer to struct) to be built when
+// a map destined for an interface has a type discriminator of the specified
+// name. Types must be registered before the session is used.
+func (_this *Session) RegisterPolymorphicType(name string, concreteType reflect.Type) {
+	if !common.IsPolymorphicCompatible(concreteType) {
+		panic(fmt.Errorf("type %v cannot be used as a polymorphic type (must be a struct or pointer to struct)", concreteType))
+	}
+	_this.polymorphicTypes[name] = concreteType
+}
+
 // Get a builder generator for the specified type. If a registered generator
 // doesn't yet exist, a new default generator will be generated and registered.
 // This method is thread-safe.
@@ -132,21 +158,37 @@ func (_this *Session) defaultBuilderGeneratorForType(dstType reflect.Type) Build
 	case reflect.Bool:
 		return generateBoolBuilder
 	case reflect.String:
+		if dstType == common.TypeNumber {
+			return generateNumberBuilder
+		}
 		return generateStringBuilder
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return generateIntBuilder
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return generateUintBuilder
+		switch dstType {
+		case common.TypeFloat16, common.TypeBFloat16:
+			return generateHalfFloatBuilder
+		default:
+			return generateUintBuilder
+		}
 	case reflect.Float32, reflect.Float64:
 		return generateFloatBuilder
 	case reflect.Interface:
 		return generateInterfaceBuilder
 	case reflect.Array:
+		if dstType == common.TypeUUID {
+			return generateUUIDBuilder
+		}
 		switch dstType.Elem().Kind() {
 		case reflect.Uint8:
 			return newUint8ArrayBuilderGenerator(dstType)
 		case reflect.Uint16:
-			return newUint16ArrayBuilderGenerator(dstType)
+			switch dstType.Elem() {
+			case common.TypeFloat16, common.TypeBFloat16:
+				return newHalfFloatArrayBuilderGenerator(dstType)
+			default:
+				return newUint16ArrayBuilderGenerator(dstType)
+			}
 		case reflect.Uint32:
 			return newUint32ArrayBuilderGenerator(dstType)
 		case reflect.Uint64:
@@ -163,6 +205,8 @@ func (_this *Session) defaultBuilderGeneratorForType(dstType reflect.Type) Build
 			return newFloat32ArrayBuilderGenerator(dstType)
 		case reflect.Float64:
 			return newFloat64ArrayBuilderGenerator(dstType)
+		case reflect.Bool:
+			return newBoolArrayBuilderGenerator(dstType)
 		default:
 			return newArrayBuilderGenerator(_this.GetBuilderGeneratorForType, dstType)
 		}
@@ -171,7 +215,12 @@ func (_this *Session) defaultBuilderGeneratorForType(dstType reflect.Type) Build
 		case reflect.Uint8:
 			return generateUint8SliceBuilder
 		case reflect.Uint16:
-			return generateUint16SliceBuilder
+			switch dstType.Elem() {
+			case common.TypeFloat16, common.TypeBFloat16:
+				return newHalfFloatSliceBuilderGenerator(dstType)
+			default:
+				return generateUint16SliceBuilder
+			}
 		case reflect.Uint32:
 			return generateUint32SliceBuilder
 		case reflect.Uint64:
@@ -188,6 +237,13 @@ func (_this *Session) defaultBuilderGeneratorForType(dstType reflect.Type) Build
 			return generateFloat32SliceBuilder
 		case reflect.Float64:
 			return generateFloat64SliceBuilder
+		case reflect.Bool:
+			return generateBoolSliceBuilder
+		case reflect.Array:
+			if dstType.Elem() == common.TypeUUID {
+				return generateUUIDSliceBuilder
+			}
+			return newSliceBuilderGenerator(_this.GetBuilderGeneratorForType, dstType)
 		default:
 			return newSliceBuilderGenerator(_this.GetBuilderGeneratorForType, dstType)
 		}
@@ -209,8 +265,10 @@ func (_this *Session) defaultBuilderGeneratorForType(dstType reflect.Type) Build
 			return generateBigFloatBuilder
 		case common.TypeBigDecimalFloat:
 			return generateBigDecimalFloatBuilder
+		case common.TypeOrderedMap:
+			return generateOrderedMapBuilder
 		default:
-			return newStructBuilderGenerator(_this.GetBuilderGeneratorForType, dstType)
+			return newStructBuilderGenerator(_this, dstType)
 		}
 	case reflect.Ptr:
 		switch dstType {
@@ -245,6 +303,8 @@ var listToInt32SliceGenerator BuilderGenerator
 var listToInt64SliceGenerator BuilderGenerator
 var listToFloat32SliceGenerator BuilderGenerator
 var listToFloat64SliceGenerator BuilderGenerator
+var listToBoolSliceGenerator BuilderGenerator
+var listToUUIDSliceGenerator BuilderGenerator
 
 func init() {
 	rootSession.Init(nil, nil)
@@ -280,4 +340,6 @@ func init() {
 	listToInt64SliceGenerator = newSliceBuilderGenerator(rootSession.GetBuilderGeneratorForType, reflect.TypeOf([]int64{}))
 	listToFloat32SliceGenerator = newSliceBuilderGenerator(rootSession.GetBuilderGeneratorForType, reflect.TypeOf([]float32{}))
 	listToFloat64SliceGenerator = newSliceBuilderGenerator(rootSession.GetBuilderGeneratorForType, reflect.TypeOf([]float64{}))
+	listToBoolSliceGenerator = newSliceBuilderGenerator(rootSession.GetBuilderGeneratorForType, reflect.TypeOf([]bool{}))
+	listToUUIDSliceGenerator = newSliceBuilderGenerator(rootSession.GetBuilderGeneratorForType, reflect.TypeOf([]types.UUID{}))
 }
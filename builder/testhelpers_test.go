@@ -27,9 +27,13 @@ import (
 	"time"
 
 	"github.com/kstenerud/go-concise-encoding/events"
+	"github.com/kstenerud/go-concise-encoding/options"
 
 	"github.com/kstenerud/go-concise-encoding/test"
 
+	// Registers the iterator that substitutes constant values
+	_ "github.com/kstenerud/go-concise-encoding/iterator"
+
 	"github.com/cockroachdb/apd/v2"
 	"github.com/kstenerud/go-compact-float"
 	"github.com/kstenerud/go-compact-time"
@@ -116,6 +120,7 @@ func AI64(v []int64) *test.TEvent            { return test.AI64(v) }
 func AF16(v []byte) *test.TEvent             { return test.AF16(v) }
 func AF32(v []float32) *test.TEvent          { return test.AF32(v) }
 func AF64(v []float64) *test.TEvent          { return test.AF64(v) }
+func AUU(v []byte) *test.TEvent              { return test.AUU(v) }
 func SB() *test.TEvent                       { return test.SB() }
 func RB() *test.TEvent                       { return test.RB() }
 func CBB() *test.TEvent                      { return test.CBB() }
@@ -144,6 +149,7 @@ func E() *test.TEvent                        { return test.E() }
 func MARK() *test.TEvent                     { return test.MARK() }
 func CONST(n string, e bool) *test.TEvent    { return test.CONST(n, e) }
 func REF() *test.TEvent                      { return test.REF() }
+func CAT() *test.TEvent                      { return test.CAT() }
 func BD() *test.TEvent                       { return test.BD() }
 func ED() *test.TEvent                       { return test.ED() }
 
@@ -152,7 +158,11 @@ func InvokeEvents(receiver events.DataEventReceiver, events ...*test.TEvent) {
 }
 
 func runBuild(session *Session, template interface{}, events ...*test.TEvent) interface{} {
-	builder := session.NewBuilderFor(template, nil)
+	return runBuildWithOptions(session, nil, template, events...)
+}
+
+func runBuildWithOptions(session *Session, opts *options.BuilderOptions, template interface{}, events ...*test.TEvent) interface{} {
+	builder := session.NewBuilderFor(template, opts)
 	test.InvokeEvents(builder, events...)
 	return builder.GetBuiltObject()
 }
@@ -171,8 +181,21 @@ func assertBuildWithSession(t *testing.T, session *Session, expected interface{}
 	}
 }
 
+func assertBuildWithOptions(t *testing.T, opts *options.BuilderOptions, expected interface{}, events ...*test.TEvent) {
+	actual := runBuildWithOptions(NewSession(nil, nil), opts, expected, events...)
+	if !equivalence.IsEquivalent(expected, actual) {
+		t.Errorf("Expected %v but got %v", describe.D(expected), describe.D(actual))
+	}
+}
+
 func assertBuildPanics(t *testing.T, template interface{}, events ...*test.TEvent) {
 	test.AssertPanics(t, "build", func() {
 		runBuild(NewSession(nil, nil), template, events...)
 	})
 }
+
+func assertBuildPanicsWithOptions(t *testing.T, opts *options.BuilderOptions, template interface{}, events ...*test.TEvent) {
+	test.AssertPanics(t, "build", func() {
+		runBuildWithOptions(NewSession(nil, nil), opts, template, events...)
+	})
+}
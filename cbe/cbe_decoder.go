@@ -70,7 +70,7 @@ func (_this *Decoder) Decode(reader io.Reader, eventReceiver events.DataEventRec
 		}
 	}()
 
-	_this.buffer.Init(reader, _this.opts.BufferSize, chooseLowWater(_this.opts.BufferSize))
+	_this.buffer.Init(common.NewLimitedReader(reader, _this.opts.MaxDocumentByteCount), _this.opts.BufferSize, chooseLowWater(_this.opts.BufferSize))
 	_this.eventReceiver = eventReceiver
 
 	_this.eventReceiver.OnBeginDocument()
@@ -179,6 +179,10 @@ func (_this *Decoder) Decode(reader io.Reader, eventReceiver events.DataEventRec
 			if arrayType == events.ArrayTypeInvalid {
 				panic(fmt.Errorf("0x%02x: Unsupported typed array type", cbeType))
 			}
+			if arrayType == events.ArrayTypeResourceIDConcat {
+				_this.eventReceiver.OnConcatenate()
+				arrayType = events.ArrayTypeResourceID
+			}
 			_this.decodeArray(arrayType)
 		case cbeTypeMarker:
 			_this.eventReceiver.OnMarker()
@@ -48,6 +48,9 @@ import (
 type Encoder struct {
 	buff buffer.StreamingWriteBuffer
 	opts options.CBEEncoderOptions
+
+	// The next resource ID begins a concatenation
+	isConcatenating bool
 }
 
 // Create a new CBE encoder.
@@ -372,7 +375,7 @@ func (_this *Encoder) OnReference() {
 }
 
 func (_this *Encoder) OnConcatenate() {
-	panic("TODO: Remove CBE Encoder OnConcatenate")
+	_this.isConcatenating = true
 }
 
 func (_this *Encoder) OnConstant(name []byte, explicitValue bool) {
@@ -392,6 +395,7 @@ func (_this *Encoder) OnEndDocument() {
 
 func (_this *Encoder) reset() {
 	_this.buff.Reset()
+	_this.isConcatenating = false
 }
 
 const (
@@ -598,6 +602,11 @@ func (_this *Encoder) encodeNaN(signaling bool) {
 }
 
 func (_this *Encoder) encodeArrayHeader(arrayType events.ArrayType) {
+	if _this.isConcatenating && arrayType == events.ArrayTypeResourceID {
+		arrayType = events.ArrayTypeResourceIDConcat
+	}
+	_this.isConcatenating = false
+
 	if isPlane2Array[arrayType] {
 		dst := _this.buff.RequireBytes(2)
 		dst[0] = byte(cbeTypePlane2)
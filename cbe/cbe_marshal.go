@@ -144,8 +144,10 @@ func (_this *Unmarshaler) Unmarshal(reader io.Reader, template interface{}) (dec
 		_this.rules.Reset()
 		_this.rules.SetNextReceiver(receiver)
 		receiver = &_this.rules
+		builder.SetReferencedDocumentRules(_this.rules.NewReferencedDocumentRules)
 	}
 	if err = _this.decoder.Decode(reader, receiver); err != nil {
+		err = builder.AnnotateError(err)
 		return
 	}
 	decoded = builder.GetBuiltObject()
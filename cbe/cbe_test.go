@@ -26,6 +26,8 @@ import (
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/kstenerud/go-concise-encoding/options"
 )
 
 // TODO: Remove this when releasing V1
@@ -437,6 +439,12 @@ func TestCBEReference(t *testing.T) {
 	assertDecodeEncode(t, []byte{header, ceVer, typeReference, typeString1, 'a'}, BD(), V(ceVer), REF(), S("a"), ED())
 }
 
+func TestCBEConcatenate(t *testing.T) {
+	assertDecodeEncode(t, []byte{header, ceVer, typeArray, TypeRID, 0x02, 'a', typeString1, 'b'}, BD(), V(ceVer), CAT(), RID("a"), S("b"), ED())
+	assertDecodeEncode(t, []byte{header, ceVer, typeArray, TypeRID, 0x02, 'a', 1}, BD(), V(ceVer), CAT(), RID("a"), I(1), ED())
+	assertDecodeWithRules(t, nil, []byte{header, ceVer, typeArray, TypeRID, 0x02, 'a', typeString1, 'b'}, BD(), V(ceVer), CAT(), RID("a"), S("b"), ED())
+}
+
 func TestCBEContainers(t *testing.T) {
 	assertDecodeEncode(t, []byte{header, ceVer, typeList, 1, typeEndContainer}, BD(), V(ceVer), L(), I(1), E(), ED())
 	assertDecodeEncode(t, []byte{header, ceVer, typeMap, 1, typeEndContainer}, BD(), V(ceVer), M(), I(1), E(), ED())
@@ -502,3 +510,15 @@ func TestCBEDuplicateEmptySliceInSlice(t *testing.T) {
 	v := []interface{}{sl, sl, sl}
 	assertMarshalUnmarshal(t, v, []byte{header, ceVer, 0x7a, 0x7a, 0x7b, 0x7a, 0x7b, 0x7a, 0x7b, 0x7b})
 }
+
+func TestCBEMaxDocumentByteCount(t *testing.T) {
+	document := []byte{header, ceVer, typeList, 0x01, 0x02, 0x03, typeEndContainer}
+	opts := options.DefaultCBEDecoderOptions()
+	opts.MaxDocumentByteCount = uint64(len(document))
+	assertDecode(t, opts, document, BD(), V(ceVer), L(), I(1), I(2), I(3), E(), ED())
+
+	opts.MaxDocumentByteCount = uint64(len(document) - 1)
+	if _, err := decodeToEvents(opts, document, false); err == nil {
+		t.Errorf("Expected decode to fail")
+	}
+}
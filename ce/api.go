@@ -35,6 +35,9 @@
 //
 // Note: Whitespace will be trimmed, so "flag1 , key1 = value1 " will work.
 //
+// If the session option FallbackToJSONTags is set, fields without a "ce" tag
+// will use their "json" tag instead (name, "-", omitempty, and string).
+//
 // The following tag values are recognized:
 //
 // omit: (flag) This struct field will not be written to a CE document, nor
@@ -44,6 +47,21 @@
 //
 // name: (k=v) Specifies the name to use when encoding/decoding to a document.
 //
+// omitempty: (flag) This struct field will not be written to a CE document if
+//            it contains a zero value or an empty container.
+//
+// id:   (k=v) Specifies a non-negative integer ID to use as the map key
+//       instead of the field name when encoding. When decoding, either the ID
+//       or the name will be accepted. This makes for smaller documents.
+//
+// required: (flag) When decoding, this field must be present in the document.
+//           All missing required fields are reported together in one error.
+//
+// default: (k=v) When decoding, this field will be set to the specified value
+//          (written as a CTE literal) if it's absent from the document.
+//          Example: ce:"default=10" or ce:"default=\"some, string\""
+//          Note: Commas are only allowed inside quoted strings.
+//
 package ce
 
 import (
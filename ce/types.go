@@ -0,0 +1,72 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package ce
+
+import (
+	"github.com/kstenerud/go-concise-encoding/internal/common"
+	"github.com/kstenerud/go-concise-encoding/types"
+)
+
+// A universally unique identifier (RFC 4122). Iterators emit a UUID for
+// values of this type, and builders produce this type when building a UUID
+// into an interface{}.
+type UUID = types.UUID
+
+// Generate a random (version 4) UUID.
+func NewRandomUUID() (UUID, error) { return types.NewRandomUUID() }
+
+// Parse a UUID in canonical form (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx).
+func ParseUUID(str string) (UUID, error) { return types.ParseUUID(str) }
+
+// An IEEE 754 half precision float. Arrays of Float16 are written as float32
+// arrays because CE float16 arrays use the bfloat16 format.
+type Float16 = types.Float16
+
+// A bfloat16 ("brain float") value. Arrays of BFloat16 are written as CE
+// float16 arrays.
+type BFloat16 = types.BFloat16
+
+// Convert a float32 to the nearest Float16 (values too large become infinity).
+func Float16FromFloat32(value float32) Float16 { return types.Float16FromFloat32(value) }
+
+// Convert a float32 to the nearest BFloat16.
+func BFloat16FromFloat32(value float32) BFloat16 { return types.BFloat16FromFloat32(value) }
+
+// A number stored as its exact decimal text. Builders can produce Numbers
+// when building integers into an interface{} (see
+// options.BuilderOptions.InterfaceIntegerType).
+type Number = types.Number
+
+// A map that preserves the order of its keys. Builders fill an OrderedMap in
+// document order, and iterators emit its entries in order.
+type OrderedMap = types.OrderedMap
+
+// A key-value pair stored in an OrderedMap.
+type MapEntry = types.MapEntry
+
+// Create a new, empty OrderedMap.
+func NewOrderedMap() *OrderedMap { return types.NewOrderedMap() }
+
+// The default map key ordering used when options.IteratorOptions.SortMapKeys
+// or options.CTEEncoderOptions.SortMapKeys is set. It returns a negative
+// number, zero, or a positive number when a sorts before, equal to, or after b.
+// Custom comparators can fall back to it for keys they don't handle.
+func CompareMapKeys(a, b interface{}) int { return common.CompareMapKeys(a, b) }
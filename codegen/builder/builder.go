@@ -46,7 +46,6 @@ var (
 	Time           = "BuildFromTime(ctx *Context, value time.Time, dst reflect.Value) reflect.Value"
 	CTime          = "BuildFromCompactTime(ctx *Context, value compact_time.Time, dst reflect.Value) reflect.Value"
 	Ref            = "BuildFromReference(ctx *Context, id interface{})"
-	Cat            = "BuildConcatenate(ctx *Context)"
 	ListInit       = "BuildInitiateList(ctx *Context)"
 	MapInit        = "BuildInitiateMap(ctx *Context)"
 	End            = "BuildEndContainer(ctx *Context)"
@@ -56,7 +55,7 @@ var (
 
 	allMethods = []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat,
 		BigDFloat, UUID, Array, SArray, Time, CTime, ListInit, MapInit, List,
-		Map, End, Ref, Cat, NotifyFinished}
+		Map, End, Ref, NotifyFinished}
 )
 
 type Builder struct {
@@ -83,12 +82,24 @@ var builders = []Builder{
 	},
 	{
 		Name:    "bool",
-		Methods: []string{Bool},
+		Methods: []string{Bool, Array, SArray},
+	},
+	{
+		Name:    "boolArray",
+		Methods: []string{Array, List},
+	},
+	{
+		Name:    "boolSlice",
+		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, List},
 	},
 	{
 		Name:    "compactTime",
 		Methods: []string{Nil, Time, CTime},
 	},
+	{
+		Name:    "concat",
+		Methods: []string{Int, Uint, BigInt, Array, SArray},
+	},
 	{
 		Name:    "custom",
 		Methods: []string{Array},
@@ -99,7 +110,7 @@ var builders = []Builder{
 	},
 	{
 		Name:    "float",
-		Methods: []string{Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat},
+		Methods: []string{Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, Array, SArray},
 	},
 	{
 		Name:    "float32Array",
@@ -107,7 +118,7 @@ var builders = []Builder{
 	},
 	{
 		Name:    "float32Slice",
-		Methods: []string{Nil, Array, List},
+		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, List},
 	},
 	{
 		Name:    "float64Array",
@@ -115,6 +126,18 @@ var builders = []Builder{
 	},
 	{
 		Name:    "float64Slice",
+		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, List},
+	},
+	{
+		Name:    "halfFloat",
+		Methods: []string{Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat},
+	},
+	{
+		Name:    "halfFloatArray",
+		Methods: []string{Array, List},
+	},
+	{
+		Name:    "halfFloatSlice",
 		Methods: []string{Nil, Array, List},
 	},
 	{
@@ -123,7 +146,7 @@ var builders = []Builder{
 	},
 	{
 		Name:    "int",
-		Methods: []string{Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat},
+		Methods: []string{Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, Array, SArray},
 	},
 	{
 		Name:    "int8Array",
@@ -131,7 +154,7 @@ var builders = []Builder{
 	},
 	{
 		Name:    "int8Slice",
-		Methods: []string{Nil, Array, List},
+		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, List},
 	},
 	{
 		Name:    "int16Array",
@@ -139,7 +162,7 @@ var builders = []Builder{
 	},
 	{
 		Name:    "int16Slice",
-		Methods: []string{Nil, Array, List},
+		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, List},
 	},
 	{
 		Name:    "int32Array",
@@ -147,7 +170,7 @@ var builders = []Builder{
 	},
 	{
 		Name:    "int32Slice",
-		Methods: []string{Nil, Array, List},
+		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, List},
 	},
 	{
 		Name:    "int64Array",
@@ -155,7 +178,7 @@ var builders = []Builder{
 	},
 	{
 		Name:    "int64Slice",
-		Methods: []string{Nil, Array, List},
+		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, List},
 	},
 	{
 		Name:    "interface",
@@ -173,6 +196,14 @@ var builders = []Builder{
 		Name:    "markerObject",
 		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, MapInit, ListInit, End, NotifyFinished},
 	},
+	{
+		Name:    "number",
+		Methods: []string{Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat},
+	},
+	{
+		Name:    "orderedMap",
+		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, ListInit, MapInit, Map, End, Ref, NotifyFinished},
+	},
 	{
 		Name:    "pBigDecimalFloat",
 		Methods: []string{Nil, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat},
@@ -189,6 +220,10 @@ var builders = []Builder{
 		Name:    "pCompactTime",
 		Methods: []string{Nil, Time, CTime},
 	},
+	{
+		Name:    "polymorphic",
+		Methods: []string{Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, End, NotifyFinished},
+	},
 	{
 		Name:    "ptr",
 		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, List, Map, NotifyFinished},
@@ -199,7 +234,7 @@ var builders = []Builder{
 	},
 	{
 		Name:    "referenceID",
-		Methods: []string{Int, Uint, BigInt},
+		Methods: []string{Int, Uint, BigInt, Array, SArray},
 	},
 	{
 		Name:    "slice",
@@ -215,7 +250,7 @@ var builders = []Builder{
 	},
 	{
 		Name:    "time",
-		Methods: []string{Time, CTime},
+		Methods: []string{Array, SArray, Time, CTime},
 	},
 	{
 		Name:    "topLevel",
@@ -223,7 +258,7 @@ var builders = []Builder{
 	},
 	{
 		Name:    "uint",
-		Methods: []string{Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat},
+		Methods: []string{Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, Array, SArray},
 	},
 	{
 		Name:    "uint8Array",
@@ -239,7 +274,7 @@ var builders = []Builder{
 	},
 	{
 		Name:    "uint16Slice",
-		Methods: []string{Nil, Array, List},
+		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, List},
 	},
 	{
 		Name:    "uint32Array",
@@ -247,7 +282,7 @@ var builders = []Builder{
 	},
 	{
 		Name:    "uint32Slice",
-		Methods: []string{Nil, Array, List},
+		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, List},
 	},
 	{
 		Name:    "uint64Array",
@@ -255,11 +290,18 @@ var builders = []Builder{
 	},
 	{
 		Name:    "uint64Slice",
-		Methods: []string{Nil, Array, List},
+		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, List},
 	},
 	{
 		Name:    "url",
 		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, ListInit, MapInit, End, Ref},
+	}, {
+		Name:    "uuid",
+		Methods: []string{UUID, Array, SArray},
+	},
+	{
+		Name:    "uuidSlice",
+		Methods: []string{Nil, Bool, Int, Uint, BigInt, Float, BigFloat, DFloat, BigDFloat, UUID, Array, SArray, Time, CTime, List},
 	},
 }
 
@@ -159,6 +159,14 @@ var encoders = []Encoder{
 		Name:    "markerID",
 		Methods: []string{Child, Begin, PInt, Int, BInt, Const, Arr, Str, BArr},
 	},
+	{
+		Name:    "concat",
+		Methods: []string{Child, Arr, Str, BArr},
+	},
+	{
+		Name:    "concatSuffix",
+		Methods: []string{Child, PInt, Int, BInt, Arr, Str, BArr},
+	},
 	{
 		Name:    "array",
 		Methods: []string{Chunk, Data},
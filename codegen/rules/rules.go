@@ -54,6 +54,7 @@ var (
 	End     = "OnEnd(ctx *Context)"
 	Marker  = "OnMarker(ctx *Context)"
 	Ref     = "OnReference(ctx *Context)"
+	Cat     = "OnConcatenate(ctx *Context)"
 	Const   = "OnConstant(ctx *Context, name []byte, explicitValue bool)"
 	Array   = "OnArray(ctx *Context, arrayType events.ArrayType, elementCount uint64, data []uint8)"
 	SArray  = "OnStringlikeArray(ctx *Context, arrayType events.ArrayType, data string)"
@@ -63,7 +64,7 @@ var (
 
 	allMethods = []string{BDoc, EDoc, ECtr, Ver, Pad, Key, NonKey, NA, Int, PInt,
 		BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment,
-		End, Marker, Ref, Const, Array, SArray, ABegin, AChunk, AData}
+		End, Marker, Ref, Cat, Const, Array, SArray, ABegin, AChunk, AData}
 )
 
 type RuleClass struct {
@@ -90,7 +91,7 @@ var ruleClasses = []RuleClass{
 	},
 	{
 		Name:    "TopLevelRule",
-		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment, Marker, Ref, Const, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment, Marker, Ref, Cat, Const, Array, SArray, ABegin},
 	},
 	{
 		Name:    "NACatRule",
@@ -98,27 +99,27 @@ var ruleClasses = []RuleClass{
 	},
 	{
 		Name:    "ListRule",
-		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment, End, Marker, Ref, Const, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment, End, Marker, Ref, Cat, Const, Array, SArray, ABegin},
 	},
 	{
 		Name:    "MapKeyRule",
-		Methods: []string{ECtr, Pad, Key, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, Meta, Comment, End, Marker, Ref, Const, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, Meta, Comment, End, Marker, Ref, Cat, Const, Array, SArray, ABegin},
 	},
 	{
 		Name:    "MapValueRule",
-		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment, Marker, Ref, Const, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment, Marker, Ref, Cat, Const, Array, SArray, ABegin},
 	},
 	{
 		Name:    "MarkupNameRule",
-		Methods: []string{ECtr, Pad, Key, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, Marker, Ref, Const, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, Marker, Ref, Cat, Const, Array, SArray, ABegin},
 	},
 	{
 		Name:    "MarkupKeyRule",
-		Methods: []string{ECtr, Pad, Key, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, Meta, Comment, End, Marker, Ref, Const, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, Meta, Comment, End, Marker, Ref, Cat, Const, Array, SArray, ABegin},
 	},
 	{
 		Name:    "MarkupValueRule",
-		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment, Marker, Ref, Const, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment, Marker, Ref, Cat, Const, Array, SArray, ABegin},
 	},
 	{
 		Name:    "MarkupContentsRule",
@@ -130,15 +131,15 @@ var ruleClasses = []RuleClass{
 	},
 	{
 		Name:    "MetaKeyRule",
-		Methods: []string{ECtr, Pad, Key, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, Meta, Comment, End, Marker, Ref, Const, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, Meta, Comment, End, Marker, Ref, Cat, Const, Array, SArray, ABegin},
 	},
 	{
 		Name:    "MetaValueRule",
-		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment, Marker, Ref, Const, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment, Marker, Ref, Cat, Const, Array, SArray, ABegin},
 	},
 	{
 		Name:    "MetaCompletionRule",
-		Methods: []string{Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment, Marker, Ref, Const, Array, SArray, ABegin},
+		Methods: []string{Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Meta, Comment, Marker, Ref, Cat, Const, Array, SArray, ABegin},
 	},
 	{
 		Name:    "ArrayRule",
@@ -174,11 +175,11 @@ var ruleClasses = []RuleClass{
 	},
 	{
 		Name:    "MarkedObjectKeyableRule",
-		Methods: []string{ECtr, Pad, Key, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, Ref, Const, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, Ref, Cat, Const, Array, SArray, ABegin},
 	},
 	{
 		Name:    "MarkedObjectAnyTypeRule",
-		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Ref, Const, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Ref, Cat, Const, Array, SArray, ABegin},
 	},
 	{
 		Name:    "ReferenceKeyableRule",
@@ -190,16 +191,24 @@ var ruleClasses = []RuleClass{
 	},
 	{
 		Name:    "ConstantKeyableRule",
-		Methods: []string{ECtr, Pad, Key, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, Ref, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, Ref, Cat, Array, SArray, ABegin},
 	},
 	{
 		Name:    "ConstantAnyTypeRule",
-		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Ref, Array, SArray, ABegin},
+		Methods: []string{ECtr, Pad, Key, NonKey, NA, Int, PInt, BInt, Float, BFloat, DFloat, BDFloat, List, Map, Markup, Ref, Cat, Array, SArray, ABegin},
 	},
 	{
 		Name:    "TLReferenceRIDRule",
 		Methods: []string{Pad, Array, SArray, ABegin, ECtr},
 	},
+	{
+		Name:    "ConcatRIDRule",
+		Methods: []string{Pad, Array, SArray, ABegin, ECtr},
+	},
+	{
+		Name:    "ConcatSuffixRule",
+		Methods: []string{Pad, Int, PInt, BInt, Array, SArray, ABegin, ECtr},
+	},
 }
 
 func GenerateCode(projectDir string) {
@@ -56,15 +56,21 @@ func BigDecimalFloatToBigInt(value *apd.Decimal, maxBase10Exponent int) (*big.In
 }
 
 func BigDecimalFloatToUint(value *apd.Decimal) (uint64, error) {
-	if i, err := value.Int64(); err == nil {
-		return uint64(i), nil
+	if value.Form != apd.Finite || value.Sign() < 0 {
+		return 0, fmt.Errorf("cannot convert %v to uint", value)
 	}
-
-	bf, err := BigDecimalFloatToBigFloat(value)
-	if err != nil {
-		return 0, err
+	integ, frac := new(apd.Decimal), new(apd.Decimal)
+	value.Modf(integ, frac)
+	if !frac.IsZero() {
+		return 0, fmt.Errorf("cannot convert %v to uint", value)
 	}
-	return BigFloatToUint(bf)
+	// Modf leaves integ with a non-negative exponent, and uint64 has at most
+	// 20 digits.
+	i, err := BigDecimalFloatToBigInt(integ, 20)
+	if err != nil || !i.IsUint64() {
+		return 0, fmt.Errorf("cannot convert %v to uint", value)
+	}
+	return i.Uint64(), nil
 }
 
 // big.Float to other
@@ -229,12 +235,7 @@ func UintToBigDecimalFloat(value uint64) apd.Decimal {
 }
 
 func UintToBigInt(value uint64) *big.Int {
-	if value <= 0x7fffffffffffffff {
-		return big.NewInt(int64(value))
-	}
-
-	bi := big.NewInt(int64(value >> 1))
-	return bi.Lsh(bi, 1)
+	return new(big.Int).SetUint64(value)
 }
 
 func UintToInt(value uint64) (int64, error) {
@@ -29,6 +29,8 @@ import (
 
 	"github.com/kstenerud/go-concise-encoding/options"
 	"github.com/kstenerud/go-concise-encoding/test"
+
+	"github.com/kstenerud/go-equivalence"
 )
 
 // TODO: Remove this when releasing V1
@@ -442,17 +444,18 @@ func TestCTETimestamp(t *testing.T) {
 }
 
 func TestCTEConstant(t *testing.T) {
-	// TODO: Update test support to allow passing rules opts
-	// assertDecodeEncode(t, nil, nil, "c0\n#someconst", BD(), V(ceVer), CONST("someconst", false), ED())
-	// assertDecodeEncode(t, nil, nil, `c0
-	// [
-	//    #c
-	//    1
-	// ]`, BD(), V(ceVer), L(), CONST("c", false), PI(1), E(), ED())
-	// assertDecodeEncode(t, nil, nil, `c0
-	// {
-	//    #c = 1
-	// }`, BD(), V(ceVer), M(), CONST("c", false), PI(1), E(), ED())
+	ruleOpts := options.DefaultRuleOptions()
+	ruleOpts.AllowUndefinedConstants = true
+	assertDecodeEncodeWithRules(t, ruleOpts, "c0\n#someconst", BD(), V(ceVer), CONST("someconst", false), ED())
+	assertDecodeEncodeWithRules(t, ruleOpts, `c0
+[
+    #c
+    1
+]`, BD(), V(ceVer), L(), CONST("c", false), PI(1), E(), ED())
+	assertDecodeEncodeWithRules(t, ruleOpts, `c0
+{
+    #c = 1
+}`, BD(), V(ceVer), M(), CONST("c", false), PI(1), E(), ED())
 
 	assertDecodeEncode(t, nil, nil, "c0\n#someconst:xyz", BD(), V(ceVer), CONST("someconst", true), S("xyz"), ED())
 	assertDecodeEncode(t, nil, nil, `c0
@@ -470,6 +473,40 @@ func TestCTEConstant(t *testing.T) {
 }`, BD(), V(ceVer), M(), CONST("c", true), PI(123), PI(1), E(), ED())
 }
 
+func TestCTEConstantSubstitution(t *testing.T) {
+	ruleOpts := options.DefaultRuleOptions()
+	ruleOpts.Constants = options.Constants{
+		"DEFAULT_TIMEOUT": options.CTELiteral("30"),
+		"HOSTS":           options.CTELiteral(`["a" "b"]`),
+		"RETRIES":         uint8(3),
+	}
+	document := []byte(`c0
+{
+    "timeout" = #DEFAULT_TIMEOUT
+    "hosts" = #HOSTS
+    "retries" = #RETRIES
+    "other" = #OTHER:1
+}`)
+	expected := []*test.TEvent{BD(), V(ceVer), M(),
+		S("timeout"), CONST("DEFAULT_TIMEOUT", true), PI(30),
+		S("hosts"), CONST("HOSTS", true), L(), S("a"), S("b"), E(),
+		S("retries"), CONST("RETRIES", true), PI(3),
+		S("other"), CONST("OTHER", true), PI(1),
+		E(), ED()}
+	events, err := decodeToEventsWithRules(nil, ruleOpts, document)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !equivalence.IsEquivalent(events, expected) {
+		t.Errorf("Expected events %v but got %v", expected, events)
+	}
+
+	if _, err := decodeToEventsWithRules(nil, ruleOpts, []byte("c0\n#UNKNOWN")); err == nil {
+		t.Errorf("Expected undefined constant to fail")
+	}
+}
+
 func TestCTEQuotedString(t *testing.T) {
 	assertDecodeEncode(t, nil, nil, `c0
 "test string"`, BD(), V(ceVer), S("test string"), ED())
@@ -542,8 +579,39 @@ func TestCTEVerbatimString(t *testing.T) {
 func TestCTERID(t *testing.T) {
 	assertDecodeEncode(t, nil, nil, `c0
 |r http://example.com|`, BD(), V(ceVer), RID("http://example.com"), ED())
+	// Resource IDs must be valid IRI references, so '|' must be
+	// percent-encoded. An escaped '\|' decodes to a raw '|', which is invalid.
+	assertDecodeEncode(t, nil, nil, `c0
+|r http://x.com/%7C|`, BD(), V(ceVer), RID(`http://x.com/%7C`), ED())
+	assertDecodeEncode(t, nil, nil, `c0
+|r http://[::1]:80/a?b#c|`, BD(), V(ceVer), RID(`http://[::1]:80/a?b#c`), ED())
+	assertDecodeEncode(t, nil, nil, `c0
+|r ../a/b|`, BD(), V(ceVer), RID(`../a/b`), ED())
+
+	assertDecodeFails(t, `c0 |r http://x.com/\||`)
+	assertDecodeFails(t, `c0 |r http://x.com/a b|`)
+	assertDecodeFails(t, `c0 |r http://x.com/%zz|`)
+	assertDecodeFails(t, `c0 |r 1http://x.com|`)
+}
+
+func TestCTEConcatenate(t *testing.T) {
+	assertDecodeEncode(t, nil, nil, `c0
+|r http://x.com/|:a`, BD(), V(ceVer), CAT(), RID("http://x.com/"), S("a"), ED())
 	assertDecodeEncode(t, nil, nil, `c0
-|r http://x.com/\||`, BD(), V(ceVer), RID(`http://x.com/|`), ED())
+|r http://x.com/|:1`, BD(), V(ceVer), CAT(), RID("http://x.com/"), PI(1), ED())
+	assertDecodeEncode(t, nil, nil, `c0
+[
+    |r http://x.com/|:"a b"
+]`, BD(), V(ceVer), L(), CAT(), RID("http://x.com/"), S("a b"), E(), ED())
+	assertDecodeEncode(t, nil, nil, `c0
+{
+    |r http://x.com/|:a = |r http://x.com/|:b
+}`, BD(), V(ceVer), M(), CAT(), RID("http://x.com/"), S("a"), CAT(), RID("http://x.com/"), S("b"), E(), ED())
+
+	assertDecodeFails(t, "c0 |r http://x.com/|:-1")
+	assertDecodeFails(t, "c0 |r http://x.com/|:1.5")
+	assertDecodeFails(t, "c0 |r http://x.com/|:")
+	assertDecodeFails(t, "c0 1:a")
 }
 
 func TestCTEArrayBoolean(t *testing.T) {
@@ -569,6 +637,11 @@ func TestCTEArrayBoolean(t *testing.T) {
 	assertDecode(t, nil, "c0\n|b  10  110 0 1 1   1    |", BD(), V(ceVer), AB(9, []byte{0b11001101, 0b1}), ED())
 }
 
+func TestCTEArrayBooleanBitOrder(t *testing.T) {
+	assertDecodeEncode(t, nil, nil, "c0\n|b 1101000001|", BD(), V(ceVer), AB(10, []byte{0x0b, 0x02}), ED())
+	assertDecodeEncode(t, nil, nil, "c0\n|b 00000000111|", BD(), V(ceVer), AB(11, []byte{0x00, 0x07}), ED())
+}
+
 func TestCTEArrayUintX(t *testing.T) {
 	assertDecodeEncode(t, nil, nil, "c0\n|u8x f1 93|", BD(), V(ceVer), AU8([]byte{0xf1, 0x93}), ED())
 	assertDecode(t, nil, "c0\n|u8x f 93 |", BD(), V(ceVer), AU8([]byte{0xf, 0x93}), ED())
@@ -1173,10 +1246,12 @@ func TestCTEMarkup(t *testing.T) {
 <a,
     \\
 >`, BD(), V(ceVer), MUP(), S("a"), E(), S("\\"), E(), ED())
+	// Markup contents can't contain control characters such as \210 (U+0010).
 	assertDecodeEncode(t, nil, nil, `c0
 <a,
-    \210
->`, BD(), V(ceVer), MUP(), S("a"), E(), S("\u0010"), E(), ED())
+    \42000
+>`, BD(), V(ceVer), MUP(), S("a"), E(), S("\u2000"), E(), ED())
+	assertDecodeFails(t, `c0 <a,\210>`)
 
 	assertDecodeEncode(t, nil, nil, `c0
 <a,
@@ -1868,3 +1943,184 @@ func TestEmptyDocument(t *testing.T) {
 	assertDecodeEncode(t, nil, nil, `c0
 @na`, BD(), V(ceVer), NA(), ED())
 }
+
+func TestCTESortMapKeys(t *testing.T) {
+	opts := options.DefaultCTEEncoderOptions()
+	opts.SortMapKeys = true
+
+	assertEncode(t, opts, `c0
+{
+    1 = 2
+    a = {
+        x = 1
+        y = 2
+    }
+    b = &1:3
+    /* c */
+    c = $1
+}`, BD(), V(ceVer), M(),
+		CMT(), S("c"), E(), S("c"), REF(), PI(1),
+		S("b"), MARK(), PI(1), PI(3),
+		S("a"), M(), S("y"), PI(2), S("x"), PI(1), E(),
+		PI(1), PI(2),
+		E(), ED())
+
+	assertEncode(t, opts, `c0
+[
+    {
+        a = 1
+        bb = 2
+    }
+]`, BD(), V(ceVer), L(), M(), SB(), AC(1, true), AD([]byte("b")), AC(1, false), AD([]byte("b")), PI(2), S("a"), PI(1), E(), E(), ED())
+
+	opts.MapKeyComparator = func(a, b interface{}) int {
+		return -common.CompareMapKeys(a, b)
+	}
+	assertEncode(t, opts, `c0
+{
+    b = 1
+    a = 2
+}`, BD(), V(ceVer), M(), S("a"), PI(2), S("b"), PI(1), E(), ED())
+}
+
+func TestCTEMaxDocumentByteCount(t *testing.T) {
+	document := "c0\n[1 2 3]"
+	opts := options.DefaultCTEDecoderOptions()
+	opts.MaxDocumentByteCount = uint64(len(document))
+	assertDecode(t, opts, document, BD(), V(ceVer), L(), PI(1), PI(2), PI(3), E(), ED())
+
+	opts.MaxDocumentByteCount = uint64(len(document) - 1)
+	if _, err := decodeToEvents(opts, []byte(document)); err == nil {
+		t.Errorf("Expected decode to fail")
+	}
+}
+
+func TestCTEMaxColumnList(t *testing.T) {
+	opts := options.DefaultCTEEncoderOptions()
+	opts.MaxColumn = 20
+
+	assertDecodeEncode(t, nil, opts, `c0
+[]`, BD(), V(ceVer), L(), E(), ED())
+
+	assertDecodeEncode(t, nil, opts, `c0
+[1 2 3]`, BD(), V(ceVer), L(), PI(1), PI(2), PI(3), E(), ED())
+
+	assertDecodeEncode(t, nil, opts, `c0
+[
+    10000
+    20000
+    30000
+    40000
+]`, BD(), V(ceVer), L(), PI(10000), PI(20000), PI(30000), PI(40000), E(), ED())
+
+	assertDecodeEncode(t, nil, opts, `c0
+[
+    [1 2]
+    [3 [4 5]]
+    [10000 20000]
+]`, BD(), V(ceVer), L(), L(), PI(1), PI(2), E(), L(), PI(3), L(), PI(4), PI(5), E(), E(),
+		L(), PI(10000), PI(20000), E(), E(), ED())
+
+	assertDecodeEncode(t, nil, opts, `c0
+[
+    [
+        100000
+        200000
+        300000
+    ]
+]`, BD(), V(ceVer), L(), L(), PI(100000), PI(200000), PI(300000), E(), E(), ED())
+}
+
+func TestCTEMaxColumnMap(t *testing.T) {
+	opts := options.DefaultCTEEncoderOptions()
+	opts.MaxColumn = 20
+
+	assertDecodeEncode(t, nil, opts, `c0
+{a = 1 b = 2}`, BD(), V(ceVer), M(), S("a"), PI(1), S("b"), PI(2), E(), ED())
+
+	assertDecodeEncode(t, nil, opts, `c0
+{
+    a = [1 2]
+    b = {x = y}
+    c = [
+        aaaaaa
+        bbbbbb
+    ]
+}`, BD(), V(ceVer), M(), S("a"), L(), PI(1), PI(2), E(), S("b"), M(), S("x"), S("y"), E(),
+		S("c"), L(), S("aaaaaa"), S("bbbbbb"), E(), E(), ED())
+
+	assertDecodeEncode(t, nil, opts, `c0
+(a = 1)[1 2]`, BD(), V(ceVer), META(), S("a"), PI(1), E(), L(), PI(1), PI(2), E(), ED())
+}
+
+func TestCTEMaxColumnMarkup(t *testing.T) {
+	opts := options.DefaultCTEEncoderOptions()
+	opts.MaxColumn = 40
+
+	assertDecodeEncode(t, nil, opts, `c0
+[
+    <a x=1,
+        aaa
+    >
+]`, BD(), V(ceVer), L(), MUP(), S("a"), S("x"), PI(1), E(), S("aaa"), E(), E(), ED())
+
+	assertDecodeEncode(t, nil, opts, `c0
+[/* a comment */ 1]`, BD(), V(ceVer), L(), CMT(), S("a comment"), E(), PI(1), E(), ED())
+}
+
+func TestCTEMaxColumnArray(t *testing.T) {
+	opts := options.DefaultCTEEncoderOptions()
+	opts.MaxColumn = 20
+
+	assertDecodeEncode(t, nil, opts, `c0
+|u8x 01 02 03 04 05
+    06 07 08 09 0a
+    0b 0c|`, BD(), V(ceVer), AU8([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}), ED())
+
+	assertDecodeEncode(t, nil, opts, `c0
+[
+    |u8x 01 02 03 04
+        05 06 07 08
+        09 0a|
+    1
+]`, BD(), V(ceVer), L(), AU8([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}), PI(1), E(), ED())
+
+	assertDecodeEncode(t, nil, opts, `c0
+|i32 1000000 2000000
+    3000000|`, BD(), V(ceVer), AI32([]int32{1000000, 2000000, 3000000}), ED())
+
+	assertDecodeEncode(t, nil, opts, `c0
+|b 0101010101010101010101|`, BD(), V(ceVer), AB(22, []byte{0xaa, 0xaa, 0x2a}), ED())
+}
+
+func TestCTEMaxColumnString(t *testing.T) {
+	opts := options.DefaultCTEEncoderOptions()
+	opts.MaxColumn = 20
+
+	assertDecodeEncode(t, nil, opts, `c0
+"short string"`, BD(), V(ceVer), S("short string"), ED())
+
+	assertEncode(t, opts, `c0
+"the quick brown \
+    fox jumps over \
+    the lazy dog"`, BD(), V(ceVer), S("the quick brown fox jumps over the lazy dog"), ED())
+	assertDecode(t, nil, `c0
+"the quick brown \
+    fox jumps over \
+    the lazy dog"`, BD(), V(ceVer), S("the quick brown fox jumps over the lazy dog"), ED())
+
+	assertEncode(t, opts, `c0
+{
+    a = "aaaa bbbb \
+        cccc dddd"
+}`, BD(), V(ceVer), M(), S("a"), S("aaaa bbbb cccc dddd"), E(), ED())
+
+	// A continuation skips the whitespace that follows it, so runs of spaces
+	// are only split before their last space.
+	assertEncode(t, opts, `c0
+"aaaa bbbb cccc  \
+    dddd"`, BD(), V(ceVer), S("aaaa bbbb cccc  dddd"), ED())
+	assertDecode(t, nil, `c0
+"aaaa bbbb cccc  \
+    dddd"`, BD(), V(ceVer), S("aaaa bbbb cccc  dddd"), ED())
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/kstenerud/go-concise-encoding/debug"
 
 	"github.com/kstenerud/go-concise-encoding/internal/chars"
+	"github.com/kstenerud/go-concise-encoding/internal/common"
 
 	"github.com/kstenerud/go-concise-encoding/options"
 
@@ -70,7 +71,7 @@ func (_this *Decoder) Decode(reader io.Reader, eventReceiver events.DataEventRec
 	}()
 
 	ctx := DecoderContext{}
-	ctx.Init(&_this.opts, reader, eventReceiver)
+	ctx.Init(&_this.opts, common.NewLimitedReader(reader, _this.opts.MaxDocumentByteCount), eventReceiver)
 	ctx.StackDecoder(decodeDocumentBegin)
 
 	for !ctx.IsDocumentComplete {
@@ -115,7 +116,6 @@ func init() {
 	decoderFuncsByFirstChar['$'] = advanceAndDecodeReference
 	decoderFuncsByFirstChar['&'] = advanceAndDecodeMarker
 	decoderFuncsByFirstChar['/'] = advanceAndDecodeComment
-	decoderFuncsByFirstChar[':'] = advanceAndDecodeSuffix
 	decoderFuncsByFirstChar['{'] = advanceAndDecodeMapBegin
 	decoderFuncsByFirstChar['}'] = advanceAndDecodeMapEnd
 	decoderFuncsByFirstChar['['] = advanceAndDecodeListBegin
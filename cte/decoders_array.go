@@ -74,7 +74,7 @@ func decodeTypedArrayBegin(ctx *DecoderContext) {
 	case "ct":
 		decodeCustomText(ctx)
 	case "r":
-		decodeRID(ctx)
+		decodeRIDOrConcatenation(ctx)
 	case "u":
 		decodeArrayUUID(ctx)
 	case "b":
@@ -173,6 +173,21 @@ func decodeRID(ctx *DecoderContext) {
 	decodeStringArray(ctx, events.ArrayTypeResourceID)
 }
 
+// A resource ID followed directly by ':' is concatenated with the suffix
+// following the colon.
+func decodeRIDOrConcatenation(ctx *DecoderContext) {
+	bytes := ctx.Stream.DecodeStringArray()
+	if ctx.Stream.PeekByteAllowEOD() != ':' {
+		ctx.EventReceiver.OnArray(events.ArrayTypeResourceID, uint64(len(bytes)), bytes)
+		return
+	}
+
+	ctx.EventReceiver.OnConcatenate()
+	ctx.EventReceiver.OnArray(events.ArrayTypeResourceID, uint64(len(bytes)), bytes)
+	ctx.Stream.AdvanceByte()
+	decodeByFirstChar(ctx)
+}
+
 func decodeCustomBinary(ctx *DecoderContext) {
 	digitType := "hex"
 	var data []uint8
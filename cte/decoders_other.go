@@ -395,9 +395,3 @@ func advanceAndDecodeReference(ctx *DecoderContext) {
 		ctx.EventReceiver.OnPositiveInt(asUint)
 	}
 }
-
-func advanceAndDecodeSuffix(ctx *DecoderContext) {
-	ctx.Stream.AdvanceByte() // Advance past ':'
-
-	panic("TODO: decodeSuffix")
-}
@@ -75,6 +75,14 @@ type Encoder interface {
 
 type EncoderEventReceiver struct {
 	context EncoderContext
+	encoder contextEventReceiver
+	// Only set when sorting map keys. Otherwise events go straight to encoder.
+	sorter *events.MapSortingEventReceiver
+}
+
+// Receives events and passes them to the current encoder.
+type contextEventReceiver struct {
+	context *EncoderContext
 }
 
 // Create a new encoder.
@@ -90,188 +98,492 @@ func NewEncoder(opts *options.CTEEncoderOptions) *EncoderEventReceiver {
 func (_this *EncoderEventReceiver) Init(opts *options.CTEEncoderOptions) {
 	opts = opts.WithDefaultsApplied()
 	_this.context.Init(opts)
+	_this.encoder.context = &_this.context
+	_this.sorter = nil
+	if opts.SortMapKeys {
+		compare := opts.MapKeyComparator
+		if compare == nil {
+			compare = common.CompareMapKeys
+		}
+		_this.sorter = events.NewMapSortingEventReceiver(&_this.encoder, compare)
+	}
 }
 
 // Reset the encoder back to its initial state.
 func (_this *EncoderEventReceiver) Reset() {
 	_this.context.Reset()
+	if _this.sorter != nil {
+		_this.sorter.Reset()
+	}
 }
 
 // Prepare the encoder for encoding. All events will be encoded to writer.
 // PrepareToEncode MUST be called before using the encoder.
 func (_this *EncoderEventReceiver) PrepareToEncode(writer io.Writer) {
-	_this.context.Stream.SetWriter(writer)
+	_this.context.SetWriter(writer)
 }
 
 func (_this *EncoderEventReceiver) OnBeginDocument() {
-	_this.context.Reset()
+	if _this.sorter != nil {
+		_this.sorter.OnBeginDocument()
+		return
+	}
+	_this.encoder.OnBeginDocument()
 }
 
 func (_this *EncoderEventReceiver) OnVersion(version uint64) {
-	_this.context.Stream.WriteVersion(version)
+	if _this.sorter != nil {
+		_this.sorter.OnVersion(version)
+		return
+	}
+	_this.encoder.OnVersion(version)
 }
 
 func (_this *EncoderEventReceiver) OnPadding(count int) {
-	// Nothing to do
+	if _this.sorter != nil {
+		_this.sorter.OnPadding(count)
+		return
+	}
+	_this.encoder.OnPadding(count)
 }
 
 func (_this *EncoderEventReceiver) OnNA() {
-	_this.context.CurrentEncoder.EncodeNA(&_this.context)
+	if _this.sorter != nil {
+		_this.sorter.OnNA()
+		return
+	}
+	_this.encoder.OnNA()
 }
 
 func (_this *EncoderEventReceiver) OnBool(value bool) {
-	_this.context.CurrentEncoder.EncodeBool(&_this.context, value)
+	if _this.sorter != nil {
+		_this.sorter.OnBool(value)
+		return
+	}
+	_this.encoder.OnBool(value)
 }
 
 func (_this *EncoderEventReceiver) OnTrue() {
-	_this.context.CurrentEncoder.EncodeTrue(&_this.context)
+	if _this.sorter != nil {
+		_this.sorter.OnTrue()
+		return
+	}
+	_this.encoder.OnTrue()
 }
 
 func (_this *EncoderEventReceiver) OnFalse() {
-	_this.context.CurrentEncoder.EncodeFalse(&_this.context)
+	if _this.sorter != nil {
+		_this.sorter.OnFalse()
+		return
+	}
+	_this.encoder.OnFalse()
 }
 
 func (_this *EncoderEventReceiver) OnPositiveInt(value uint64) {
-	_this.context.CurrentEncoder.EncodePositiveInt(&_this.context, value)
+	if _this.sorter != nil {
+		_this.sorter.OnPositiveInt(value)
+		return
+	}
+	_this.encoder.OnPositiveInt(value)
 }
 
 func (_this *EncoderEventReceiver) OnNegativeInt(value uint64) {
-	_this.context.CurrentEncoder.EncodeNegativeInt(&_this.context, value)
+	if _this.sorter != nil {
+		_this.sorter.OnNegativeInt(value)
+		return
+	}
+	_this.encoder.OnNegativeInt(value)
 }
 
 func (_this *EncoderEventReceiver) OnInt(value int64) {
-	_this.context.CurrentEncoder.EncodeInt(&_this.context, value)
+	if _this.sorter != nil {
+		_this.sorter.OnInt(value)
+		return
+	}
+	_this.encoder.OnInt(value)
 }
 
 func (_this *EncoderEventReceiver) OnBigInt(value *big.Int) {
-	if value == nil {
-		_this.OnNA()
+	if _this.sorter != nil {
+		_this.sorter.OnBigInt(value)
 		return
 	}
-
-	_this.context.CurrentEncoder.EncodeBigInt(&_this.context, value)
+	_this.encoder.OnBigInt(value)
 }
 
 func (_this *EncoderEventReceiver) OnFloat(value float64) {
-	if math.IsNaN(value) {
-		_this.OnNan(common.IsSignalingNan(value))
+	if _this.sorter != nil {
+		_this.sorter.OnFloat(value)
 		return
 	}
-
-	_this.context.CurrentEncoder.EncodeFloat(&_this.context, value)
+	_this.encoder.OnFloat(value)
 }
 
 func (_this *EncoderEventReceiver) OnBigFloat(value *big.Float) {
-	if value == nil {
-		_this.OnNA()
+	if _this.sorter != nil {
+		_this.sorter.OnBigFloat(value)
 		return
 	}
-
-	_this.context.CurrentEncoder.EncodeBigFloat(&_this.context, value)
+	_this.encoder.OnBigFloat(value)
 }
 
 func (_this *EncoderEventReceiver) OnDecimalFloat(value compact_float.DFloat) {
-	if value.IsNan() {
-		_this.OnNan(value.IsSignalingNan())
+	if _this.sorter != nil {
+		_this.sorter.OnDecimalFloat(value)
 		return
 	}
-
-	_this.context.CurrentEncoder.EncodeDecimalFloat(&_this.context, value)
+	_this.encoder.OnDecimalFloat(value)
 }
 
 func (_this *EncoderEventReceiver) OnBigDecimalFloat(value *apd.Decimal) {
-	if value == nil {
-		_this.OnNA()
-		return
-	}
-	if value.Form == apd.NaNSignaling {
-		_this.OnNan(true)
-		return
-	}
-	if value.Form == apd.NaN {
-		_this.OnNan(false)
+	if _this.sorter != nil {
+		_this.sorter.OnBigDecimalFloat(value)
 		return
 	}
-
-	_this.context.CurrentEncoder.EncodeBigDecimalFloat(&_this.context, value)
+	_this.encoder.OnBigDecimalFloat(value)
 }
 
 func (_this *EncoderEventReceiver) OnNan(signaling bool) {
-	_this.context.CurrentEncoder.EncodeNan(&_this.context, signaling)
+	if _this.sorter != nil {
+		_this.sorter.OnNan(signaling)
+		return
+	}
+	_this.encoder.OnNan(signaling)
 }
 
 func (_this *EncoderEventReceiver) OnUUID(value []byte) {
-	_this.context.CurrentEncoder.EncodeUUID(&_this.context, value)
+	if _this.sorter != nil {
+		_this.sorter.OnUUID(value)
+		return
+	}
+	_this.encoder.OnUUID(value)
 }
 
 func (_this *EncoderEventReceiver) OnTime(value time.Time) {
-	_this.context.CurrentEncoder.EncodeTime(&_this.context, value)
+	if _this.sorter != nil {
+		_this.sorter.OnTime(value)
+		return
+	}
+	_this.encoder.OnTime(value)
 }
 
 func (_this *EncoderEventReceiver) OnCompactTime(value compact_time.Time) {
-	_this.context.CurrentEncoder.EncodeCompactTime(&_this.context, value)
+	if _this.sorter != nil {
+		_this.sorter.OnCompactTime(value)
+		return
+	}
+	_this.encoder.OnCompactTime(value)
 }
 
 func (_this *EncoderEventReceiver) OnArray(arrayType events.ArrayType, elementCount uint64, value []byte) {
-	_this.context.CurrentEncoder.EncodeArray(&_this.context, arrayType, elementCount, value)
+	if _this.sorter != nil {
+		_this.sorter.OnArray(arrayType, elementCount, value)
+		return
+	}
+	_this.encoder.OnArray(arrayType, elementCount, value)
 }
 
 func (_this *EncoderEventReceiver) OnStringlikeArray(arrayType events.ArrayType, value string) {
-	_this.context.CurrentEncoder.EncodeStringlikeArray(&_this.context, arrayType, value)
+	if _this.sorter != nil {
+		_this.sorter.OnStringlikeArray(arrayType, value)
+		return
+	}
+	_this.encoder.OnStringlikeArray(arrayType, value)
 }
 
 func (_this *EncoderEventReceiver) OnArrayBegin(arrayType events.ArrayType) {
-	_this.context.CurrentEncoder.BeginArray(&_this.context, arrayType)
+	if _this.sorter != nil {
+		_this.sorter.OnArrayBegin(arrayType)
+		return
+	}
+	_this.encoder.OnArrayBegin(arrayType)
 }
 
 func (_this *EncoderEventReceiver) OnArrayChunk(elementCount uint64, moreChunksFollow bool) {
-	_this.context.CurrentEncoder.BeginArrayChunk(&_this.context, elementCount, moreChunksFollow)
+	if _this.sorter != nil {
+		_this.sorter.OnArrayChunk(elementCount, moreChunksFollow)
+		return
+	}
+	_this.encoder.OnArrayChunk(elementCount, moreChunksFollow)
 }
 
 func (_this *EncoderEventReceiver) OnArrayData(data []byte) {
-	_this.context.CurrentEncoder.EncodeArrayData(&_this.context, data)
+	if _this.sorter != nil {
+		_this.sorter.OnArrayData(data)
+		return
+	}
+	_this.encoder.OnArrayData(data)
 }
 
 func (_this *EncoderEventReceiver) OnConcatenate() {
-	_this.context.BeginStandardConcatenate()
+	if _this.sorter != nil {
+		_this.sorter.OnConcatenate()
+		return
+	}
+	_this.encoder.OnConcatenate()
 }
 
 func (_this *EncoderEventReceiver) OnList() {
-	_this.context.CurrentEncoder.BeginList(&_this.context)
+	if _this.sorter != nil {
+		_this.sorter.OnList()
+		return
+	}
+	_this.encoder.OnList()
 }
 
 func (_this *EncoderEventReceiver) OnMap() {
-	_this.context.CurrentEncoder.BeginMap(&_this.context)
+	if _this.sorter != nil {
+		_this.sorter.OnMap()
+		return
+	}
+	_this.encoder.OnMap()
 }
 
 func (_this *EncoderEventReceiver) OnMarkup() {
-	_this.context.CurrentEncoder.BeginMarkup(&_this.context)
+	if _this.sorter != nil {
+		_this.sorter.OnMarkup()
+		return
+	}
+	_this.encoder.OnMarkup()
 }
 
 func (_this *EncoderEventReceiver) OnMetadata() {
-	_this.context.CurrentEncoder.BeginMetadata(&_this.context)
+	if _this.sorter != nil {
+		_this.sorter.OnMetadata()
+		return
+	}
+	_this.encoder.OnMetadata()
 }
 
 func (_this *EncoderEventReceiver) OnComment() {
-	_this.context.CurrentEncoder.BeginComment(&_this.context)
+	if _this.sorter != nil {
+		_this.sorter.OnComment()
+		return
+	}
+	_this.encoder.OnComment()
 }
 
 func (_this *EncoderEventReceiver) OnEnd() {
-	_this.context.CurrentEncoder.End(&_this.context)
+	if _this.sorter != nil {
+		_this.sorter.OnEnd()
+		return
+	}
+	_this.encoder.OnEnd()
 }
 
 func (_this *EncoderEventReceiver) OnMarker() {
-	_this.context.CurrentEncoder.BeginMarker(&_this.context)
+	if _this.sorter != nil {
+		_this.sorter.OnMarker()
+		return
+	}
+	_this.encoder.OnMarker()
 }
 
 func (_this *EncoderEventReceiver) OnReference() {
-	_this.context.CurrentEncoder.BeginReference(&_this.context)
+	if _this.sorter != nil {
+		_this.sorter.OnReference()
+		return
+	}
+	_this.encoder.OnReference()
 }
 
 func (_this *EncoderEventReceiver) OnConstant(name []byte, explicitValue bool) {
-	_this.context.CurrentEncoder.BeginConstant(&_this.context, name, explicitValue)
+	if _this.sorter != nil {
+		_this.sorter.OnConstant(name, explicitValue)
+		return
+	}
+	_this.encoder.OnConstant(name, explicitValue)
 }
 
 func (_this *EncoderEventReceiver) OnEndDocument() {
-	_this.context.Stream.Flush()
+	if _this.sorter != nil {
+		_this.sorter.OnEndDocument()
+		return
+	}
+	_this.encoder.OnEndDocument()
+}
+
+// ============================================================================
+
+func (_this *contextEventReceiver) OnBeginDocument() {
+	_this.context.Reset()
+}
+
+func (_this *contextEventReceiver) OnVersion(version uint64) {
+	_this.context.Stream.WriteVersion(version)
+}
+
+func (_this *contextEventReceiver) OnPadding(count int) {
+	// Nothing to do
+}
+
+func (_this *contextEventReceiver) OnNA() {
+	_this.context.CurrentEncoder.EncodeNA(_this.context)
+}
+
+func (_this *contextEventReceiver) OnBool(value bool) {
+	_this.context.CurrentEncoder.EncodeBool(_this.context, value)
+}
+
+func (_this *contextEventReceiver) OnTrue() {
+	_this.context.CurrentEncoder.EncodeTrue(_this.context)
+}
+
+func (_this *contextEventReceiver) OnFalse() {
+	_this.context.CurrentEncoder.EncodeFalse(_this.context)
+}
+
+func (_this *contextEventReceiver) OnPositiveInt(value uint64) {
+	_this.context.CurrentEncoder.EncodePositiveInt(_this.context, value)
+}
+
+func (_this *contextEventReceiver) OnNegativeInt(value uint64) {
+	_this.context.CurrentEncoder.EncodeNegativeInt(_this.context, value)
+}
+
+func (_this *contextEventReceiver) OnInt(value int64) {
+	_this.context.CurrentEncoder.EncodeInt(_this.context, value)
+}
+
+func (_this *contextEventReceiver) OnBigInt(value *big.Int) {
+	if value == nil {
+		_this.OnNA()
+		return
+	}
+
+	_this.context.CurrentEncoder.EncodeBigInt(_this.context, value)
+}
+
+func (_this *contextEventReceiver) OnFloat(value float64) {
+	if math.IsNaN(value) {
+		_this.OnNan(common.IsSignalingNan(value))
+		return
+	}
+
+	_this.context.CurrentEncoder.EncodeFloat(_this.context, value)
+}
+
+func (_this *contextEventReceiver) OnBigFloat(value *big.Float) {
+	if value == nil {
+		_this.OnNA()
+		return
+	}
+
+	_this.context.CurrentEncoder.EncodeBigFloat(_this.context, value)
+}
+
+func (_this *contextEventReceiver) OnDecimalFloat(value compact_float.DFloat) {
+	if value.IsNan() {
+		_this.OnNan(value.IsSignalingNan())
+		return
+	}
+
+	_this.context.CurrentEncoder.EncodeDecimalFloat(_this.context, value)
+}
+
+func (_this *contextEventReceiver) OnBigDecimalFloat(value *apd.Decimal) {
+	if value == nil {
+		_this.OnNA()
+		return
+	}
+	if value.Form == apd.NaNSignaling {
+		_this.OnNan(true)
+		return
+	}
+	if value.Form == apd.NaN {
+		_this.OnNan(false)
+		return
+	}
+
+	_this.context.CurrentEncoder.EncodeBigDecimalFloat(_this.context, value)
+}
+
+func (_this *contextEventReceiver) OnNan(signaling bool) {
+	_this.context.CurrentEncoder.EncodeNan(_this.context, signaling)
+}
+
+func (_this *contextEventReceiver) OnUUID(value []byte) {
+	_this.context.CurrentEncoder.EncodeUUID(_this.context, value)
+}
+
+func (_this *contextEventReceiver) OnTime(value time.Time) {
+	_this.context.CurrentEncoder.EncodeTime(_this.context, value)
+}
+
+func (_this *contextEventReceiver) OnCompactTime(value compact_time.Time) {
+	_this.context.CurrentEncoder.EncodeCompactTime(_this.context, value)
+}
+
+func (_this *contextEventReceiver) OnArray(arrayType events.ArrayType, elementCount uint64, value []byte) {
+	_this.context.CurrentEncoder.EncodeArray(_this.context, _this.beginConcatIfRIDCat(arrayType), elementCount, value)
+}
+
+func (_this *contextEventReceiver) OnStringlikeArray(arrayType events.ArrayType, value string) {
+	_this.context.CurrentEncoder.EncodeStringlikeArray(_this.context, _this.beginConcatIfRIDCat(arrayType), value)
+}
+
+func (_this *contextEventReceiver) OnArrayBegin(arrayType events.ArrayType) {
+	_this.context.CurrentEncoder.BeginArray(_this.context, _this.beginConcatIfRIDCat(arrayType))
+}
+
+// A concatenated resource ID array is encoded as a concatenation followed by
+// a regular resource ID.
+func (_this *contextEventReceiver) beginConcatIfRIDCat(arrayType events.ArrayType) events.ArrayType {
+	if arrayType == events.ArrayTypeResourceIDConcat {
+		_this.OnConcatenate()
+		return events.ArrayTypeResourceID
+	}
+	return arrayType
+}
+
+func (_this *contextEventReceiver) OnArrayChunk(elementCount uint64, moreChunksFollow bool) {
+	_this.context.CurrentEncoder.BeginArrayChunk(_this.context, elementCount, moreChunksFollow)
+}
+
+func (_this *contextEventReceiver) OnArrayData(data []byte) {
+	_this.context.CurrentEncoder.EncodeArrayData(_this.context, data)
+}
+
+func (_this *contextEventReceiver) OnConcatenate() {
+	_this.context.CurrentEncoder.BeginConcatenate(_this.context)
+}
+
+func (_this *contextEventReceiver) OnList() {
+	_this.context.CurrentEncoder.BeginList(_this.context)
+}
+
+func (_this *contextEventReceiver) OnMap() {
+	_this.context.CurrentEncoder.BeginMap(_this.context)
+}
+
+func (_this *contextEventReceiver) OnMarkup() {
+	_this.context.CurrentEncoder.BeginMarkup(_this.context)
+}
+
+func (_this *contextEventReceiver) OnMetadata() {
+	_this.context.CurrentEncoder.BeginMetadata(_this.context)
+}
+
+func (_this *contextEventReceiver) OnComment() {
+	_this.context.CurrentEncoder.BeginComment(_this.context)
+}
+
+func (_this *contextEventReceiver) OnEnd() {
+	_this.context.CurrentEncoder.End(_this.context)
+}
+
+func (_this *contextEventReceiver) OnMarker() {
+	_this.context.CurrentEncoder.BeginMarker(_this.context)
+}
+
+func (_this *contextEventReceiver) OnReference() {
+	_this.context.CurrentEncoder.BeginReference(_this.context)
+}
+
+func (_this *contextEventReceiver) OnConstant(name []byte, explicitValue bool) {
+	_this.context.CurrentEncoder.BeginConstant(_this.context, name, explicitValue)
+}
+
+func (_this *contextEventReceiver) OnEndDocument() {
+	_this.context.Flush()
 }
@@ -21,7 +21,8 @@
 package cte
 
 import (
-	"fmt"
+	"io"
+	"strings"
 
 	"github.com/kstenerud/go-concise-encoding/events"
 	"github.com/kstenerud/go-concise-encoding/options"
@@ -57,17 +58,20 @@ type EncoderContext struct {
 	currentPrefix       string
 	Stream              EncodeBuffer
 	ArrayEngine         arrayEncoderEngine
+	lineWrapper         lineWrapper
 }
 
 func (_this *EncoderContext) Init(opts *options.CTEEncoderOptions) {
 	_this.opts = *opts
-	_this.ArrayEngine.Init(&_this.Stream, &_this.opts)
+	_this.lineWrapper.Init(_this.opts.MaxColumn, &_this.indenter)
+	_this.ArrayEngine.Init(&_this.Stream, &_this.lineWrapper, &_this.opts)
 	_this.Reset()
 }
 
 func (_this *EncoderContext) Reset() {
 	_this.indenter.Reset()
 	_this.Stream.Reset()
+	_this.lineWrapper.Reset()
 	_this.encoderStack = _this.encoderStack[:0]
 	_this.CurrentEncoder = nil
 	_this.Stack(&globalTopLevelEncoder)
@@ -89,15 +93,56 @@ func (_this *EncoderContext) ChangeEncoder(encoder Encoder) {
 	_this.CurrentEncoder = encoder
 }
 
+func (_this *EncoderContext) SetWriter(writer io.Writer) {
+	if _this.lineWrapper.IsEnabled() {
+		_this.lineWrapper.SetWriter(writer)
+		_this.Stream.SetWriter(&_this.lineWrapper)
+		return
+	}
+	_this.Stream.SetWriter(writer)
+}
+
+func (_this *EncoderContext) Flush() {
+	_this.Stream.Flush()
+	if _this.lineWrapper.IsEnabled() {
+		_this.lineWrapper.Flush()
+	}
+}
+
+// Increase the indent for a container whose objects may be written on a
+// single line if they fit.
 func (_this *EncoderContext) IncreaseIndent() {
+	_this.increaseIndent(true)
+}
+
+// Increase the indent for a container whose objects must always be written
+// on separate lines.
+func (_this *EncoderContext) IncreaseFixedIndent() {
+	_this.increaseIndent(false)
+}
+
+func (_this *EncoderContext) increaseIndent(canCompact bool) {
 	_this.indenter.increase()
+	if _this.lineWrapper.IsEnabled() {
+		_this.Stream.Flush()
+		_this.lineWrapper.BeginGroup(canCompact)
+	}
 }
 
 func (_this *EncoderContext) DecreaseIndent() {
 	_this.indenter.decrease()
+	if _this.lineWrapper.IsEnabled() {
+		_this.Stream.Flush()
+		_this.lineWrapper.EndGroup()
+	}
 }
 
 func (_this *EncoderContext) WriteBasicIndent() {
+	if _this.lineWrapper.IsEnabled() {
+		_this.Stream.Flush()
+		_this.lineWrapper.AddClosingBreak(string(_this.indenter.Get()))
+		return
+	}
 	_this.Stream.AddBytes(_this.indenter.Get())
 }
 
@@ -130,6 +175,11 @@ func (_this *EncoderContext) ClearPrefix() {
 }
 
 func (_this *EncoderContext) WriteCurrentPrefix() {
+	if _this.lineWrapper.IsEnabled() && strings.HasPrefix(_this.currentPrefix, "\n") {
+		_this.Stream.Flush()
+		_this.lineWrapper.AddObjectBreak(_this.currentPrefix)
+		return
+	}
 	_this.Stream.AddString(_this.currentPrefix)
 	// TODO: Need to do this?
 	// _this.ClearMapPrefix()
@@ -179,21 +229,20 @@ func (_this *EncoderContext) BeginStandardReference() {
 }
 
 func (_this *EncoderContext) BeginStandardConcatenate() {
-	panic(fmt.Errorf("TODO: EncoderContext.BeginConcatenate"))
+	_this.Stack(&globalConcatEncoder)
 }
 
 func (_this *EncoderContext) BeginStandardConstant(name []byte, explicitValue bool) {
 	_this.Stream.AddByte('#')
 	_this.Stream.AddBytes(name)
+	if !explicitValue {
+		// An undefined constant is a complete object by itself.
+		_this.CurrentEncoder.ChildContainerFinished(_this, true)
+		return
+	}
 	_this.Stack(&globalConstantEncoder)
 }
 
-func (_this *EncoderContext) BeginConcatenatedNA() {
-	panic("TODO: EncoderContext.BeginConcatenatedNA")
-	// _this.Stack(&globalNACatEncoder)
-	// _this.CurrentEncoder.Begin(_this)
-}
-
 func (_this *EncoderContext) BeginStandardArray(arrayType events.ArrayType) {
 	_this.Stack((&globalArrayEncoder))
 	_this.ArrayEngine.BeginArray(arrayType, func() {
@@ -45,6 +45,7 @@ func (_this *arrayEncoder) EncodeArrayData(ctx *EncoderContext, data []byte) {
 
 type arrayEncoderEngine struct {
 	stream                 *EncodeBuffer
+	lineWrapper            *lineWrapper
 	addElementsFunc        func(b []byte)
 	onComplete             func()
 	arrayElementBitWidth   int
@@ -58,8 +59,9 @@ type arrayEncoderEngine struct {
 	opts                   *options.CTEEncoderOptions
 }
 
-func (_this *arrayEncoderEngine) Init(stream *EncodeBuffer, opts *options.CTEEncoderOptions) {
+func (_this *arrayEncoderEngine) Init(stream *EncodeBuffer, lineWrapper *lineWrapper, opts *options.CTEEncoderOptions) {
 	_this.stream = stream
+	_this.lineWrapper = lineWrapper
 	_this.arrayChunkLeftover = _this.arrayChunkBacking[:]
 	_this.opts = opts
 }
@@ -101,7 +103,7 @@ func (_this *arrayEncoderEngine) EncodeStringlikeArray(arrayType events.ArrayTyp
 func (_this *arrayEncoderEngine) EncodeArray(arrayType events.ArrayType, elementCount uint64, data []uint8) {
 	switch arrayType {
 	case events.ArrayTypeString:
-		_this.stream.WritePotentiallyQuotedStringBytes(data)
+		_this.writeString(data)
 	case events.ArrayTypeResourceID:
 		_this.stream.AddString("|r ")
 		_this.stream.WritePotentiallyEscapedStringArrayContents(data)
@@ -133,6 +135,41 @@ func (_this *arrayEncoderEngine) BeginArray(arrayType events.ArrayType, onComple
 
 	beginOp := arrayEncodeBeginOps[arrayType]
 	beginOp(_this, onComplete)
+
+	if isFilledArrayType(arrayType) && _this.lineWrapper.IsEnabled() {
+		_this.beginFill(fillModeArray)
+		_this.onComplete = func() {
+			_this.endFill()
+			_this.stream.WriteArrayEnd()
+			onComplete()
+		}
+	}
+}
+
+// Numeric elements can be wrapped onto new lines at the spaces between them.
+func isFilledArrayType(arrayType events.ArrayType) bool {
+	return arrayType >= events.ArrayTypeCustomBinary && arrayType != events.ArrayTypeBoolean
+}
+
+func (_this *arrayEncoderEngine) beginFill(mode fillMode) {
+	if _this.lineWrapper.IsEnabled() {
+		_this.stream.Flush()
+		_this.lineWrapper.BeginFill(mode)
+	}
+}
+
+func (_this *arrayEncoderEngine) endFill() {
+	if _this.lineWrapper.IsEnabled() {
+		_this.stream.Flush()
+		_this.lineWrapper.EndFill()
+	}
+}
+
+// Long quoted strings can be split at their spaces using continuations.
+func (_this *arrayEncoderEngine) writeString(data []byte) {
+	_this.beginFill(fillModeString)
+	_this.stream.WritePotentiallyQuotedStringBytes(data)
+	_this.endFill()
 }
 
 func (_this *arrayEncoderEngine) handleFirstElement(data []byte) {
@@ -162,7 +199,7 @@ func (_this *arrayEncoderEngine) addBooleanArrayData(data []byte) {
 				_this.stream.AddByte('0')
 			}
 		}
-		data = data[:len(data)-1]
+		data = data[1:]
 		_this.remainingChunkElements -= 8
 	}
 	if _this.remainingChunkElements > 0 && len(data) > 0 {
@@ -232,7 +269,7 @@ func (_this *arrayEncoderEngine) beginArrayString(onComplete func()) {
 	_this.setElementByteWidth(1)
 	_this.addElementsFunc = func(data []byte) { _this.appendStringbuffer(data) }
 	_this.onComplete = func() {
-		_this.stream.WritePotentiallyQuotedStringBytes(_this.stringBuffer)
+		_this.writeString(_this.stringBuffer)
 		onComplete()
 	}
 }
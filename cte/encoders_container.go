@@ -780,7 +780,7 @@ func (_this *markupNameEncoder) prepareForContainer(ctx *EncoderContext) {
 
 func (_this *markupNameEncoder) Begin(ctx *EncoderContext) {
 	ctx.Stream.WriteMarkupBegin()
-	ctx.IncreaseIndent()
+	ctx.IncreaseFixedIndent()
 	ctx.ClearPrefix()
 	ctx.ContainerHasObjects = false
 }
@@ -21,6 +21,7 @@
 package cte
 
 import (
+	"fmt"
 	"math/big"
 	"time"
 
@@ -441,7 +442,7 @@ func (_this *referenceEncoder) EncodeBigInt(ctx *EncoderContext, value *big.Int)
 	_this.complete(ctx)
 }
 func (_this *referenceEncoder) BeginConstant(ctx *EncoderContext, name []byte, explicitValue bool) {
-	panic("TODO: referenceEncoder.BeginConstant")
+	panic(fmt.Errorf("a reference ID cannot be a constant (%s)", string(name)))
 }
 func (_this *referenceEncoder) EncodeArray(ctx *EncoderContext, arrayType events.ArrayType, elementCount uint64, data []uint8) {
 	ctx.ArrayEngine.EncodeArray(arrayType, elementCount, data)
@@ -490,7 +491,7 @@ func (_this *markerIDEncoder) EncodeBigInt(ctx *EncoderContext, value *big.Int)
 	_this.complete(ctx)
 }
 func (_this *markerIDEncoder) BeginConstant(ctx *EncoderContext, name []byte, explicitValue bool) {
-	panic("TODO: markerIDEncoder.BeginConstant")
+	panic(fmt.Errorf("a marker ID cannot be a constant (%s)", string(name)))
 }
 func (_this *markerIDEncoder) EncodeArray(ctx *EncoderContext, arrayType events.ArrayType, elementCount uint64, data []uint8) {
 	ctx.ArrayEngine.EncodeArray(arrayType, elementCount, data)
@@ -503,3 +504,73 @@ func (_this *markerIDEncoder) EncodeStringlikeArray(ctx *EncoderContext, arrayTy
 func (_this *markerIDEncoder) BeginArray(ctx *EncoderContext, arrayType events.ArrayType) {
 	ctx.BeginStandardArray(arrayType)
 }
+
+// =============================================================================
+
+type concatEncoder struct{}
+
+var globalConcatEncoder concatEncoder
+
+func (_this *concatEncoder) String() string { return "concatEncoder" }
+
+func (_this *concatEncoder) complete(ctx *EncoderContext) {
+	ctx.Stream.AddByte(':')
+	ctx.ChangeEncoder(&globalConcatSuffixEncoder)
+}
+
+func (_this *concatEncoder) ChildContainerFinished(ctx *EncoderContext, isVisibleChild bool) {
+	_this.complete(ctx)
+}
+
+func (_this *concatEncoder) EncodeArray(ctx *EncoderContext, arrayType events.ArrayType, elementCount uint64, data []uint8) {
+	ctx.ArrayEngine.EncodeArray(arrayType, elementCount, data)
+	_this.complete(ctx)
+}
+func (_this *concatEncoder) EncodeStringlikeArray(ctx *EncoderContext, arrayType events.ArrayType, data string) {
+	ctx.ArrayEngine.EncodeStringlikeArray(arrayType, data)
+	_this.complete(ctx)
+}
+func (_this *concatEncoder) BeginArray(ctx *EncoderContext, arrayType events.ArrayType) {
+	ctx.BeginStandardArray(arrayType)
+}
+
+// =============================================================================
+
+type concatSuffixEncoder struct{}
+
+var globalConcatSuffixEncoder concatSuffixEncoder
+
+func (_this *concatSuffixEncoder) String() string { return "concatSuffixEncoder" }
+
+func (_this *concatSuffixEncoder) complete(ctx *EncoderContext) {
+	ctx.Unstack()
+	ctx.CurrentEncoder.ChildContainerFinished(ctx, true)
+}
+
+func (_this *concatSuffixEncoder) ChildContainerFinished(ctx *EncoderContext, isVisibleChild bool) {
+	_this.complete(ctx)
+}
+
+func (_this *concatSuffixEncoder) EncodePositiveInt(ctx *EncoderContext, value uint64) {
+	ctx.Stream.WritePositiveInt(value)
+	_this.complete(ctx)
+}
+func (_this *concatSuffixEncoder) EncodeInt(ctx *EncoderContext, value int64) {
+	ctx.Stream.WriteInt(value)
+	_this.complete(ctx)
+}
+func (_this *concatSuffixEncoder) EncodeBigInt(ctx *EncoderContext, value *big.Int) {
+	ctx.Stream.WriteBigInt(value)
+	_this.complete(ctx)
+}
+func (_this *concatSuffixEncoder) EncodeArray(ctx *EncoderContext, arrayType events.ArrayType, elementCount uint64, data []uint8) {
+	ctx.ArrayEngine.EncodeArray(arrayType, elementCount, data)
+	_this.complete(ctx)
+}
+func (_this *concatSuffixEncoder) EncodeStringlikeArray(ctx *EncoderContext, arrayType events.ArrayType, data string) {
+	ctx.ArrayEngine.EncodeStringlikeArray(arrayType, data)
+	_this.complete(ctx)
+}
+func (_this *concatSuffixEncoder) BeginArray(ctx *EncoderContext, arrayType events.ArrayType) {
+	ctx.BeginStandardArray(arrayType)
+}
@@ -0,0 +1,367 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package cte
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// lineWrapper sits between the encode buffer and the final writer when
+// MaxColumn is set, and decides where lines get broken.
+//
+// Containers (lists, maps, metadata) are groups, and the separators between
+// their objects are breaks. A group is written on a single line if it fits
+// within MaxColumn, otherwise all of its breaks become newlines. When a line
+// is too long, the outermost group on that line is broken first.
+//
+// Typed arrays and quoted strings are filled instead: each of their breaks
+// only becomes a newline if the next element (or word) wouldn't otherwise
+// fit. Quoted strings are broken using a continuation escape.
+//
+// Output is held back until the layout of every group it contains has been
+// decided.
+type lineWrapper struct {
+	writer    io.Writer
+	indenter  *indenter
+	maxColumn int
+	// The column that the pending tokens start at
+	column     int
+	pending    []wrapToken
+	openGroups []*wrapGroup
+	// The most recently closed group (which the closing break belongs to)
+	closedGroup *wrapGroup
+	fill        fillMode
+	fillIndent  string
+}
+
+type wrapGroup struct {
+	depth int
+	// Groups that must always be broken (such as markup) can't be compacted
+	canCompact bool
+	isBroken   bool
+	breakCount int
+}
+
+func (_this *wrapGroup) isUndecided() bool {
+	return _this != nil && _this.canCompact && !_this.isBroken
+}
+
+func (_this *wrapGroup) isBrokenOrNil() bool {
+	return _this == nil || !_this.canCompact || _this.isBroken
+}
+
+type wrapTokenType int
+
+const (
+	wrapTokenText wrapTokenType = iota
+	wrapTokenBreak
+	wrapTokenFill
+)
+
+type wrapToken struct {
+	tokenType wrapTokenType
+	// The token's text, or the text of an unbroken break
+	text string
+	// The text of a broken break
+	brokenText string
+	group      *wrapGroup
+}
+
+type fillMode int
+
+const (
+	fillModeNone fillMode = iota
+	fillModeArray
+	fillModeString
+)
+
+func (_this *lineWrapper) Init(maxColumn uint, indenter *indenter) {
+	_this.maxColumn = int(maxColumn)
+	_this.indenter = indenter
+	_this.Reset()
+}
+
+func (_this *lineWrapper) IsEnabled() bool {
+	return _this.maxColumn > 0
+}
+
+func (_this *lineWrapper) SetWriter(writer io.Writer) {
+	_this.writer = writer
+}
+
+func (_this *lineWrapper) Reset() {
+	_this.column = 0
+	_this.pending = _this.pending[:0]
+	_this.openGroups = _this.openGroups[:0]
+	_this.closedGroup = nil
+	_this.fill = fillModeNone
+}
+
+// Receives text from the encode buffer.
+func (_this *lineWrapper) Write(p []byte) (n int, err error) {
+	text := string(p)
+	if strings.IndexByte(text, '\n') >= 0 {
+		// Text with embedded newlines can't be part of a single line.
+		_this.breakOpenGroups()
+	}
+
+	switch _this.fill {
+	case fillModeArray:
+		_this.addFilledText(text, func(text string, i int) bool { return true })
+	case fillModeString:
+		// The continuation escape skips all whitespace that follows it, so
+		// only break before a non-whitespace character.
+		_this.addFilledText(text, func(text string, i int) bool {
+			return i+1 < len(text) && !isWrapWhitespace(text[i+1])
+		})
+	default:
+		_this.addText(text)
+	}
+	return len(p), nil
+}
+
+func (_this *lineWrapper) BeginGroup(canCompact bool) {
+	if !canCompact {
+		_this.breakOpenGroups()
+	}
+	_this.openGroups = append(_this.openGroups, &wrapGroup{
+		depth:      len(_this.openGroups),
+		canCompact: canCompact,
+	})
+}
+
+func (_this *lineWrapper) EndGroup() {
+	last := len(_this.openGroups) - 1
+	_this.closedGroup = _this.openGroups[last]
+	_this.openGroups = _this.openGroups[:last]
+}
+
+// Add a break before an object in the current group. brokenText is what to
+// write if the group gets broken.
+func (_this *lineWrapper) AddObjectBreak(brokenText string) {
+	group := _this.currentGroup()
+	compactText := " "
+	if group == nil || group.breakCount == 0 {
+		compactText = ""
+	}
+	_this.addBreak(group, compactText, brokenText)
+
+	if group.isBrokenOrNil() {
+		// Everything pending is now complete, so its layout can be decided.
+		_this.commit()
+	}
+}
+
+// Add a break before the end of the most recently closed group.
+func (_this *lineWrapper) AddClosingBreak(brokenText string) {
+	_this.addBreak(_this.closedGroup, "", brokenText)
+}
+
+func (_this *lineWrapper) BeginFill(mode fillMode) {
+	_this.fill = mode
+	_this.fillIndent = string(_this.indenter.Get()) + "    "
+}
+
+func (_this *lineWrapper) EndFill() {
+	_this.fill = fillModeNone
+}
+
+// Write out everything that's pending.
+func (_this *lineWrapper) Flush() {
+	_this.commit()
+}
+
+func (_this *lineWrapper) currentGroup() *wrapGroup {
+	if len(_this.openGroups) == 0 {
+		return nil
+	}
+	return _this.openGroups[len(_this.openGroups)-1]
+}
+
+func (_this *lineWrapper) breakOpenGroups() {
+	for _, group := range _this.openGroups {
+		group.isBroken = true
+	}
+}
+
+func (_this *lineWrapper) addText(text string) {
+	if len(text) == 0 {
+		return
+	}
+	last := len(_this.pending) - 1
+	if last >= 0 && _this.pending[last].tokenType == wrapTokenText {
+		_this.pending[last].text += text
+		return
+	}
+	_this.pending = append(_this.pending, wrapToken{tokenType: wrapTokenText, text: text})
+}
+
+func (_this *lineWrapper) addFilledText(text string, canBreakAt func(text string, i int) bool) {
+	brokenText := _this.fillIndent
+	if _this.fill == fillModeString {
+		brokenText = ` \` + brokenText
+	}
+	start := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] == ' ' && canBreakAt(text, i) {
+			_this.addText(text[start:i])
+			_this.pending = append(_this.pending, wrapToken{
+				tokenType:  wrapTokenFill,
+				text:       " ",
+				brokenText: brokenText,
+			})
+			start = i + 1
+		}
+	}
+	_this.addText(text[start:])
+}
+
+func (_this *lineWrapper) addBreak(group *wrapGroup, compactText string, brokenText string) {
+	if group != nil {
+		group.breakCount++
+	}
+	_this.pending = append(_this.pending, wrapToken{
+		tokenType:  wrapTokenBreak,
+		text:       compactText,
+		brokenText: brokenText,
+		group:      group,
+	})
+	_this.breakOverlongLines()
+}
+
+// Break groups until every line fits (or can't be broken any further).
+func (_this *lineWrapper) breakOverlongLines() {
+	for {
+		group := _this.findGroupToBreak()
+		if group == nil {
+			return
+		}
+		group.isBroken = true
+	}
+}
+
+// Find the outermost undecided group on the first line that's too long.
+func (_this *lineWrapper) findGroupToBreak() *wrapGroup {
+	column := _this.column
+	var candidate *wrapGroup
+
+	endLine := func() *wrapGroup {
+		if column > _this.maxColumn {
+			return candidate
+		}
+		return nil
+	}
+
+	for _, token := range _this.pending {
+		switch token.tokenType {
+		case wrapTokenText:
+			if index := strings.LastIndexByte(token.text, '\n'); index >= 0 {
+				column += utf8.RuneCountInString(token.text[:index])
+				if group := endLine(); group != nil {
+					return group
+				}
+				candidate = nil
+				column = utf8.RuneCountInString(token.text[index+1:])
+			} else {
+				column += utf8.RuneCountInString(token.text)
+			}
+		case wrapTokenFill:
+			column += len(token.text)
+		case wrapTokenBreak:
+			if token.group.isBrokenOrNil() {
+				if group := endLine(); group != nil {
+					return group
+				}
+				candidate = nil
+				column = lineWidthAfterBreak(token.brokenText)
+				continue
+			}
+			column += len(token.text)
+			if candidate == nil || token.group.depth < candidate.depth {
+				candidate = token.group
+			}
+		}
+	}
+	return endLine()
+}
+
+// Write out all pending tokens.
+func (_this *lineWrapper) commit() {
+	if len(_this.pending) == 0 {
+		return
+	}
+
+	var sb strings.Builder
+	column := _this.column
+	for i, token := range _this.pending {
+		text := token.text
+		switch token.tokenType {
+		case wrapTokenBreak:
+			if token.group.isBrokenOrNil() {
+				text = token.brokenText
+			}
+		case wrapTokenFill:
+			// Leave room for the continuation in case the next segment is
+			// followed by another break.
+			continuationWidth := strings.IndexByte(token.brokenText, '\n')
+			if column+len(text)+_this.segmentWidth(i+1)+continuationWidth > _this.maxColumn {
+				text = token.brokenText
+			}
+		}
+		sb.WriteString(text)
+		if index := strings.LastIndexByte(text, '\n'); index >= 0 {
+			column = utf8.RuneCountInString(text[index+1:])
+		} else {
+			column += utf8.RuneCountInString(text)
+		}
+	}
+	_this.column = column
+	_this.pending = _this.pending[:0]
+
+	if _, err := io.WriteString(_this.writer, sb.String()); err != nil {
+		panic(err)
+	}
+}
+
+// The width of the text starting at pending[index], up to the next break.
+func (_this *lineWrapper) segmentWidth(index int) (width int) {
+	for _, token := range _this.pending[index:] {
+		if token.tokenType != wrapTokenText {
+			return
+		}
+		if newline := strings.IndexByte(token.text, '\n'); newline >= 0 {
+			return width + utf8.RuneCountInString(token.text[:newline])
+		}
+		width += utf8.RuneCountInString(token.text)
+	}
+	return
+}
+
+func lineWidthAfterBreak(brokenText string) int {
+	index := strings.LastIndexByte(brokenText, '\n')
+	return utf8.RuneCountInString(brokenText[index+1:])
+}
+
+func isWrapWhitespace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}
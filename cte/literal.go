@@ -0,0 +1,48 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package cte
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kstenerud/go-concise-encoding/events"
+	"github.com/kstenerud/go-concise-encoding/version"
+)
+
+// Decode a single CTE value (without a document header), sending its events
+// to eventReceiver.
+func DecodeLiteral(literal []byte, eventReceiver events.DataEventReceiver) error {
+	var document bytes.Buffer
+	document.WriteString(fmt.Sprintf("c%v ", version.ConciseEncodingVersion))
+	document.Write(literal)
+	return NewDecoder(nil).Decode(&document, eventReceiver)
+}
+
+// Decode a complete CTE document, sending its events to eventReceiver.
+func DecodeDocument(document []byte, eventReceiver events.DataEventReceiver) error {
+	return NewDecoder(nil).Decode(bytes.NewBuffer(document), eventReceiver)
+}
+
+func init() {
+	events.RegisterLiteralDecoder(DecodeLiteral)
+	events.RegisterDocumentDecoder(DecodeDocument)
+}
@@ -147,6 +147,7 @@ func CMT() *test.TEvent                      { return test.CMT() }
 func E() *test.TEvent                        { return test.E() }
 func MARK() *test.TEvent                     { return test.MARK() }
 func REF() *test.TEvent                      { return test.REF() }
+func CAT() *test.TEvent                      { return test.CAT() }
 func CONST(n string, e bool) *test.TEvent    { return test.CONST(n, e) }
 func BD() *test.TEvent                       { return test.BD() }
 func ED() *test.TEvent                       { return test.ED() }
@@ -154,10 +155,14 @@ func ED() *test.TEvent                       { return test.ED() }
 var DebugPrintEvents = false
 
 func decodeToEvents(opts *options.CTEDecoderOptions, document []byte) (evts []*test.TEvent, err error) {
+	return decodeToEventsWithRules(opts, nil, document)
+}
+
+func decodeToEventsWithRules(opts *options.CTEDecoderOptions, ruleOpts *options.RuleOptions, document []byte) (evts []*test.TEvent, err error) {
 	var receiver events.DataEventReceiver
 	ter := test.NewTEventStore()
 	receiver = ter
-	receiver = rules.NewRules(receiver, nil)
+	receiver = rules.NewRules(receiver, ruleOpts)
 	if DebugPrintEvents {
 		receiver = test.NewStdoutTEventPrinter(receiver)
 	}
@@ -167,10 +172,14 @@ func decodeToEvents(opts *options.CTEDecoderOptions, document []byte) (evts []*t
 }
 
 func encodeEvents(opts *options.CTEEncoderOptions, events ...*test.TEvent) []byte {
+	return encodeEventsWithRules(opts, nil, events...)
+}
+
+func encodeEventsWithRules(opts *options.CTEEncoderOptions, ruleOpts *options.RuleOptions, events ...*test.TEvent) []byte {
 	buffer := &bytes.Buffer{}
 	encoder := NewEncoder(opts)
 	encoder.PrepareToEncode(buffer)
-	r := rules.NewRules(encoder, nil)
+	r := rules.NewRules(encoder, ruleOpts)
 	test.InvokeEvents(r, events...)
 	return buffer.Bytes()
 }
@@ -226,6 +235,27 @@ func assertDecodeEncode(t *testing.T, decodeOpts *options.CTEDecoderOptions,
 	return assertEncode(t, encodeOpts, document, actualEvents...)
 }
 
+// Decode and re-encode document with the specified rule options in effect.
+func assertDecodeEncodeWithRules(t *testing.T, ruleOpts *options.RuleOptions, document string, expectedEvents ...*test.TEvent) (successful bool) {
+	actualEvents, err := decodeToEventsWithRules(nil, ruleOpts, []byte(document))
+	if err != nil {
+		t.Errorf("Error [%v] while decoding document [%v]", err, document)
+		return
+	}
+	if !equivalence.IsEquivalent(actualEvents, expectedEvents) {
+		t.Errorf("Expected document [%v] to decode to events %v but got %v", document, expectedEvents, actualEvents)
+		return
+	}
+
+	actualDocument := string(encodeEventsWithRules(nil, ruleOpts, actualEvents...))
+	if !equivalence.IsEquivalent(actualDocument, document) {
+		t.Errorf("Expected events %v to encode to document [%v] but got [%v]", actualEvents, document, actualDocument)
+		return
+	}
+	successful = true
+	return
+}
+
 func assertMarshal(t *testing.T, value interface{}, expectedDocument string) (successful bool) {
 	document, err := NewMarshaler(nil).MarshalToDocument(value)
 	if err != nil {
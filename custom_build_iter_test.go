@@ -287,3 +287,25 @@ func TestCustomBuildIter(t *testing.T) {
 	assertMarshalUnmarshalComplex(t, complex(1, 1))
 	assertMarshalUnmarshalComplex(t, complex(float64(1.0000000000000000000000000001), float64(1)))
 }
+
+type CustomDefaultStruct struct {
+	C complex128 `ce:"default=|ct cplx(1.5+2.5i)|"`
+}
+
+func TestCustomBuildDefaultValue(t *testing.T) {
+	// Default values are built using the session's custom builders.
+	unmarshalOpts := options.DefaultCTEUnmarshalerOptions()
+	unmarshalOpts.Session.CustomTextBuildFunction = convertFromCustomText
+	unmarshalOpts.Session.CustomBuiltTypes = append(unmarshalOpts.Session.CustomBuiltTypes, reflect.TypeOf(complex128(0)))
+
+	unmarshaler := ce.NewCTEUnmarshaler(unmarshalOpts)
+	actual, err := unmarshaler.UnmarshalFromDocument([]byte("c0 {}"), CustomDefaultStruct{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &CustomDefaultStruct{C: complex(1.5, 2.5)}
+	if !equivalence.IsEquivalent(expected, actual) {
+		t.Errorf("Expected %v but got %v", describe.D(expected), describe.D(actual))
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package events
+
+import (
+	"fmt"
+)
+
+// ConstantValueIterator sends the events of a named constant's value to
+// eventReceiver, without beginning or ending a document. value is either a go
+// value or an options.CTELiteral. Errors are reported via panics.
+type ConstantValueIterator func(value interface{}, eventReceiver DataEventReceiver)
+
+var constantValueIterator ConstantValueIterator
+
+// The iterator package registers its constant value iterator here on init so
+// that packages it depends upon (such as the rules and builders) can
+// substitute the values of named constants without causing an import cycle.
+func RegisterConstantValueIterator(iterator ConstantValueIterator) {
+	constantValueIterator = iterator
+}
+
+// Send the events of a named constant's value to eventReceiver, without
+// beginning or ending a document.
+//
+// Note: This is a LOW LEVEL API. Error reporting is done via panics. Be sure
+// to recover() at an appropriate location when calling this function.
+func IterateConstantValue(value interface{}, eventReceiver DataEventReceiver) {
+	if constantValueIterator == nil {
+		panic(fmt.Errorf("no constant value iterator has been registered (import the iterator package)"))
+	}
+	constantValueIterator(value, eventReceiver)
+}
@@ -40,6 +40,9 @@ const (
 	ArrayTypeInvalid ArrayType = iota
 	ArrayTypeString
 	ArrayTypeResourceID
+	// A resource ID that will be followed by a suffix (a string or a positive
+	// integer) to concatenate onto it. This is equivalent to OnConcatenate()
+	// followed by an ArrayTypeResourceID array.
 	ArrayTypeResourceIDConcat
 	ArrayTypeCustomText
 	ArrayTypeCustomBinary
@@ -155,6 +158,9 @@ type DataEventReceiver interface {
 	OnEnd()
 	OnMarker()
 	OnReference()
+	// Concatenate a resource ID and a suffix into a single resource ID. Must be
+	// followed by a resource ID, and then the suffix (a string or a positive
+	// integer).
 	OnConcatenate()
 	OnConstant(name []byte, explicitValue bool)
 
@@ -212,3 +218,17 @@ func (_this *NullEventReceiver) OnReference()                        {}
 func (_this *NullEventReceiver) OnConcatenate()                      {}
 func (_this *NullEventReceiver) OnConstant(_ []byte, _ bool)         {}
 func (_this *NullEventReceiver) OnEndDocument()                      {}
+
+// DocumentlessEventReceiver passes events through to another receiver, except
+// for the begin document, version, and end document events. This allows the
+// contents of one document to be spliced into another.
+type DocumentlessEventReceiver struct {
+	DataEventReceiver
+}
+
+func NewDocumentlessEventReceiver(next DataEventReceiver) *DocumentlessEventReceiver {
+	return &DocumentlessEventReceiver{next}
+}
+func (_this *DocumentlessEventReceiver) OnBeginDocument() {}
+func (_this *DocumentlessEventReceiver) OnVersion(uint64) {}
+func (_this *DocumentlessEventReceiver) OnEndDocument()   {}
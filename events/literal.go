@@ -0,0 +1,69 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package events
+
+import (
+	"fmt"
+)
+
+// LiteralDecoder decodes a single value written in CTE format (without a
+// document header), sending the resulting data events to eventReceiver.
+type LiteralDecoder func(literal []byte, eventReceiver DataEventReceiver) error
+
+var literalDecoder LiteralDecoder
+
+// The CTE codec registers its literal decoder here on init so that packages it
+// depends upon (such as the builders) can interpret CTE literals found in
+// struct tags and options without causing an import cycle.
+func RegisterLiteralDecoder(decoder LiteralDecoder) {
+	literalDecoder = decoder
+}
+
+// Decode a single value written in CTE format (without a document header),
+// sending the resulting data events to eventReceiver.
+func DecodeLiteral(literal []byte, eventReceiver DataEventReceiver) error {
+	if literalDecoder == nil {
+		return fmt.Errorf("no CTE literal decoder has been registered (import the cte package)")
+	}
+	return literalDecoder(literal, eventReceiver)
+}
+
+// DocumentDecoder decodes a complete CTE document (including its header),
+// sending the resulting data events to eventReceiver.
+type DocumentDecoder func(document []byte, eventReceiver DataEventReceiver) error
+
+var documentDecoder DocumentDecoder
+
+// The CTE codec registers its document decoder here on init so that packages
+// it depends upon (such as the builders) can load referenced documents
+// without causing an import cycle.
+func RegisterDocumentDecoder(decoder DocumentDecoder) {
+	documentDecoder = decoder
+}
+
+// Decode a complete CTE document, sending the resulting data events to
+// eventReceiver.
+func DecodeDocument(document []byte, eventReceiver DataEventReceiver) error {
+	if documentDecoder == nil {
+		return fmt.Errorf("no CTE document decoder has been registered (import the cte package)")
+	}
+	return documentDecoder(document, eventReceiver)
+}
@@ -0,0 +1,492 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package events
+
+import (
+	"math"
+	"math/big"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/kstenerud/go-concise-encoding/types"
+
+	"github.com/cockroachdb/apd/v2"
+	"github.com/kstenerud/go-compact-float"
+	"github.com/kstenerud/go-compact-time"
+)
+
+// MapSortingEventReceiver passes events through to another receiver, but
+// buffers each map (including nested maps) until it ends, and then passes on
+// its entries sorted by key.
+//
+// Keys are passed to the comparator as their natural go values: nil, bool,
+// uint64, int64, *big.Int, float64, *big.Float, compact_float.DFloat,
+// *apd.Decimal, types.UUID, time.Time, compact_time.Time, string, *url.URL
+// (resource IDs), or []byte (other arrays). Keys that are references or
+// constants without an explicit value are passed as nil. Concatenated resource
+// IDs are compared using their resource ID portion.
+//
+// Comments and metadata stay with the entry that follows them. Comments at
+// the end of a map stay at the end.
+type MapSortingEventReceiver struct {
+	next    DataEventReceiver
+	compare func(a, b interface{}) int
+	frames  []*mapSortFrame
+}
+
+type recordedEvent func(receiver DataEventReceiver)
+
+type mapSortEntry struct {
+	events           []recordedEvent
+	objectsRequired  int
+	objectsCompleted int
+	idsToSkip        int
+	keyIsDone        bool
+	hasKey           bool
+	key              interface{}
+}
+
+type mapSortFrame struct {
+	entries []*mapSortEntry
+	current *mapSortEntry
+
+	// Containers opened inside the current object, and whether they are
+	// visible objects (as opposed to comments, metadata, and markup
+	// attributes).
+	containers []bool
+
+	arrayType           ArrayType
+	arrayBytesRemaining uint64
+	arrayIsLastChunk    bool
+	arrayData           []byte
+}
+
+// Create a map sorting receiver that sorts keys using compare (which returns
+// a negative number if a sorts before b, a positive number if a sorts after
+// b, and 0 if they are equal), and then passes events to next.
+func NewMapSortingEventReceiver(next DataEventReceiver, compare func(a, b interface{}) int) *MapSortingEventReceiver {
+	_this := &MapSortingEventReceiver{}
+	_this.Init(next, compare)
+	return _this
+}
+
+func (_this *MapSortingEventReceiver) Init(next DataEventReceiver, compare func(a, b interface{}) int) {
+	_this.next = next
+	_this.compare = compare
+	_this.Reset()
+}
+
+// Discard any buffered maps.
+func (_this *MapSortingEventReceiver) Reset() {
+	_this.frames = _this.frames[:0]
+}
+
+func (_this *MapSortingEventReceiver) isPassingThrough() bool {
+	return len(_this.frames) == 0
+}
+
+func (_this *MapSortingEventReceiver) frame() *mapSortFrame {
+	return _this.frames[len(_this.frames)-1]
+}
+
+func (_this *mapSortFrame) entry() *mapSortEntry {
+	if _this.current == nil {
+		_this.current = &mapSortEntry{objectsRequired: 2}
+	}
+	return _this.current
+}
+
+func (_this *mapSortFrame) record(event recordedEvent) {
+	entry := _this.entry()
+	entry.events = append(entry.events, event)
+}
+
+func (_this *mapSortFrame) isAtObjectLevel() bool {
+	return len(_this.containers) == 0
+}
+
+func (_this *mapSortFrame) captureKey(key interface{}) {
+	entry := _this.entry()
+	if !_this.isAtObjectLevel() || entry.keyIsDone || entry.hasKey || entry.idsToSkip > 0 {
+		return
+	}
+	entry.key = key
+	entry.hasKey = true
+}
+
+func (_this *mapSortFrame) completeObject() {
+	entry := _this.entry()
+	entry.objectsCompleted++
+	if !entry.keyIsDone {
+		if entry.idsToSkip > 0 {
+			entry.idsToSkip--
+		} else {
+			entry.keyIsDone = true
+		}
+	}
+	if entry.objectsCompleted >= entry.objectsRequired {
+		_this.entries = append(_this.entries, entry)
+		_this.current = nil
+	}
+}
+
+func (_this *mapSortFrame) completeScalar(key interface{}) {
+	if _this.isAtObjectLevel() {
+		_this.captureKey(key)
+		_this.completeObject()
+	}
+}
+
+func (_this *mapSortFrame) beginContainer(isVisible bool) {
+	_this.entry()
+	_this.containers = append(_this.containers, isVisible)
+}
+
+// Returns true if this ends the map itself
+func (_this *mapSortFrame) endContainer() (isMapEnd bool) {
+	if _this.isAtObjectLevel() {
+		return true
+	}
+	last := len(_this.containers) - 1
+	isVisible := _this.containers[last]
+	_this.containers = _this.containers[:last]
+	if isVisible && _this.isAtObjectLevel() {
+		_this.completeObject()
+	}
+	return false
+}
+
+// Get the sorted events of a finished map (including the begin and end
+// events).
+func (_this *MapSortingEventReceiver) sortedEvents(frame *mapSortFrame) []recordedEvent {
+	sort.SliceStable(frame.entries, func(i, j int) bool {
+		return _this.compare(frame.entries[i].key, frame.entries[j].key) < 0
+	})
+	result := []recordedEvent{func(receiver DataEventReceiver) { receiver.OnMap() }}
+	for _, entry := range frame.entries {
+		result = append(result, entry.events...)
+	}
+	if frame.current != nil {
+		result = append(result, frame.current.events...)
+	}
+	return append(result, func(receiver DataEventReceiver) { receiver.OnEnd() })
+}
+
+func (_this *MapSortingEventReceiver) endMap() {
+	frame := _this.frame()
+	_this.frames = _this.frames[:len(_this.frames)-1]
+	events := _this.sortedEvents(frame)
+	if _this.isPassingThrough() {
+		for _, event := range events {
+			event(_this.next)
+		}
+		return
+	}
+
+	parent := _this.frame()
+	parent.record(func(receiver DataEventReceiver) {
+		for _, event := range events {
+			event(receiver)
+		}
+	})
+	if parent.isAtObjectLevel() {
+		parent.completeObject()
+	}
+}
+
+func (_this *MapSortingEventReceiver) onScalar(event recordedEvent, key interface{}) {
+	if _this.isPassingThrough() {
+		event(_this.next)
+		return
+	}
+	frame := _this.frame()
+	frame.record(event)
+	frame.completeScalar(key)
+}
+
+// The suffix of a concatenated resource ID is an extra object
+func (_this *mapSortFrame) beginConcatenate() {
+	if _this.isAtObjectLevel() {
+		_this.entry().objectsRequired++
+	}
+}
+
+func (_this *MapSortingEventReceiver) onArrayComplete() {
+	frame := _this.frame()
+	if !frame.isAtObjectLevel() {
+		return
+	}
+	frame.completeScalar(arrayKey(frame.arrayType, frame.arrayData))
+	frame.arrayData = nil
+}
+
+func arrayKey(arrayType ArrayType, data []byte) interface{} {
+	switch arrayType {
+	case ArrayTypeString:
+		return string(data)
+	case ArrayTypeResourceID, ArrayTypeResourceIDConcat:
+		if rid, err := url.Parse(string(data)); err == nil {
+			return rid
+		}
+		return string(data)
+	default:
+		return data
+	}
+}
+
+func copyBytes(data []byte) []byte {
+	result := make([]byte, len(data))
+	copy(result, data)
+	return result
+}
+
+func (_this *MapSortingEventReceiver) OnBeginDocument() {
+	_this.Reset()
+	_this.next.OnBeginDocument()
+}
+func (_this *MapSortingEventReceiver) OnEndDocument() {
+	_this.next.OnEndDocument()
+}
+func (_this *MapSortingEventReceiver) OnVersion(version uint64) {
+	_this.next.OnVersion(version)
+}
+func (_this *MapSortingEventReceiver) OnPadding(count int) {
+	if _this.isPassingThrough() {
+		_this.next.OnPadding(count)
+		return
+	}
+	_this.frame().record(func(receiver DataEventReceiver) { receiver.OnPadding(count) })
+}
+func (_this *MapSortingEventReceiver) OnNA() {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnNA() }, nil)
+}
+func (_this *MapSortingEventReceiver) OnBool(value bool) {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnBool(value) }, value)
+}
+func (_this *MapSortingEventReceiver) OnTrue() {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnTrue() }, true)
+}
+func (_this *MapSortingEventReceiver) OnFalse() {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnFalse() }, false)
+}
+func (_this *MapSortingEventReceiver) OnPositiveInt(value uint64) {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnPositiveInt(value) }, value)
+}
+func (_this *MapSortingEventReceiver) OnNegativeInt(value uint64) {
+	var key interface{}
+	if value <= math.MaxInt64 {
+		key = -int64(value)
+	} else {
+		key = new(big.Int).Neg(new(big.Int).SetUint64(value))
+	}
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnNegativeInt(value) }, key)
+}
+func (_this *MapSortingEventReceiver) OnInt(value int64) {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnInt(value) }, value)
+}
+func (_this *MapSortingEventReceiver) OnBigInt(value *big.Int) {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnBigInt(value) }, value)
+}
+func (_this *MapSortingEventReceiver) OnFloat(value float64) {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnFloat(value) }, value)
+}
+func (_this *MapSortingEventReceiver) OnBigFloat(value *big.Float) {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnBigFloat(value) }, value)
+}
+func (_this *MapSortingEventReceiver) OnDecimalFloat(value compact_float.DFloat) {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnDecimalFloat(value) }, value)
+}
+func (_this *MapSortingEventReceiver) OnBigDecimalFloat(value *apd.Decimal) {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnBigDecimalFloat(value) }, value)
+}
+func (_this *MapSortingEventReceiver) OnNan(signaling bool) {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnNan(signaling) }, math.NaN())
+}
+func (_this *MapSortingEventReceiver) OnTime(value time.Time) {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnTime(value) }, value)
+}
+func (_this *MapSortingEventReceiver) OnCompactTime(value compact_time.Time) {
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnCompactTime(value) }, value)
+}
+func (_this *MapSortingEventReceiver) OnUUID(value []byte) {
+	if _this.isPassingThrough() {
+		_this.next.OnUUID(value)
+		return
+	}
+	var uuid types.UUID
+	copy(uuid[:], value)
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnUUID(uuid[:]) }, uuid)
+}
+func (_this *MapSortingEventReceiver) OnArray(arrayType ArrayType, elementCount uint64, data []uint8) {
+	if _this.isPassingThrough() {
+		_this.next.OnArray(arrayType, elementCount, data)
+		return
+	}
+	data = copyBytes(data)
+	if arrayType == ArrayTypeResourceIDConcat {
+		_this.frame().beginConcatenate()
+	}
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnArray(arrayType, elementCount, data) },
+		arrayKey(arrayType, data))
+}
+func (_this *MapSortingEventReceiver) OnStringlikeArray(arrayType ArrayType, data string) {
+	var key interface{} = data
+	if arrayType == ArrayTypeResourceID || arrayType == ArrayTypeResourceIDConcat {
+		key = arrayKey(arrayType, []byte(data))
+	}
+	if arrayType == ArrayTypeResourceIDConcat && !_this.isPassingThrough() {
+		_this.frame().beginConcatenate()
+	}
+	_this.onScalar(func(receiver DataEventReceiver) { receiver.OnStringlikeArray(arrayType, data) }, key)
+}
+func (_this *MapSortingEventReceiver) OnArrayBegin(arrayType ArrayType) {
+	if _this.isPassingThrough() {
+		_this.next.OnArrayBegin(arrayType)
+		return
+	}
+	frame := _this.frame()
+	frame.record(func(receiver DataEventReceiver) { receiver.OnArrayBegin(arrayType) })
+	if arrayType == ArrayTypeResourceIDConcat {
+		frame.beginConcatenate()
+	}
+	frame.arrayType = arrayType
+	frame.arrayData = frame.arrayData[:0]
+}
+func (_this *MapSortingEventReceiver) OnArrayChunk(length uint64, moreChunksFollow bool) {
+	if _this.isPassingThrough() {
+		_this.next.OnArrayChunk(length, moreChunksFollow)
+		return
+	}
+	frame := _this.frame()
+	frame.record(func(receiver DataEventReceiver) { receiver.OnArrayChunk(length, moreChunksFollow) })
+	frame.arrayBytesRemaining = (length*uint64(frame.arrayType.ElementSize()) + 7) / 8
+	frame.arrayIsLastChunk = !moreChunksFollow
+	if frame.arrayBytesRemaining == 0 && frame.arrayIsLastChunk {
+		_this.onArrayComplete()
+	}
+}
+func (_this *MapSortingEventReceiver) OnArrayData(data []byte) {
+	if _this.isPassingThrough() {
+		_this.next.OnArrayData(data)
+		return
+	}
+	frame := _this.frame()
+	data = copyBytes(data)
+	frame.record(func(receiver DataEventReceiver) { receiver.OnArrayData(data) })
+	if frame.isAtObjectLevel() && !frame.entry().keyIsDone {
+		frame.arrayData = append(frame.arrayData, data...)
+	}
+	frame.arrayBytesRemaining -= uint64(len(data))
+	if frame.arrayBytesRemaining == 0 && frame.arrayIsLastChunk {
+		_this.onArrayComplete()
+	}
+}
+func (_this *MapSortingEventReceiver) onContainer(event recordedEvent, isVisible bool) {
+	if _this.isPassingThrough() {
+		event(_this.next)
+		return
+	}
+	frame := _this.frame()
+	frame.record(event)
+	frame.beginContainer(isVisible)
+}
+func (_this *MapSortingEventReceiver) OnList() {
+	_this.onContainer(func(receiver DataEventReceiver) { receiver.OnList() }, true)
+}
+func (_this *MapSortingEventReceiver) OnMap() {
+	_this.frames = append(_this.frames, &mapSortFrame{})
+}
+func (_this *MapSortingEventReceiver) OnMarkup() {
+	_this.onContainer(func(receiver DataEventReceiver) { receiver.OnMarkup() }, true)
+	if !_this.isPassingThrough() {
+		// Markup has an attributes section and a contents section, each
+		// terminated by an end event.
+		_this.frame().beginContainer(false)
+	}
+}
+func (_this *MapSortingEventReceiver) OnMetadata() {
+	_this.onContainer(func(receiver DataEventReceiver) { receiver.OnMetadata() }, false)
+}
+func (_this *MapSortingEventReceiver) OnComment() {
+	_this.onContainer(func(receiver DataEventReceiver) { receiver.OnComment() }, false)
+}
+func (_this *MapSortingEventReceiver) OnEnd() {
+	if _this.isPassingThrough() {
+		_this.next.OnEnd()
+		return
+	}
+	frame := _this.frame()
+	if frame.endContainer() {
+		_this.endMap()
+		return
+	}
+	frame.record(func(receiver DataEventReceiver) { receiver.OnEnd() })
+}
+func (_this *MapSortingEventReceiver) OnMarker() {
+	if _this.isPassingThrough() {
+		_this.next.OnMarker()
+		return
+	}
+	frame := _this.frame()
+	frame.record(func(receiver DataEventReceiver) { receiver.OnMarker() })
+	if frame.isAtObjectLevel() {
+		// The marker ID is an extra object
+		entry := frame.entry()
+		entry.objectsRequired++
+		if !entry.keyIsDone {
+			entry.idsToSkip++
+		}
+	}
+}
+func (_this *MapSortingEventReceiver) OnReference() {
+	if _this.isPassingThrough() {
+		_this.next.OnReference()
+		return
+	}
+	frame := _this.frame()
+	frame.record(func(receiver DataEventReceiver) { receiver.OnReference() })
+	// The reference is completed by its ID, which is not a meaningful key.
+	frame.captureKey(nil)
+}
+func (_this *MapSortingEventReceiver) OnConcatenate() {
+	if _this.isPassingThrough() {
+		_this.next.OnConcatenate()
+		return
+	}
+	frame := _this.frame()
+	frame.record(func(receiver DataEventReceiver) { receiver.OnConcatenate() })
+	frame.beginConcatenate()
+}
+func (_this *MapSortingEventReceiver) OnConstant(name []byte, explicitValue bool) {
+	if _this.isPassingThrough() {
+		_this.next.OnConstant(name, explicitValue)
+		return
+	}
+	frame := _this.frame()
+	name = copyBytes(name)
+	frame.record(func(receiver DataEventReceiver) { receiver.OnConstant(name, explicitValue) })
+	if explicitValue {
+		// The explicit value that follows completes the object
+		return
+	}
+	frame.completeScalar(nil)
+}
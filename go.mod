@@ -11,4 +11,5 @@ require (
 	github.com/kstenerud/go-duplicates v1.1.1
 	github.com/kstenerud/go-equivalence v1.0.4
 	github.com/kstenerud/go-uleb128 v1.0.4
+	golang.org/x/text v0.3.6
 )
@@ -29,6 +29,8 @@ import (
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/kstenerud/go-concise-encoding/types"
+
 	"github.com/cockroachdb/apd/v2"
 	"github.com/kstenerud/go-compact-float"
 	"github.com/kstenerud/go-compact-time"
@@ -40,6 +42,7 @@ var (
 	TypeInterfaceArray = reflect.TypeOf([1]interface{}{})
 	TypeInterfaceSlice = reflect.TypeOf([]interface{}{})
 	TypeInterfaceMap   = reflect.TypeOf(map[interface{}]interface{}{})
+	TypeStringMap      = reflect.TypeOf(map[string]interface{}{})
 	TypeString         = reflect.TypeOf("")
 	TypeBytes          = reflect.TypeOf([]uint8{})
 	TypeTime           = reflect.TypeOf(time.Time{})
@@ -57,6 +60,15 @@ var (
 
 	TypeURL  = reflect.TypeOf(url.URL{})
 	TypePURL = reflect.TypeOf((*url.URL)(nil))
+
+	TypeUUID = reflect.TypeOf(types.UUID{})
+
+	TypeNumber      = reflect.TypeOf(types.Number(""))
+	TypeOrderedMap  = reflect.TypeOf(types.OrderedMap{})
+	TypePOrderedMap = reflect.TypeOf((*types.OrderedMap)(nil))
+
+	TypeFloat16  = reflect.TypeOf(types.Float16(0))
+	TypeBFloat16 = reflect.TypeOf(types.BFloat16(0))
 )
 
 var KeyableTypes = []reflect.Type{
@@ -78,6 +90,7 @@ var KeyableTypes = []reflect.Type{
 	reflect.TypeOf((*time.Time)(nil)).Elem(),
 	reflect.TypeOf((*compact_time.Time)(nil)).Elem(),
 	reflect.TypeOf((*compact_float.DFloat)(nil)).Elem(),
+	reflect.TypeOf((*types.UUID)(nil)).Elem(),
 	reflect.TypeOf((*interface{})(nil)).Elem(),
 
 	// Must be pointers
@@ -155,6 +168,20 @@ func IsNil(v reflect.Value) bool {
 	return IsNullable(v) && v.IsNil()
 }
 
+// Returns true if t can be used as a polymorphic type (a struct or pointer to
+// struct).
+func IsPolymorphicCompatible(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// Returns true if t can hold a UUID (an array of 16 bytes).
+func IsUUIDCompatible(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8
+}
+
 func CloneBytes(bytes []byte) []byte {
 	bytesCopy := make([]byte, len(bytes), len(bytes))
 	copy(bytesCopy, bytes)
@@ -213,3 +240,28 @@ func ElementCountToByteCount(elementBitWidth int, elementCount uint64) uint64 {
 	}
 	return byteCount
 }
+
+// Split a "ce" struct tag into its comma separated entries. Commas inside
+// double quotes (where a backslash escapes the next character) don't separate
+// entries, so that values written as CTE literals can contain them.
+func SplitCETag(tags string) []string {
+	var entries []string
+	start := 0
+	isQuoted := false
+	for i := 0; i < len(tags); i++ {
+		switch tags[i] {
+		case '\\':
+			if isQuoted {
+				i++
+			}
+		case '"':
+			isQuoted = !isQuoted
+		case ',':
+			if !isQuoted {
+				entries = append(entries, tags[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(entries, tags[start:])
+}
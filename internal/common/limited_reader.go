@@ -0,0 +1,56 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package common
+
+import (
+	"fmt"
+	"io"
+)
+
+// Wraps a reader, failing with an error once more than maxByteCount bytes
+// have been read from it.
+type LimitedReader struct {
+	reader             io.Reader
+	remainingByteCount uint64
+	maxByteCount       uint64
+}
+
+func NewLimitedReader(reader io.Reader, maxByteCount uint64) *LimitedReader {
+	return &LimitedReader{
+		reader:             reader,
+		remainingByteCount: maxByteCount,
+		maxByteCount:       maxByteCount,
+	}
+}
+
+func (_this *LimitedReader) Read(p []byte) (n int, err error) {
+	// Ask for one byte more than allowed so that a document of exactly
+	// maxByteCount bytes still reaches EOF cleanly.
+	if _this.remainingByteCount < uint64(len(p)) {
+		p = p[:_this.remainingByteCount+1]
+	}
+	n, err = _this.reader.Read(p)
+	if uint64(n) > _this.remainingByteCount {
+		return 0, fmt.Errorf("Document exceeds max byte count of %d", _this.maxByteCount)
+	}
+	_this.remainingByteCount -= uint64(n)
+	return
+}
@@ -0,0 +1,278 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/apd/v2"
+	"github.com/kstenerud/go-compact-float"
+	"github.com/kstenerud/go-compact-time"
+)
+
+// Map key categories, in sort order.
+const (
+	mapKeyRankNil = iota
+	mapKeyRankBool
+	mapKeyRankNumber
+	mapKeyRankString
+	mapKeyRankResourceID
+	mapKeyRankUUID
+	mapKeyRankTime
+	mapKeyRankOther
+)
+
+// CompareMapKeys defines a total order over map keys of any type, returning
+// a negative number if a sorts before b, a positive number if a sorts after b,
+// and 0 if they are equal. Pointers and interfaces are compared by what they
+// point to.
+//
+// Keys are first ordered by category:
+//
+//	nil < bool < numbers < strings < resource IDs < UUIDs < times < other
+//
+// Within a category, bools sort false first, numbers (integers, floats,
+// decimal floats, big numbers, and types.Number) sort numerically, strings and
+// resource IDs sort by their UTF-8 bytes, UUIDs sort by their bytes, times
+// sort chronologically (or by their text when they can't be converted to a
+// time.Time), and other types sort by their fmt "%v" text. Equal values of
+// different types are ordered by type name.
+func CompareMapKeys(a, b interface{}) int {
+	va := mapKeyTarget(reflect.ValueOf(a))
+	vb := mapKeyTarget(reflect.ValueOf(b))
+	rankA := mapKeyRank(va)
+	rankB := mapKeyRank(vb)
+	if rankA != rankB {
+		return compareInts(rankA, rankB)
+	}
+
+	result := 0
+	switch rankA {
+	case mapKeyRankNil:
+		return 0
+	case mapKeyRankBool:
+		result = compareBools(va.Bool(), vb.Bool())
+	case mapKeyRankNumber:
+		result = compareNumbers(va, vb)
+	case mapKeyRankString:
+		result = strings.Compare(va.String(), vb.String())
+	case mapKeyRankResourceID:
+		urlA := va.Interface().(url.URL)
+		urlB := vb.Interface().(url.URL)
+		result = strings.Compare(urlA.String(), urlB.String())
+	case mapKeyRankUUID:
+		result = bytes.Compare(uuidBytes(va), uuidBytes(vb))
+	case mapKeyRankTime:
+		result = compareTimes(va, vb)
+	default:
+		result = strings.Compare(fmt.Sprintf("%v", va.Interface()), fmt.Sprintf("%v", vb.Interface()))
+	}
+	if result == 0 && va.Type() != vb.Type() {
+		result = strings.Compare(va.Type().String(), vb.Type().String())
+	}
+	return result
+}
+
+func mapKeyTarget(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func mapKeyRank(v reflect.Value) int {
+	if !v.IsValid() {
+		return mapKeyRankNil
+	}
+	switch v.Type() {
+	case TypeNumber, TypeBigInt, TypeBigFloat, TypeBigDecimalFloat, TypeDFloat:
+		return mapKeyRankNumber
+	case TypeURL:
+		return mapKeyRankResourceID
+	case TypeTime, TypeCompactTime:
+		return mapKeyRankTime
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return mapKeyRankBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return mapKeyRankNumber
+	case reflect.String:
+		return mapKeyRankString
+	case reflect.Array:
+		if IsUUIDCompatible(v.Type()) {
+			return mapKeyRankUUID
+		}
+	}
+	return mapKeyRankOther
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func uuidBytes(v reflect.Value) []byte {
+	result := make([]byte, v.Len())
+	for i := range result {
+		result[i] = uint8(v.Index(i).Uint())
+	}
+	return result
+}
+
+// Numeric classes, in sort order.
+const (
+	numberClassNegativeInfinity = iota
+	numberClassFinite
+	numberClassPositiveInfinity
+	numberClassNaN
+)
+
+func compareNumbers(a, b reflect.Value) int {
+	classA, ratA := numberAsRat(a)
+	classB, ratB := numberAsRat(b)
+	if classA != classB || classA != numberClassFinite {
+		return compareInts(classA, classB)
+	}
+	return ratA.Cmp(ratB)
+}
+
+func numberAsRat(v reflect.Value) (class int, rat *big.Rat) {
+	switch v.Type() {
+	case TypeNumber:
+		return ratFromString(v.String())
+	case TypeBigInt:
+		bi := v.Interface().(big.Int)
+		return numberClassFinite, new(big.Rat).SetInt(&bi)
+	case TypeBigFloat:
+		bf := v.Interface().(big.Float)
+		if bf.IsInf() {
+			return infinityClass(bf.Signbit()), nil
+		}
+		rat, _ := bf.Rat(nil)
+		return numberClassFinite, rat
+	case TypeDFloat:
+		df := v.Interface().(compact_float.DFloat)
+		switch {
+		case df.IsNan():
+			return numberClassNaN, nil
+		case df.IsInfinity():
+			return infinityClass(df.IsNegativeInfinity()), nil
+		}
+		return ratFromString(df.APD().Text('f'))
+	case TypeBigDecimalFloat:
+		d := v.Interface().(apd.Decimal)
+		switch d.Form {
+		case apd.Infinite:
+			return infinityClass(d.Negative), nil
+		case apd.NaN, apd.NaNSignaling:
+			return numberClassNaN, nil
+		}
+		return ratFromString(d.Text('f'))
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return numberClassFinite, new(big.Rat).SetInt64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return numberClassFinite, new(big.Rat).SetUint64(v.Uint())
+	default:
+		f := v.Float()
+		switch {
+		case f != f:
+			return numberClassNaN, nil
+		case math.IsInf(f, 0):
+			return infinityClass(f < 0), nil
+		}
+		return numberClassFinite, new(big.Rat).SetFloat64(f)
+	}
+}
+
+func infinityClass(isNegative bool) int {
+	if isNegative {
+		return numberClassNegativeInfinity
+	}
+	return numberClassPositiveInfinity
+}
+
+func ratFromString(str string) (class int, rat *big.Rat) {
+	rat, ok := new(big.Rat).SetString(str)
+	if !ok {
+		return numberClassNaN, nil
+	}
+	return numberClassFinite, rat
+}
+
+func compareTimes(a, b reflect.Value) int {
+	timeA, okA := asGoTime(a)
+	timeB, okB := asGoTime(b)
+	if okA && okB {
+		switch {
+		case timeA.Before(timeB):
+			return -1
+		case timeA.After(timeB):
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(fmt.Sprintf("%v", a.Interface()), fmt.Sprintf("%v", b.Interface()))
+}
+
+func asGoTime(v reflect.Value) (time.Time, bool) {
+	switch t := v.Interface().(type) {
+	case time.Time:
+		return t, true
+	case compact_time.Time:
+		goTime, err := t.AsGoTime()
+		return goTime, err == nil
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,52 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package iterator
+
+import (
+	"github.com/kstenerud/go-concise-encoding/events"
+	"github.com/kstenerud/go-concise-encoding/options"
+)
+
+// Sends the events of a named constant's value to eventReceiver, without
+// beginning or ending a document. value is either a go value or an
+// options.CTELiteral.
+//
+// Note: This is a LOW LEVEL API. Error reporting is done via panics. Be sure
+// to recover() at an appropriate location when calling this function.
+func IterateConstantValue(value interface{}, eventReceiver events.DataEventReceiver) {
+	receiver := events.NewDocumentlessEventReceiver(eventReceiver)
+	if literal, ok := value.(options.CTELiteral); ok {
+		if err := events.DecodeLiteral([]byte(literal), receiver); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	// Markers in the constant's value would clash with those of the document
+	// it's being substituted into.
+	opts := options.DefaultIteratorOptions()
+	opts.RecursionSupport = false
+	rootSession.NewIterator(receiver, opts).Iterate(value)
+}
+
+func init() {
+	events.RegisterConstantValueIterator(IterateConstantValue)
+}
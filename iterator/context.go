@@ -21,9 +21,12 @@
 package iterator
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/kstenerud/go-concise-encoding/events"
+	"github.com/kstenerud/go-concise-encoding/options"
 )
 
 // Common function signatures
@@ -34,31 +37,126 @@ type Context struct {
 	// Per-session data
 	GetIteratorForType        GetIteratorForType
 	LowercaseStructFieldNames bool
+	FallbackToJSONTags        bool
+	PolymorphicTypeKey        string
+	PolymorphicIterators      map[reflect.Type]IteratorFunction
 
 	// Per-root-iterator data
 	EventReceiver   events.DataEventReceiver
 	TryAddReference TryAddReference
+	// If not nil, map keys are sorted using this comparator
+	MapKeyComparator options.MapKeyComparator
+	MaxDepth         int
+	// The pointers and containers currently being iterated, outermost first
+	path []reflect.Value
 }
 
 func (_this *Context) NotifyNil() {
 	_this.EventReceiver.OnNA()
 }
 
-func sessionContext(getIteratorFunc GetIteratorForType, lowercaseStructFieldNames bool) Context {
+// Notify that iteration is entering a pointer or container. Panics if this
+// exceeds the maximum depth.
+func (_this *Context) EnterValue(v reflect.Value) {
+	_this.path = append(_this.path, v)
+	if len(_this.path) > _this.MaxDepth {
+		_this.panicDepthExceeded()
+	}
+}
+
+// Notify that iteration is leaving the pointer or container most recently
+// entered.
+func (_this *Context) LeaveValue() {
+	_this.path = _this.path[:len(_this.path)-1]
+}
+
+func (_this *Context) resetPath() {
+	_this.path = _this.path[:0]
+}
+
+// Number of path entries to show at each end of a long path.
+const depthErrorPathEdgeLength = 5
+
+func (_this *Context) panicDepthExceeded() {
+	if start, end := _this.findCycle(); start >= 0 {
+		panic(fmt.Errorf("cyclic data detected (enable RecursionSupport to encode it): %v",
+			describeTypePath(_this.path[start:end+1])))
+	}
+
+	path := _this.path
+	if len(path) > depthErrorPathEdgeLength*2 {
+		panic(fmt.Errorf("exceeded max depth of %v: %v -> ... -> %v", _this.MaxDepth,
+			describeTypePath(path[:depthErrorPathEdgeLength]),
+			describeTypePath(path[len(path)-depthErrorPathEdgeLength:])))
+	}
+	panic(fmt.Errorf("exceeded max depth of %v: %v", _this.MaxDepth, describeTypePath(path)))
+}
+
+// Find a cycle at the end of the path, returning the indices of the first and
+// second visits to the same pointer (or -1, -1 if there's no cycle).
+func (_this *Context) findCycle() (start int, end int) {
+	for end = len(_this.path) - 1; end >= 0; end-- {
+		if lastPointer, ok := pathPointer(_this.path[end]); ok {
+			for start = end - 1; start >= 0; start-- {
+				if pointer, ok := pathPointer(_this.path[start]); ok && pointer == lastPointer &&
+					_this.path[start].Type() == _this.path[end].Type() {
+					return
+				}
+			}
+			break
+		}
+	}
+	return -1, -1
+}
+
+func pathPointer(v reflect.Value) (pointer uintptr, ok bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		return v.Pointer(), true
+	default:
+		return 0, false
+	}
+}
+
+func describeTypePath(path []reflect.Value) string {
+	sb := strings.Builder{}
+	for i, v := range path {
+		if i > 0 {
+			sb.WriteString(" -> ")
+		}
+		sb.WriteString(v.Type().String())
+	}
+	return sb.String()
+}
+
+func sessionContext(getIteratorFunc GetIteratorForType,
+	opts *options.IteratorSessionOptions,
+	polymorphicIterators map[reflect.Type]IteratorFunction) Context {
+
 	return Context{
 		GetIteratorForType:        getIteratorFunc,
-		LowercaseStructFieldNames: lowercaseStructFieldNames,
+		LowercaseStructFieldNames: opts.LowercaseStructFieldNames,
+		FallbackToJSONTags:        opts.FallbackToJSONTags,
+		PolymorphicTypeKey:        opts.PolymorphicTypeKey,
+		PolymorphicIterators:      polymorphicIterators,
 	}
 }
 
 func iteratorContext(sessionContext *Context,
 	eventReceiver events.DataEventReceiver,
-	tryAddReference TryAddReference) Context {
+	tryAddReference TryAddReference,
+	mapKeyComparator options.MapKeyComparator,
+	maxDepth int) Context {
 
 	return Context{
 		GetIteratorForType:        sessionContext.GetIteratorForType,
 		LowercaseStructFieldNames: sessionContext.LowercaseStructFieldNames,
+		FallbackToJSONTags:        sessionContext.FallbackToJSONTags,
+		PolymorphicTypeKey:        sessionContext.PolymorphicTypeKey,
+		PolymorphicIterators:      sessionContext.PolymorphicIterators,
 		EventReceiver:             eventReceiver,
 		TryAddReference:           tryAddReference,
+		MapKeyComparator:          mapKeyComparator,
+		MaxDepth:                  maxDepth,
 	}
 }
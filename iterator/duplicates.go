@@ -0,0 +1,106 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package iterator
+
+import (
+	"reflect"
+
+	"github.com/kstenerud/go-concise-encoding/internal/common"
+
+	"github.com/kstenerud/go-duplicates"
+)
+
+// Find all pointers that are encountered more than once while walking an
+// object. This follows the same walk as duplicates.FindDuplicatePointers, but
+// also visits map keys so that pointers used as keys can be marked and
+// referenced like any other pointer.
+func findDuplicatePointers(object interface{}) map[duplicates.TypedPointer]bool {
+	finder := duplicates.NewDuplicateFinder()
+	scanForDuplicatePointers(finder, reflect.ValueOf(object))
+	return finder.DuplicatePointers
+}
+
+func scanForDuplicatePointers(finder *duplicates.DuplicateFinder, value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+		scanForDuplicatePointers(finder, value.Elem())
+	case reflect.Ptr:
+		if value.IsNil() || finder.RegisterPointer(value) {
+			return
+		}
+		scanForDuplicatePointers(finder, value.Elem())
+	case reflect.Map:
+		if value.IsNil() || value.Len() == 0 || finder.RegisterPointer(value) {
+			return
+		}
+		scanKeys := isDuplicateScannableKind(value.Type().Key().Kind())
+		scanValues := isDuplicateScannableKind(value.Type().Elem().Kind())
+		if !scanKeys && !scanValues {
+			return
+		}
+		iter := common.MapRange(value)
+		for iter.Next() {
+			if scanKeys {
+				scanForDuplicatePointers(finder, iter.Key())
+			}
+			if scanValues {
+				scanForDuplicatePointers(finder, iter.Value())
+			}
+		}
+	case reflect.Slice:
+		if value.IsNil() || value.Len() == 0 || finder.RegisterPointer(value) {
+			return
+		}
+		fallthrough
+	case reflect.Array:
+		if !isDuplicateScannableKind(value.Type().Elem().Kind()) {
+			return
+		}
+		count := value.Len()
+		for i := 0; i < count; i++ {
+			scanForDuplicatePointers(finder, value.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			if field.CanAddr() {
+				field = field.Addr()
+			}
+			if isDuplicateScannableKind(field.Kind()) {
+				scanForDuplicatePointers(finder, field)
+			}
+		}
+	}
+}
+
+const duplicateScannableKinds uint = (uint(1) << reflect.Interface) |
+	(uint(1) << reflect.Ptr) |
+	(uint(1) << reflect.Slice) |
+	(uint(1) << reflect.Map) |
+	(uint(1) << reflect.Array) |
+	(uint(1) << reflect.Struct)
+
+func isDuplicateScannableKind(kind reflect.Kind) bool {
+	return duplicateScannableKinds&(uint(1)<<kind) != 0
+}
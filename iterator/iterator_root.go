@@ -24,6 +24,7 @@ import (
 	"reflect"
 
 	"github.com/kstenerud/go-concise-encoding/events"
+	"github.com/kstenerud/go-concise-encoding/internal/common"
 	"github.com/kstenerud/go-concise-encoding/options"
 
 	"github.com/kstenerud/go-duplicates"
@@ -63,9 +64,18 @@ func (_this *RootObjectIterator) Init(context *Context,
 
 	opts = opts.WithDefaultsApplied()
 	_this.opts = *opts
+	var mapKeyComparator options.MapKeyComparator
+	if opts.SortMapKeys {
+		mapKeyComparator = opts.MapKeyComparator
+		if mapKeyComparator == nil {
+			mapKeyComparator = common.CompareMapKeys
+		}
+	}
 	_this.context = iteratorContext(context,
 		eventReceiver,
-		_this.addReference)
+		_this.addReference,
+		mapKeyComparator,
+		opts.MaxDepth)
 }
 
 // Iterates over an object, sending events to the root iterator's
@@ -74,6 +84,7 @@ func (_this *RootObjectIterator) Init(context *Context,
 // Note: This is a LOW LEVEL API. Error reporting is done via panics. Be sure
 // to recover() at an appropriate location when calling this function.
 func (_this *RootObjectIterator) Iterate(object interface{}) {
+	_this.context.resetPath()
 	_this.context.EventReceiver.OnBeginDocument()
 	_this.context.EventReceiver.OnVersion(_this.opts.ConciseEncodingVersion)
 	if object == nil {
@@ -83,7 +94,7 @@ func (_this *RootObjectIterator) Iterate(object interface{}) {
 	}
 
 	if _this.opts.RecursionSupport {
-		_this.foundReferences = duplicates.FindDuplicatePointers(object)
+		_this.foundReferences = findDuplicatePointers(object)
 		_this.namedReferences = make(map[duplicates.TypedPointer]uint32)
 	}
 
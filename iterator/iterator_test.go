@@ -23,6 +23,7 @@ package iterator
 import (
 	"math/big"
 	"net/url"
+	"reflect"
 	"testing"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 
 	"github.com/kstenerud/go-concise-encoding/internal/common"
 	"github.com/kstenerud/go-concise-encoding/options"
+	"github.com/kstenerud/go-concise-encoding/types"
 
 	"github.com/cockroachdb/apd/v2"
 	"github.com/kstenerud/go-equivalence"
@@ -85,6 +87,29 @@ func TestIterateBasic(t *testing.T) {
 	assertIterate(t, (*url.URL)(nil), NA())
 }
 
+func TestIterateHalfFloat(t *testing.T) {
+	assertIterate(t, types.Float16FromFloat32(1.5), F(1.5))
+	assertIterate(t, types.BFloat16FromFloat32(-2), F(-2))
+	assertIterate(t, []types.BFloat16{types.BFloat16FromFloat32(1), types.BFloat16FromFloat32(1.5)},
+		AF16([]byte{0x80, 0x3f, 0xc0, 0x3f}))
+	assertIterate(t, [1]types.BFloat16{types.BFloat16FromFloat32(1)}, AF16([]byte{0x80, 0x3f}))
+	assertIterate(t, []types.Float16{types.Float16FromFloat32(1), types.Float16FromFloat32(0.1)},
+		AF32([]float32{1, types.Float16FromFloat32(0.1).Float32()}))
+}
+
+func TestIterateUUID(t *testing.T) {
+	uuid := types.UUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	assertIterate(t, uuid, UUID(uuid[:]))
+	assertIterate(t, &uuid, UUID(uuid[:]))
+	assertIterate(t, []types.UUID{uuid}, L(), UUID(uuid[:]), E())
+	assertIterate(t, [16]byte(uuid), AU8(uuid[:]))
+
+	type CustomUUID [16]byte
+	sOpts := options.DefaultIteratorSessionOptions()
+	sOpts.UUIDTypes = []reflect.Type{reflect.TypeOf(CustomUUID{})}
+	assertIterateWithOptions(t, sOpts, options.DefaultIteratorOptions(), CustomUUID(uuid), UUID(uuid[:]))
+}
+
 func TestIterateArrayUint8(t *testing.T) {
 	a := [2]byte{1, 2}
 	assertIterate(t, a, AU8([]byte{1, 2}))
@@ -197,11 +222,13 @@ func TestIterateArrayFloat64(t *testing.T) {
 
 func TestIterateArrayBool(t *testing.T) {
 	a := [2]bool{true, false}
-	assertIterate(t, a, AB(2, []byte{0x02}))
-	assertIterate(t, &a, AB(2, []byte{0x02}))
+	assertIterate(t, a, AB(2, []byte{0x01}))
+	assertIterate(t, &a, AB(2, []byte{0x01}))
 	s := []bool{true, false}
-	assertIterate(t, s, AB(2, []byte{0x02}))
-	assertIterate(t, &s, AB(2, []byte{0x02}))
+	assertIterate(t, s, AB(2, []byte{0x01}))
+	assertIterate(t, &s, AB(2, []byte{0x01}))
+	assertIterate(t, []bool{false, true, true, false, false, false, false, false, true},
+		AB(9, []byte{0x06, 0x01}))
 }
 
 func TestIterateInterface(t *testing.T) {
@@ -296,3 +323,260 @@ func TestIterateTaggedStruct(t *testing.T) {
 
 	assertIterate(t, obj2, M(), S("test"), S("Named should be present"), E())
 }
+
+type IDStruct struct {
+	A int    `ce:"id=1"`
+	B string `ce:"id = 0x10"`
+	C int
+}
+
+func TestIterateStructFieldIDs(t *testing.T) {
+	obj := &IDStruct{
+		A: 100,
+		B: "test",
+		C: 5,
+	}
+
+	assertIterate(t, obj, M(), PI(1), I(100), PI(16), S("test"), S("c"), I(5), E())
+}
+
+type JSONTagStruct struct {
+	Named    string   `json:"the_name"`
+	Omitted  string   `json:"-"`
+	Empty    []string `json:",omitempty"`
+	Stringed float64  `json:"stringed,string"`
+	Both     int      `json:"json_name" ce:"name=ce_name"`
+}
+
+func TestIterateJSONTags(t *testing.T) {
+	obj := &JSONTagStruct{
+		Named:    "a",
+		Omitted:  "b",
+		Stringed: 1.5,
+		Both:     1,
+	}
+
+	sOpts := options.DefaultIteratorSessionOptions()
+	sOpts.FallbackToJSONTags = true
+	iOpts := options.DefaultIteratorOptions()
+	assertIterateWithOptions(t, sOpts, iOpts, obj,
+		M(),
+		S("the_name"), S("a"),
+		S("stringed"), S("1.5"),
+		S("ce_name"), I(1),
+		E())
+
+	assertIterate(t, obj,
+		M(),
+		S("named"), S("a"),
+		S("omitted"), S("b"),
+		S("empty"), NA(),
+		S("stringed"), F(1.5),
+		S("ce_name"), I(1),
+		E())
+}
+
+type OmitEmptyStruct struct {
+	A int    `ce:"omitempty"`
+	B string `ce:"omitempty"`
+	C *int   `ce:"omitempty"`
+}
+
+func TestIterateOmitEmpty(t *testing.T) {
+	assertIterate(t, &OmitEmptyStruct{}, M(), E())
+	assertIterate(t, &OmitEmptyStruct{A: 1, B: "x"}, M(), S("a"), I(1), S("b"), S("x"), E())
+}
+
+type PolyShape interface{}
+
+type PolyCircle struct {
+	Radius int
+}
+
+type PolyRect struct {
+	W, H int
+}
+
+func TestIteratePolymorphic(t *testing.T) {
+	sessionOpts := options.DefaultIteratorSessionOptions()
+	sessionOpts.PolymorphicTypes = map[string]reflect.Type{
+		"circle": reflect.TypeOf(PolyCircle{}),
+		"rect":   reflect.TypeOf(&PolyRect{}),
+	}
+
+	assertIterateWithOptions(t, sessionOpts, nil,
+		[]PolyShape{PolyCircle{Radius: 2}, &PolyRect{W: 1, H: 3}, (*PolyRect)(nil), PolyRect{W: 4}},
+		L(),
+		M(), S("$type"), S("circle"), S("radius"), I(2), E(),
+		M(), S("$type"), S("rect"), S("w"), I(1), S("h"), I(3), E(),
+		NA(),
+		M(), S("w"), I(4), S("h"), I(0), E(),
+		E())
+
+	// Only values stored in interfaces get a discriminator
+	assertIterateWithOptions(t, sessionOpts, nil, PolyCircle{Radius: 2}, M(), S("radius"), I(2), E())
+
+	sessionOpts.PolymorphicTypeKey = "kind"
+	assertIterateWithOptions(t, sessionOpts, nil, []interface{}{PolyCircle{}},
+		L(), M(), S("kind"), S("circle"), S("radius"), I(0), E(), E())
+
+	test.AssertPanics(t, "bad registration", func() {
+		NewSession(nil, nil).RegisterPolymorphicType("int", reflect.TypeOf(1))
+	})
+}
+
+type ConstTimeout int
+
+type ConstConfig struct {
+	Timeout ConstTimeout
+	Retries int
+}
+
+func TestIterateConstants(t *testing.T) {
+	sessionOpts := options.DefaultIteratorSessionOptions()
+	sessionOpts.Constants = options.Constants{
+		"DEFAULT_TIMEOUT": ConstTimeout(30),
+		"NO_TIMEOUT":      ConstTimeout(0),
+	}
+
+	assertIterateWithOptions(t, sessionOpts, nil, ConstConfig{Timeout: 30, Retries: 30},
+		M(), S("timeout"), CONST("DEFAULT_TIMEOUT", false), S("retries"), I(30), E())
+	assertIterateWithOptions(t, sessionOpts, nil, &ConstConfig{Timeout: 10},
+		M(), S("timeout"), I(10), S("retries"), I(0), E())
+	assertIterateWithOptions(t, sessionOpts, nil, map[string]ConstTimeout{"a": 0},
+		M(), S("a"), CONST("NO_TIMEOUT", false), E())
+	assertIterateWithOptions(t, sessionOpts, nil, []interface{}{ConstTimeout(30)},
+		L(), CONST("DEFAULT_TIMEOUT", false), E())
+
+	if err := (&options.IteratorSessionOptions{Constants: options.Constants{"a": []int{1}}}).Validate(); err == nil {
+		t.Errorf("Expected a non-comparable constant to fail validation")
+	}
+	if err := (&options.IteratorSessionOptions{Constants: options.Constants{"a": 1, "b": 1}}).Validate(); err == nil {
+		t.Errorf("Expected constants with the same value to fail validation")
+	}
+	if err := sessionOpts.Validate(); err != nil {
+		t.Error(err)
+	}
+	test.AssertPanics(t, "not comparable", func() {
+		NewSession(nil, &options.IteratorSessionOptions{Constants: options.Constants{"a": []int{1}}})
+	})
+	test.AssertPanics(t, "duplicate value", func() {
+		NewSession(nil, &options.IteratorSessionOptions{Constants: options.Constants{"a": 1, "b": 1}})
+	})
+}
+
+func TestIterateConstantValue(t *testing.T) {
+	receiver := test.NewTEventStore()
+	receiver.Events = receiver.Events[:0]
+	IterateConstantValue([]string{"a", "b"}, receiver)
+	expected := []*test.TEvent{L(), S("a"), S("b"), E()}
+	if !equivalence.IsEquivalent(expected, receiver.Events) {
+		t.Errorf("Expected %v but got %v", expected, receiver.Events)
+	}
+}
+
+func TestIterateNumber(t *testing.T) {
+	assertIterate(t, types.Number("-100"), I(-100))
+	assertIterate(t, types.Number("18446744073709551615"), PI(0xffffffffffffffff))
+	assertIterate(t, types.Number("-100000000000000000000"), BI(NewBigInt("-100000000000000000000", 10)))
+	assertIterate(t, types.Number("1.5"), BDF(NewBDF("1.5")))
+	test.AssertPanics(t, "invalid number", func() {
+		iterateObject(types.Number("x"), test.NewTEventStore(), nil, nil)
+	})
+}
+
+func TestIterateOrderedMap(t *testing.T) {
+	m := types.NewOrderedMap()
+	m.Set("z", 1)
+	m.Set("a", []interface{}{true})
+	m.Set(5, nil)
+	events := []*test.TEvent{M(), S("z"), I(1), S("a"), L(), B(true), E(), I(5), NA(), E()}
+
+	assertIterate(t, m, events...)
+	assertIterate(t, *m, events...)
+	assertIterate(t, (*types.OrderedMap)(nil), NA())
+	assertIterate(t, []interface{}{m}, append(append([]*test.TEvent{L()}, events...), E())...)
+}
+
+func TestIterateSortedMapKeys(t *testing.T) {
+	opts := options.DefaultIteratorOptions()
+	opts.SortMapKeys = true
+	uuid := types.UUID{0xf1, 0xce, 0x4a, 0x71, 0x08, 0x4c, 0x4b, 0x1a, 0x90, 0x7f, 0x21, 0x17, 0x36, 0xc8, 0x55, 0xd4}
+	date := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	m := map[interface{}]interface{}{
+		"b":     1,
+		"a":     2,
+		10:      3,
+		-5:      4,
+		2.5:     5,
+		uuid:    6,
+		date:    7,
+		true:    8,
+		uint(3): 9,
+	}
+	assertIterateWithOptions(t, nil, opts, m,
+		M(), B(true), I(8), I(-5), I(4), F(2.5), I(5), PI(3), I(9), I(10), I(3),
+		S("a"), I(2), S("b"), I(1), UUID(uuid[:]), I(6), GT(date), I(7), E())
+
+	assertIterateWithOptions(t, nil, opts, map[string]int{"c": 1, "a": 2, "b": 3},
+		M(), S("a"), I(2), S("b"), I(3), S("c"), I(1), E())
+
+	opts.MapKeyComparator = func(a, b interface{}) int {
+		return -common.CompareMapKeys(a, b)
+	}
+	assertIterateWithOptions(t, nil, opts, map[string]int{"c": 1, "a": 2, "b": 3},
+		M(), S("c"), I(1), S("b"), I(3), S("a"), I(2), E())
+}
+
+func TestIterateRecurseMapKeysAndArrays(t *testing.T) {
+	opts := options.DefaultIteratorOptions()
+	opts.RecursionSupport = true
+
+	i := 5
+	assertIterateWithOptions(t, nil, opts, []interface{}{&i, map[*int]int{&i: 1}},
+		L(), MARK(), PI(0), I(5), M(), REF(), PI(0), I(1), E(), E())
+
+	obj := &RecursiveStructTestIterate{I: 1}
+	assertIterateWithOptions(t, nil, opts, [2]*RecursiveStructTestIterate{obj, obj},
+		L(), MARK(), PI(0), M(), S("i"), I(1), S("r"), NA(), E(), REF(), PI(0), E())
+}
+
+func TestIterateCycleWithoutRecursionSupport(t *testing.T) {
+	opts := options.DefaultIteratorOptions()
+	opts.RecursionSupport = false
+
+	obj := &RecursiveStructTestIterate{I: 1}
+	obj.R = &RecursiveStructTestIterate{I: 2, R: obj}
+	err := test.ReportPanic(func() {
+		iterateObject(obj, test.NewTEventStore(), nil, opts)
+	})
+	expected := "cyclic data detected (enable RecursionSupport to encode it): " +
+		"*iterator.RecursiveStructTestIterate -> iterator.RecursiveStructTestIterate -> " +
+		"*iterator.RecursiveStructTestIterate -> iterator.RecursiveStructTestIterate -> " +
+		"*iterator.RecursiveStructTestIterate"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error [%v] but got [%v]", expected, err)
+	}
+
+	cyclicSlice := make([]interface{}, 1)
+	cyclicSlice[0] = cyclicSlice
+	test.AssertPanics(t, "cyclic slice", func() {
+		iterateObject(cyclicSlice, test.NewTEventStore(), nil, opts)
+	})
+}
+
+func TestIterateMaxDepth(t *testing.T) {
+	opts := options.DefaultIteratorOptions()
+	opts.MaxDepth = 4
+
+	assertIterateWithOptions(t, nil, opts, [][][][]interface{}{{{{1}}}},
+		L(), L(), L(), L(), I(1), E(), E(), E(), E())
+
+	err := test.ReportPanic(func() {
+		iterateObject([][][][][]interface{}{{{{{1}}}}}, test.NewTEventStore(), nil, opts)
+	})
+	expected := "exceeded max depth of 4: [][][][][]interface {} -> [][][][]interface {} -> [][][]interface {} -> [][]interface {} -> []interface {}"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error [%v] but got [%v]", expected, err)
+	}
+}
@@ -27,6 +27,8 @@ import (
 	"math/big"
 	"net/url"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,6 +36,7 @@ import (
 
 	"github.com/kstenerud/go-concise-encoding/events"
 	"github.com/kstenerud/go-concise-encoding/options"
+	"github.com/kstenerud/go-concise-encoding/types"
 
 	"github.com/cockroachdb/apd/v2"
 	"github.com/kstenerud/go-compact-float"
@@ -114,6 +117,14 @@ func iterateDecimalFloat(context *Context, v reflect.Value) {
 	context.EventReceiver.OnDecimalFloat(v.Interface().(compact_float.DFloat))
 }
 
+func iterateUUID(context *Context, v reflect.Value) {
+	var uuid [16]byte
+	for i := 0; i < len(uuid); i++ {
+		uuid[i] = uint8(v.Index(i).Uint())
+	}
+	context.EventReceiver.OnUUID(uuid[:])
+}
+
 func iterateBool(context *Context, v reflect.Value) {
 	context.EventReceiver.OnBool(v.Bool())
 }
@@ -130,6 +141,35 @@ func iterateFloat(context *Context, v reflect.Value) {
 	context.EventReceiver.OnFloat(v.Float())
 }
 
+func iterateFloat16(context *Context, v reflect.Value) {
+	context.EventReceiver.OnFloat(float64(types.Float16(v.Uint()).Float32()))
+}
+
+func iterateBFloat16(context *Context, v reflect.Value) {
+	context.EventReceiver.OnFloat(float64(types.BFloat16(v.Uint()).Float32()))
+}
+
+func iterateNumber(context *Context, v reflect.Value) {
+	str := v.String()
+	if value, err := strconv.ParseInt(str, 10, 64); err == nil {
+		context.EventReceiver.OnInt(value)
+		return
+	}
+	if value, err := strconv.ParseUint(str, 10, 64); err == nil {
+		context.EventReceiver.OnPositiveInt(value)
+		return
+	}
+	if value, ok := new(big.Int).SetString(str, 10); ok {
+		context.EventReceiver.OnBigInt(value)
+		return
+	}
+	value, _, err := apd.NewFromString(str)
+	if err != nil {
+		panic(fmt.Errorf("%v is not a valid number", str))
+	}
+	context.EventReceiver.OnBigDecimalFloat(value)
+}
+
 func iterateString(context *Context, v reflect.Value) {
 	context.EventReceiver.OnStringlikeArray(events.ArrayTypeString, v.String())
 }
@@ -139,7 +179,10 @@ func iterateInterface(context *Context, v reflect.Value) {
 		context.NotifyNil()
 	} else {
 		elem := v.Elem()
-		iterate := context.GetIteratorForType(elem.Type())
+		iterate, isPolymorphic := context.PolymorphicIterators[elem.Type()]
+		if !isPolymorphic {
+			iterate = context.GetIteratorForType(elem.Type())
+		}
 		iterate(context, elem)
 	}
 }
@@ -155,28 +198,35 @@ func newPointerIterator(ctx *Context, pointerType reflect.Type) IteratorFunction
 		if context.TryAddReference(v) {
 			return
 		}
+		context.EnterValue(v)
 		iterate(context, v.Elem())
+		context.LeaveValue()
 	}
 }
 
 func newSliceOrArrayAsListIterator(ctx *Context, sliceType reflect.Type) IteratorFunction {
 	iterate := ctx.GetIteratorForType(sliceType.Elem())
+	isSlice := sliceType.Kind() == reflect.Slice
 
 	return func(context *Context, v reflect.Value) {
-		if v.IsNil() {
-			context.NotifyNil()
-			return
-		}
-		if context.TryAddReference(v) {
-			return
+		if isSlice {
+			if v.IsNil() {
+				context.NotifyNil()
+				return
+			}
+			if context.TryAddReference(v) {
+				return
+			}
 		}
 
+		context.EnterValue(v)
 		context.EventReceiver.OnList()
 		length := v.Len()
 		for i := 0; i < length; i++ {
 			iterate(context, v.Index(i))
 		}
 		context.EventReceiver.OnEnd()
+		context.LeaveValue()
 	}
 }
 
@@ -193,13 +243,50 @@ func newMapIterator(ctx *Context, mapType reflect.Type) IteratorFunction {
 			return
 		}
 
+		context.EnterValue(v)
 		context.EventReceiver.OnMap()
-		iter := common.MapRange(v)
-		for iter.Next() {
-			iterateKey(context, iter.Key())
-			iterateValue(context, iter.Value())
+		if context.MapKeyComparator != nil {
+			keys := v.MapKeys()
+			sort.SliceStable(keys, func(i, j int) bool {
+				return context.MapKeyComparator(keys[i].Interface(), keys[j].Interface()) < 0
+			})
+			for _, key := range keys {
+				iterateKey(context, key)
+				iterateValue(context, v.MapIndex(key))
+			}
+		} else {
+			iter := common.MapRange(v)
+			for iter.Next() {
+				iterateKey(context, iter.Key())
+				iterateValue(context, iter.Value())
+			}
 		}
 		context.EventReceiver.OnEnd()
+		context.LeaveValue()
+	}
+}
+
+func iterateOrderedMap(context *Context, v reflect.Value) {
+	orderedMap := v.Interface().(types.OrderedMap)
+	context.EnterValue(v)
+	context.EventReceiver.OnMap()
+	for _, entry := range orderedMap.Entries() {
+		iterateInterface(context, reflect.ValueOf(&entry.Key).Elem())
+		iterateInterface(context, reflect.ValueOf(&entry.Value).Elem())
+	}
+	context.EventReceiver.OnEnd()
+	context.LeaveValue()
+}
+
+func newConstantIterator(names map[interface{}]string, iterate IteratorFunction) IteratorFunction {
+	return func(context *Context, v reflect.Value) {
+		if v.CanInterface() {
+			if name, ok := names[v.Interface()]; ok {
+				context.EventReceiver.OnConstant([]byte(name), false)
+				return
+			}
+		}
+		iterate(context, v)
 	}
 }
 
@@ -223,13 +310,26 @@ func newCustomTextIterator(convert options.ConvertToCustomFunction) IteratorFunc
 	}
 }
 
-type structField struct {
-	Name      string
-	Type      reflect.Type
+// A struct field as seen by the iterators (after applying tags and name
+// lowercasing).
+type StructField struct {
+	// The map key that the field is written under (unless HasID is true).
+	Name string
+	Type reflect.Type
+	// The field's index within the struct
 	Index     int
-	Iterate   IteratorFunction
-	Omit      bool
 	OmitEmpty bool
+	// If true, the field is written under the key ID instead of Name.
+	HasID bool
+	ID    uint64
+	// If true, the field is written as a string.
+	AsString bool
+}
+
+type structField struct {
+	StructField
+	Iterate IteratorFunction
+	Omit    bool
 	// TODO: OmitValue
 	OmitValue string
 }
@@ -245,8 +345,8 @@ func (_this *structField) applyTags(tags string) {
 		}
 	}
 
-	for _, entry := range strings.Split(tags, ",") {
-		kv := strings.Split(entry, "=")
+	for _, entry := range common.SplitCETag(tags) {
+		kv := strings.SplitN(entry, "=", 2)
 		switch strings.TrimSpace(kv[0]) {
 		// TODO: lowercase/origcase
 		// TODO: recurse/norecurse?
@@ -261,48 +361,204 @@ func (_this *structField) applyTags(tags string) {
 				_this.OmitValue = strings.TrimSpace(kv[1])
 			}
 		case "omitempty":
-			// TODO: Implement omitempty
 			_this.OmitEmpty = true
 		case "name":
 			requiresValue(kv, "name")
 			_this.Name = strings.TrimSpace(kv[1])
+		case "id":
+			requiresValue(kv, "id")
+			_this.HasID = true
+			_this.ID = parseStructFieldID(kv[1])
+		case "required", "default":
+			// Only used by builders
 		default:
 			panic(fmt.Errorf("%v: Unknown Concise Encoding struct tag field", entry))
 		}
 	}
 }
 
-func newStructIterator(ctx *Context, structType reflect.Type) IteratorFunction {
+// Apply tags in the format used by encoding/json
+func (_this *structField) applyJSONTags(tags string) {
+	if tags == "" {
+		return
+	}
+	if tags == "-" {
+		_this.Omit = true
+		return
+	}
+
+	entries := strings.Split(tags, ",")
+	if entries[0] != "" {
+		_this.Name = entries[0]
+	}
+	for _, entry := range entries[1:] {
+		switch entry {
+		case "omitempty":
+			_this.OmitEmpty = true
+		case "string":
+			_this.AsString = true
+		}
+	}
+}
+
+func parseStructFieldID(value string) uint64 {
+	id, err := strconv.ParseUint(strings.TrimSpace(value), 0, 64)
+	if err != nil {
+		panic(fmt.Errorf(`tag key "id" requires a non-negative integer value, not "%v"`, strings.TrimSpace(value)))
+	}
+	return id
+}
+
+// Get the fields of a struct type that will be iterated, in order.
+func getStructFields(ctx *Context, structType reflect.Type) []structField {
 	fields := make([]structField, 0, structType.NumField())
+	fieldIDs := make(map[uint64]string)
 	for i := 0; i < structType.NumField(); i++ {
 		reflectField := structType.Field(i)
 		if common.IsFieldExported(reflectField.Name) {
-			field := structField{
+			field := structField{StructField: StructField{
 				Name:  reflectField.Name,
 				Type:  reflectField.Type,
 				Index: i,
+			}}
+			if tags, ok := reflectField.Tag.Lookup("ce"); ok || !ctx.FallbackToJSONTags {
+				field.applyTags(tags)
+			} else {
+				field.applyJSONTags(reflectField.Tag.Get("json"))
 			}
-			field.applyTags(reflectField.Tag.Get("ce"))
 			if ctx.LowercaseStructFieldNames {
 				field.Name = common.ASCIIToLower(field.Name)
 			}
 
 			if !field.Omit {
-				field.Iterate = ctx.GetIteratorForType(field.Type)
+				if field.HasID {
+					if existing, exists := fieldIDs[field.ID]; exists {
+						panic(fmt.Errorf("%v: fields %v and %v both have id %v", structType, existing, reflectField.Name, field.ID))
+					}
+					fieldIDs[field.ID] = reflectField.Name
+				}
 				fields = append(fields, field)
 			}
 		}
 	}
 
+	return fields
+}
+
+func newStructIterator(ctx *Context, structType reflect.Type) IteratorFunction {
+	iterateFields := newStructFieldsIterator(ctx, structType)
+
 	return func(context *Context, v reflect.Value) {
+		context.EnterValue(v)
 		context.EventReceiver.OnMap()
+		iterateFields(context, v)
+		context.EventReceiver.OnEnd()
+		context.LeaveValue()
+	}
+}
+
+// Iterates over a struct's fields as map key-value pairs, without beginning
+// or ending the map.
+func newStructFieldsIterator(ctx *Context, structType reflect.Type) IteratorFunction {
+	fields := getStructFields(ctx, structType)
+	for i := range fields {
+		field := &fields[i]
+		field.Iterate = ctx.GetIteratorForType(field.Type)
+		if field.AsString {
+			field.Iterate = newAsStringIterator(field.Type, field.Iterate)
+		}
+	}
 
+	return func(context *Context, v reflect.Value) {
 		for _, field := range fields {
-			context.EventReceiver.OnStringlikeArray(events.ArrayTypeString, field.Name)
-			field.Iterate(context, v.Field(field.Index))
+			fieldValue := v.Field(field.Index)
+			if field.OmitEmpty && isEmptyValue(fieldValue) {
+				continue
+			}
+			if field.HasID {
+				context.EventReceiver.OnPositiveInt(field.ID)
+			} else {
+				context.EventReceiver.OnStringlikeArray(events.ArrayTypeString, field.Name)
+			}
+			field.Iterate(context, fieldValue)
 		}
+	}
+}
 
+// Iterates over a struct (or pointer to struct) stored in an interface,
+// writing a type discriminator as the first map entry so that a builder can
+// determine which concrete type to build.
+func newPolymorphicIterator(ctx *Context, name string, concreteType reflect.Type) IteratorFunction {
+	isPointer := concreteType.Kind() == reflect.Ptr
+	structType := concreteType
+	if isPointer {
+		structType = concreteType.Elem()
+	}
+	iterateFields := newStructFieldsIterator(ctx, structType)
+	typeKey := ctx.PolymorphicTypeKey
+
+	return func(context *Context, v reflect.Value) {
+		if isPointer {
+			if v.IsNil() {
+				context.NotifyNil()
+				return
+			}
+			if context.TryAddReference(v) {
+				return
+			}
+		}
+		context.EnterValue(v)
+		if isPointer {
+			v = v.Elem()
+		}
+		context.EventReceiver.OnMap()
+		context.EventReceiver.OnStringlikeArray(events.ArrayTypeString, typeKey)
+		context.EventReceiver.OnStringlikeArray(events.ArrayTypeString, name)
+		iterateFields(context, v)
 		context.EventReceiver.OnEnd()
+		context.LeaveValue()
+	}
+}
+
+// Returns true if v is a zero value, or an empty map, slice, or string.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// Creates an iterator that writes booleans and numbers as strings. Other
+// types are iterated normally.
+func newAsStringIterator(t reflect.Type, iterate IteratorFunction) IteratorFunction {
+	var format func(v reflect.Value) string
+	switch t.Kind() {
+	case reflect.Bool:
+		format = func(v reflect.Value) string { return strconv.FormatBool(v.Bool()) }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		format = func(v reflect.Value) string { return strconv.FormatInt(v.Int(), 10) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		format = func(v reflect.Value) string { return strconv.FormatUint(v.Uint(), 10) }
+	case reflect.Float32, reflect.Float64:
+		bitSize := t.Bits()
+		format = func(v reflect.Value) string { return strconv.FormatFloat(v.Float(), 'g', -1, bitSize) }
+	default:
+		return iterate
+	}
+
+	return func(context *Context, v reflect.Value) {
+		context.EventReceiver.OnStringlikeArray(events.ArrayTypeString, format(v))
 	}
 }
 
@@ -444,6 +700,32 @@ func iterateSliceOrArrayFloat32(context *Context, v reflect.Value) {
 	context.EventReceiver.OnArray(events.ArrayTypeFloat32, uint64(elementCount), data)
 }
 
+// Float16 elements are widened to float32 because CE float16 arrays are
+// bfloat16, which can't represent all Float16 values.
+func iterateSliceOrArrayFloat16(context *Context, v reflect.Value) {
+	elementCount := v.Len()
+	data := make([]uint8, elementCount*4, elementCount*4)
+	for i := 0; i < elementCount; i++ {
+		elem := math.Float32bits(types.Float16(v.Index(i).Uint()).Float32())
+		data[i*4] = uint8(elem)
+		data[i*4+1] = uint8(elem >> 8)
+		data[i*4+2] = uint8(elem >> 16)
+		data[i*4+3] = uint8(elem >> 24)
+	}
+	context.EventReceiver.OnArray(events.ArrayTypeFloat32, uint64(elementCount), data)
+}
+
+func iterateSliceOrArrayBFloat16(context *Context, v reflect.Value) {
+	elementCount := v.Len()
+	data := make([]uint8, elementCount*2, elementCount*2)
+	for i := 0; i < elementCount; i++ {
+		elem := v.Index(i).Uint()
+		data[i*2] = uint8(elem)
+		data[i*2+1] = uint8(elem >> 8)
+	}
+	context.EventReceiver.OnArray(events.ArrayTypeFloat16, uint64(elementCount), data)
+}
+
 func iterateSliceOrArrayFloat64(context *Context, v reflect.Value) {
 	elementCount := v.Len()
 	data := make([]uint8, elementCount*8, elementCount*8)
@@ -465,29 +747,10 @@ func iterateSliceOrArrayBool(context *Context, v reflect.Value) {
 	elementCount := v.Len()
 	byteCount := common.ElementCountToByteCount(1, uint64(elementCount))
 	data := make([]uint8, byteCount, byteCount)
-	if elementCount == 0 {
-		context.EventReceiver.OnArray(events.ArrayTypeBoolean, uint64(elementCount), data)
-		return
-	}
-
-	nextData := data
-	var nextByte uint8
-	if v.Index(0).Bool() {
-		nextByte = 1
-	}
-
-	for i := 1; i < elementCount; i++ {
-		if i&7 == 0 {
-			nextData[0] = nextByte
-			nextData = nextData[1:]
-		}
-		nextByte <<= 1
+	for i := 0; i < elementCount; i++ {
 		if v.Index(i).Bool() {
-			nextByte |= 1
+			data[i>>3] |= 1 << (i & 7)
 		}
 	}
-	if elementCount&7 != 0 {
-		nextData[0] = nextByte
-	}
 	context.EventReceiver.OnArray(events.ArrayTypeBoolean, uint64(elementCount), data)
 }
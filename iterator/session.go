@@ -36,9 +36,10 @@ import (
 // only in their own session, and don't pollute the base mapping and cause
 // unintended behavior in codec activity elsewhere in the program.
 type Session struct {
-	iteratorFuncs sync.Map
-	opts          options.IteratorSessionOptions
-	context       Context
+	iteratorFuncs        sync.Map
+	polymorphicIterators map[reflect.Type]IteratorFunction
+	opts                 options.IteratorSessionOptions
+	context              Context
 }
 
 // Start a new iterator session. It will inherit the iterators of its parent.
@@ -57,6 +58,9 @@ func NewSession(parent *Session, opts *options.IteratorSessionOptions) *Session
 // If opts is nil, default options will be used.
 func (_this *Session) Init(parent *Session, opts *options.IteratorSessionOptions) {
 	opts = opts.WithDefaultsApplied()
+	if err := opts.Validate(); err != nil {
+		panic(err)
+	}
 	if parent == nil {
 		parent = &rootSession
 	}
@@ -66,7 +70,13 @@ func (_this *Session) Init(parent *Session, opts *options.IteratorSessionOptions
 		return true
 	})
 
+	_this.polymorphicIterators = make(map[reflect.Type]IteratorFunction)
+	for k, v := range parent.polymorphicIterators {
+		_this.polymorphicIterators[k] = v
+	}
+
 	_this.opts = *opts
+	_this.context = sessionContext(_this.GetIteratorForType, &_this.opts, _this.polymorphicIterators)
 
 	for t, converter := range _this.opts.CustomBinaryConverters {
 		_this.RegisterIteratorForType(t, newCustomBinaryIterator(converter))
@@ -74,8 +84,16 @@ func (_this *Session) Init(parent *Session, opts *options.IteratorSessionOptions
 	for t, converter := range _this.opts.CustomTextConverters {
 		_this.RegisterIteratorForType(t, newCustomTextIterator(converter))
 	}
-
-	_this.context = sessionContext(_this.GetIteratorForType, _this.opts.LowercaseStructFieldNames)
+	for _, t := range _this.opts.UUIDTypes {
+		if !common.IsUUIDCompatible(t) {
+			panic(fmt.Errorf("type %v cannot be used as a UUID (must be an array of 16 bytes)", t))
+		}
+		_this.RegisterIteratorForType(t, iterateUUID)
+	}
+	for name, t := range _this.opts.PolymorphicTypes {
+		_this.RegisterPolymorphicType(name, t)
+	}
+	_this.registerConstants(_this.opts.Constants)
 }
 
 // Creates a new iterator that sends data events to eventReceiver.
@@ -91,6 +109,16 @@ func (_this *Session) RegisterIteratorForType(t reflect.Type, iterator IteratorF
 	_this.iteratorFuncs.Store(t, iterator)
 }
 
+// Register a concrete type (a struct or pointer to struct) to be written with
+// a type discriminator of the specified name when it's stored in an interface.
+// Types must be registered before the session is used.
+func (_this *Session) RegisterPolymorphicType(name string, concreteType reflect.Type) {
+	if !common.IsPolymorphicCompatible(concreteType) {
+		panic(fmt.Errorf("type %v cannot be used as a polymorphic type (must be a struct or pointer to struct)", concreteType))
+	}
+	_this.polymorphicIterators[concreteType] = newPolymorphicIterator(&_this.context, name, concreteType)
+}
+
 // Get an iterator template for the specified type. If a registered template
 // doesn't yet exist, a new default template will be generated and registered.
 func (_this *Session) GetIteratorForType(t reflect.Type) IteratorFunction {
@@ -117,6 +145,18 @@ func (_this *Session) GetIteratorForType(t reflect.Type) IteratorFunction {
 	return iterator
 }
 
+// Get the fields of a struct type as this session's iterators see them (after
+// applying tags and name lowercasing), in the order they are iterated.
+// Omitted fields are not included.
+func (_this *Session) GetStructFields(structType reflect.Type) []StructField {
+	fields := getStructFields(&_this.context, structType)
+	result := make([]StructField, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, field.StructField)
+	}
+	return result
+}
+
 // ============================================================================
 // Internal
 
@@ -144,26 +184,66 @@ func init() {
 	}
 }
 
+// Wrap the iterators of all types that have sentinel values so that they emit
+// the sentinel's constant name instead of iterating the value. The constants
+// must have passed options validation.
+func (_this *Session) registerConstants(constants options.Constants) {
+	namesByType := make(map[reflect.Type]map[interface{}]string)
+	for name, value := range constants {
+		t := reflect.TypeOf(value)
+		names := namesByType[t]
+		if names == nil {
+			names = make(map[interface{}]string)
+			namesByType[t] = names
+		}
+		names[value] = name
+	}
+
+	for t, names := range namesByType {
+		_this.RegisterIteratorForType(t, newConstantIterator(names, _this.GetIteratorForType(t)))
+	}
+}
+
 func (_this *Session) getDefaultIteratorForType(t reflect.Type) IteratorFunction {
 	switch t.Kind() {
 	case reflect.Bool:
 		return iterateBool
 	case reflect.String:
+		if t == common.TypeNumber {
+			return iterateNumber
+		}
 		return iterateString
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return iterateInt
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return iterateUint
+		switch t {
+		case common.TypeFloat16:
+			return iterateFloat16
+		case common.TypeBFloat16:
+			return iterateBFloat16
+		default:
+			return iterateUint
+		}
 	case reflect.Float32, reflect.Float64:
 		return iterateFloat
 	case reflect.Interface:
 		return iterateInterface
 	case reflect.Array:
+		if t == common.TypeUUID {
+			return iterateUUID
+		}
 		switch t.Elem().Kind() {
 		case reflect.Uint8:
 			return iterateArrayUint8
 		case reflect.Uint16:
-			return iterateSliceOrArrayUint16
+			switch t.Elem() {
+			case common.TypeFloat16:
+				return iterateSliceOrArrayFloat16
+			case common.TypeBFloat16:
+				return iterateSliceOrArrayBFloat16
+			default:
+				return iterateSliceOrArrayUint16
+			}
 		case reflect.Uint32:
 			return iterateSliceOrArrayUint32
 		case reflect.Uint64:
@@ -194,7 +274,14 @@ func (_this *Session) getDefaultIteratorForType(t reflect.Type) IteratorFunction
 		case reflect.Uint8:
 			return iterateSliceUint8
 		case reflect.Uint16:
-			return iterateSliceOrArrayUint16
+			switch t.Elem() {
+			case common.TypeFloat16:
+				return iterateSliceOrArrayFloat16
+			case common.TypeBFloat16:
+				return iterateSliceOrArrayBFloat16
+			default:
+				return iterateSliceOrArrayUint16
+			}
 		case reflect.Uint32:
 			return iterateSliceOrArrayUint32
 		case reflect.Uint64:
@@ -238,6 +325,8 @@ func (_this *Session) getDefaultIteratorForType(t reflect.Type) IteratorFunction
 			return iterateBigFloat
 		case common.TypeBigDecimalFloat:
 			return iterateBigDecimal
+		case common.TypeOrderedMap:
+			return iterateOrderedMap
 		default:
 			return newStructIterator(&_this.context, t)
 		}
@@ -22,11 +22,19 @@ package concise_encoding
 
 import (
 	"fmt"
+	"math/big"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/kstenerud/go-concise-encoding/builder"
 	"github.com/kstenerud/go-concise-encoding/ce"
 	"github.com/kstenerud/go-concise-encoding/options"
 	"github.com/kstenerud/go-concise-encoding/test"
+
+	"github.com/kstenerud/go-describe"
+	"github.com/kstenerud/go-equivalence"
 )
 
 func TestMarshalUnmarshal(t *testing.T) {
@@ -87,3 +95,522 @@ func TestEmptyListWithIndents(t *testing.T) {
 		t.Errorf("Expected %v but got %v", expected, actual)
 	}
 }
+
+type DefaultsStruct struct {
+	I   int      `ce:"default=100"`
+	F   float64  `ce:"default=-1.5"`
+	S   string   `ce:"default=\"a, \\\"b\\\"\""`
+	B   bool     `ce:"default=@true"`
+	BI  *big.Int `ce:"default=12345678901234567890"`
+	Req int      `ce:"required"`
+}
+
+func TestUnmarshalDefaults(t *testing.T) {
+	result, err := ce.UnmarshalCTEFromDocument([]byte(`c0 {req=1 i=5}`), DefaultsStruct{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &DefaultsStruct{
+		I:   5,
+		F:   -1.5,
+		S:   `a, "b"`,
+		B:   true,
+		BI:  NewBigInt("12345678901234567890", 10),
+		Req: 1,
+	}
+	if !equivalence.IsEquivalent(expected, result) {
+		t.Errorf("Expected %v but got %v", describe.D(expected), describe.D(result))
+	}
+
+	// The iterator must also accept commas inside quoted tag values
+	if _, err = ce.MarshalCTEToDocument(result, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnmarshalMissingRequired(t *testing.T) {
+	_, err := ce.UnmarshalCTEFromDocument([]byte(`c0 {i=5}`), DefaultsStruct{}, nil)
+	if err == nil {
+		t.Errorf("Expected an error due to missing required field")
+	}
+}
+
+type ReferencedDefaultsStruct struct {
+	Any      interface{}
+	Defaults DefaultsStruct
+}
+
+func TestUnmarshalReferencedDefaults(t *testing.T) {
+	// Defaults also apply when a struct is converted from a referenced map.
+	result, err := ce.UnmarshalCTEFromDocument([]byte(`c0 {any=&1:{req=1 i=5} defaults=$1}`), ReferencedDefaultsStruct{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := DefaultsStruct{
+		I:   5,
+		F:   -1.5,
+		S:   `a, "b"`,
+		B:   true,
+		BI:  NewBigInt("12345678901234567890", 10),
+		Req: 1,
+	}
+	if !equivalence.IsEquivalent(expected, result.(*ReferencedDefaultsStruct).Defaults) {
+		t.Errorf("Expected %v but got %v", describe.D(expected), describe.D(result))
+	}
+
+	_, err = ce.UnmarshalCTEFromDocument([]byte(`c0 {any=&1:{i=5} defaults=$1}`), ReferencedDefaultsStruct{}, nil)
+	if err == nil {
+		t.Errorf("Expected an error due to missing required field")
+	}
+}
+
+func TestMarshalUnmarshalBoolArrayBitOrder(t *testing.T) {
+	// Elements are packed low bit first (see events.ArrayTypeBoolean), so the
+	// first element must be the first digit in the document.
+	value := []bool{true, true, false, true, false, false, false, false, false, true}
+	document, err := ce.MarshalCTEToDocument(value, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "c0\n|b 1101000001|"
+	if string(document) != expected {
+		t.Errorf("Expected document [%v] but got [%v]", expected, string(document))
+	}
+}
+
+type ConcatenatedRIDStruct struct {
+	URL  *url.URL
+	Name string
+}
+
+func TestUnmarshalConcatenatedRID(t *testing.T) {
+	result, err := ce.UnmarshalCTEFromDocument([]byte(`c0 {URL=|r http://x.com/|:a Name=|r http://x.com/|:1}`), ConcatenatedRIDStruct{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &ConcatenatedRIDStruct{
+		URL:  test.NewRID("http://x.com/a"),
+		Name: "http://x.com/1",
+	}
+	if !equivalence.IsEquivalent(expected, result) {
+		t.Errorf("Expected %v but got %v", describe.D(expected), describe.D(result))
+	}
+}
+
+type ReferencedDB struct {
+	Host string
+	Port int
+}
+
+type ReferencingConfig struct {
+	Name    string
+	DB      ReferencedDB
+	Servers []string
+	Alias   string
+	Alias2  string
+}
+
+func TestUnmarshalReferencedDocuments(t *testing.T) {
+	opts := options.DefaultCTEUnmarshalerOptions()
+	opts.Builder.ReferenceResolver = builder.NewMemoryReferenceResolver(map[string][]byte{
+		"configs/main.cte":           []byte(`c0 {name="main" db=$|r db.cte| servers=$|r common/servers.cte| alias=&1:"x" alias2=$1}`),
+		"configs/db.cte":             []byte(`c0 {host="localhost" port=5432}`),
+		"configs/common/servers.cte": []byte(`c0 [&1:"a" $1 "b"]`),
+	})
+
+	expected := &ReferencingConfig{
+		Name:    "main",
+		DB:      ReferencedDB{Host: "localhost", Port: 5432},
+		Servers: []string{"a", "a", "b"},
+		Alias:   "x",
+		Alias2:  "x",
+	}
+	result, err := ce.UnmarshalCTEFromDocument([]byte(`c0 $|r configs/main.cte|`), ReferencingConfig{}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equivalence.IsEquivalent(expected, result) {
+		t.Errorf("Expected %v but got %v", describe.D(expected), describe.D(result))
+	}
+
+	if _, err = ce.UnmarshalCTEFromDocument([]byte(`c0 $|r configs/main.cte|`), ReferencingConfig{}, nil); err == nil {
+		t.Errorf("Expected unmarshal without a reference resolver to fail")
+	}
+}
+
+func TestUnmarshalReferencedDocumentsFail(t *testing.T) {
+	opts := options.DefaultCTEUnmarshalerOptions()
+	opts.Builder.ReferenceResolver = builder.NewMemoryReferenceResolver(map[string][]byte{
+		"a.cte":       []byte(`c0 [$|r b.cte|]`),
+		"b.cte":       []byte(`c0 [$|r a.cte|]`),
+		"chain1.cte":  []byte(`c0 [$|r chain2.cte|]`),
+		"chain2.cte":  []byte(`c0 [$|r chain3.cte|]`),
+		"chain3.cte":  []byte(`c0 [1]`),
+		"invalid.cte": []byte(`c0 [1`),
+	})
+	opts.Builder.MaxReferenceDepth = 2
+
+	assertFails := func(document string, expectedMessage string) {
+		_, err := ce.UnmarshalCTEFromDocument([]byte(document), nil, opts)
+		if err == nil {
+			t.Errorf("Expected unmarshal of [%v] to fail", document)
+		} else if !strings.Contains(err.Error(), expectedMessage) {
+			t.Errorf("Expected unmarshal of [%v] to fail with [%v] but got [%v]", document, expectedMessage, err)
+		}
+	}
+	assertFails(`c0 $|r a.cte|`, "cyclic resource ID reference: a.cte -> b.cte -> a.cte")
+	assertFails(`c0 $|r chain1.cte|`, "exceeded max reference depth of 2")
+	assertFails(`c0 $|r invalid.cte|`, "error in referenced document [invalid.cte]")
+	assertFails(`c0 $|r missing.cte|`, "no document registered for resource ID [missing.cte]")
+
+	opts.Builder.MaxReferenceDepth = 3
+	if _, err := ce.UnmarshalCTEFromDocument([]byte(`c0 $|r chain1.cte|`), nil, opts); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnmarshalReferencedDocumentsFanOut(t *testing.T) {
+	// Each document refers to the next one 20 times, which would load
+	// 20^5 documents.
+	documents := map[string][]byte{}
+	for i := 0; i < 5; i++ {
+		document := "c0 [" + strings.Repeat(fmt.Sprintf("$|r %v.cte| ", i+1), 20) + "]"
+		documents[fmt.Sprintf("%v.cte", i)] = []byte(document)
+	}
+	documents["5.cte"] = []byte(`c0 1`)
+	opts := options.DefaultCTEUnmarshalerOptions()
+	opts.Builder.ReferenceResolver = builder.NewMemoryReferenceResolver(documents)
+
+	_, err := ce.UnmarshalCTEFromDocument([]byte(`c0 $|r 0.cte|`), nil, opts)
+	if err == nil || !strings.Contains(err.Error(), "exceeded max referenced document count of 1000") {
+		t.Errorf("Expected referenced document count to be exceeded but got %v", err)
+	}
+
+	opts.Builder.MaxReferencedDocumentCount = 25
+	if _, err = ce.UnmarshalCTEFromDocument([]byte(`c0 $|r 4.cte|`), nil, opts); err != nil {
+		t.Error(err)
+	}
+	opts.Builder.MaxReferencedDocumentByteCount = 50
+	_, err = ce.UnmarshalCTEFromDocument([]byte(`c0 $|r 4.cte|`), nil, opts)
+	if err == nil || !strings.Contains(err.Error(), "exceeded max referenced document byte count of 50") {
+		t.Errorf("Expected referenced document byte count to be exceeded but got %v", err)
+	}
+}
+
+func TestUnmarshalReferencedDocumentsRuleLimits(t *testing.T) {
+	opts := options.DefaultCTEUnmarshalerOptions()
+	opts.Builder.ReferenceResolver = builder.NewMemoryReferenceResolver(map[string][]byte{
+		"a.cte":        []byte(`c0 [1 2]`),
+		"long-str.cte": []byte(`c0 "12345678901"`),
+	})
+	opts.Rules.MaxObjectCount = 7
+	opts.Rules.MaxStringByteLength = 10
+
+	if _, err := ce.UnmarshalCTEFromDocument([]byte(`c0 [1 $|r a.cte|]`), nil, opts); err != nil {
+		t.Error(err)
+	}
+	// Object counts are for the whole build, not for each document.
+	if _, err := ce.UnmarshalCTEFromDocument([]byte(`c0 [1 2 $|r a.cte|]`), nil, opts); err == nil {
+		t.Errorf("Expected max object count to be exceeded")
+	}
+	if _, err := ce.UnmarshalCTEFromDocument([]byte(`c0 $|r long-str.cte|`), nil, opts); err == nil {
+		t.Errorf("Expected max string byte length to be exceeded in the referenced document")
+	}
+}
+
+func TestUnmarshalInconsistentRuleLimits(t *testing.T) {
+	// Default limits follow the limits they depend on
+	opts := options.DefaultCTEUnmarshalerOptions()
+	opts.Rules.MaxObjectCount = 1000000000
+	opts.Rules.MaxStringByteLength = 10
+	if _, err := ce.UnmarshalCTEFromDocument([]byte(`c0 {a=1}`), nil, opts); err != nil {
+		t.Error(err)
+	}
+
+	// Explicit inconsistent limits are reported as errors
+	opts = options.DefaultCTEUnmarshalerOptions()
+	opts.Rules.MaxStringByteLength = 10
+	opts.Rules.MaxKeyByteLength = 20
+	if _, err := ce.UnmarshalCTEFromDocument([]byte(`c0 {a=1}`), nil, opts); err == nil {
+		t.Errorf("Expected max key byte length > max string byte length to be an error")
+	}
+	opts = options.DefaultCTEUnmarshalerOptions()
+	opts.Rules.MaxObjectCount = 100
+	opts.Rules.MaxExpandedObjectCount = 10
+	if _, err := ce.UnmarshalCTEFromDocument([]byte(`c0 {a=1}`), nil, opts); err == nil {
+		t.Errorf("Expected max expanded object count < max object count to be an error")
+	}
+
+	document, err := ce.MarshalCBEToDocument(1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cbeOpts := options.DefaultCBEUnmarshalerOptions()
+	cbeOpts.Rules.MaxObjectCount = 100
+	cbeOpts.Rules.MaxExpandedObjectCount = 10
+	if _, err := ce.UnmarshalCBEFromDocument(document, nil, cbeOpts); err == nil {
+		t.Errorf("Expected max expanded object count < max object count to be an error")
+	}
+}
+
+type ConfigTimeout int
+
+type ConstantConfig struct {
+	Timeout ConfigTimeout
+	Retries int
+}
+
+func TestMarshalUnmarshalConstants(t *testing.T) {
+	marshalOpts := options.DefaultCTEMarshalerOptions()
+	marshalOpts.Session.Constants = options.Constants{"DEFAULT_TIMEOUT": ConfigTimeout(30)}
+	document, err := ce.MarshalCTEToDocument(ConstantConfig{Timeout: 30, Retries: 3}, marshalOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(document), "#DEFAULT_TIMEOUT") {
+		t.Errorf("Expected document [%v] to contain constant #DEFAULT_TIMEOUT", string(document))
+	}
+
+	// Constants can be resolved by either the rules or the builder
+	ruleOpts := options.DefaultCTEUnmarshalerOptions()
+	ruleOpts.Rules.Constants = options.Constants{"DEFAULT_TIMEOUT": options.CTELiteral("30")}
+	builderOpts := options.DefaultCTEUnmarshalerOptions()
+	builderOpts.EnforceRules = false
+	builderOpts.Builder.Constants = options.Constants{"DEFAULT_TIMEOUT": 30}
+
+	expected := &ConstantConfig{Timeout: 30, Retries: 3}
+	for _, opts := range []*options.CTEUnmarshalerOptions{ruleOpts, builderOpts} {
+		result, err := ce.UnmarshalCTEFromDocument(document, ConstantConfig{}, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equivalence.IsEquivalent(expected, result) {
+			t.Errorf("Expected %v but got %v", describe.D(expected), describe.D(result))
+		}
+	}
+
+	if _, err := ce.UnmarshalCTEFromDocument(document, ConstantConfig{}, nil); err == nil {
+		t.Errorf("Expected unmarshal of undefined constant to fail")
+	}
+}
+
+func TestMarshalUnmarshalUUID(t *testing.T) {
+	uuid, err := ce.ParseUUID("123e4567-e89b-12d3-a456-426614174000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertMarshalUnmarshal(t, uuid)
+
+	document, err := ce.MarshalCTEToDocument(uuid, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := ce.UnmarshalCTEFromDocument(document, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != uuid {
+		t.Errorf("Expected %v but got %v", describe.D(uuid), describe.D(result))
+	}
+}
+
+func TestMarshalUnmarshalHalfFloat(t *testing.T) {
+	assertMarshalUnmarshal(t, []ce.Float16{ce.Float16FromFloat32(1), ce.Float16FromFloat32(0.1)})
+	assertMarshalUnmarshal(t, []ce.BFloat16{ce.BFloat16FromFloat32(1), ce.BFloat16FromFloat32(-0.1)})
+	assertMarshalUnmarshal(t, map[string]interface{}{
+		"v": []ce.BFloat16{ce.BFloat16FromFloat32(1), ce.BFloat16FromFloat32(1e30)},
+	})
+}
+
+func TestUnmarshalTypedArraysToInterface(t *testing.T) {
+	document := []byte(`c0 {"a"=|i16 1 -2| "b"=|f64 1.5| "c"=|b 110| "d"=|u32x ff|}`)
+	result, err := ce.UnmarshalCTEFromDocument(document, map[string]interface{}{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"a": []int16{1, -2},
+		"b": []float64{1.5},
+		"c": []bool{true, true, false},
+		"d": []uint32{0xff},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %v but got %v", describe.D(expected), describe.D(result))
+	}
+
+	assertMarshalUnmarshal(t, []bool{true, false, false, true, true, false, true, false, true})
+}
+
+type LosslessStruct struct {
+	Prices []float32
+}
+
+func TestUnmarshalLossyConversionReportsPath(t *testing.T) {
+	opts := options.DefaultCTEUnmarshalerOptions()
+	opts.Builder.AllowLossyFloatConversion = false
+
+	if _, err := ce.UnmarshalCTEFromDocument([]byte(`c0 {Prices=[1.5 0.25]}`), LosslessStruct{}, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ce.UnmarshalCTEFromDocument([]byte(`c0 {Prices=[1.5 0.1]}`), LosslessStruct{}, opts)
+	if err == nil {
+		t.Fatalf("Expected a lossy conversion error")
+	}
+	if !strings.HasPrefix(err.Error(), "Prices[1]: ") {
+		t.Errorf("Expected error to begin with the path Prices[1] but got: %v", err)
+	}
+}
+
+type PolyShape interface {
+	Area() float64
+}
+
+type PolyCircle struct {
+	Radius float64
+}
+
+func (_this PolyCircle) Area() float64 { return _this.Radius * _this.Radius * 3 }
+
+type PolySquare struct {
+	Side float64
+}
+
+func (_this *PolySquare) Area() float64 { return _this.Side * _this.Side }
+
+type PolyPlugin struct {
+	Name   string
+	Shapes []PolyShape
+}
+
+func TestMarshalUnmarshalPolymorphic(t *testing.T) {
+	polymorphicTypes := map[string]reflect.Type{
+		"circle": reflect.TypeOf(PolyCircle{}),
+		"square": reflect.TypeOf(&PolySquare{}),
+	}
+	marshalOpts := options.DefaultCTEMarshalerOptions()
+	marshalOpts.Session.PolymorphicTypes = polymorphicTypes
+	unmarshalOpts := options.DefaultCTEUnmarshalerOptions()
+	unmarshalOpts.Session.PolymorphicTypes = polymorphicTypes
+
+	expected := &PolyPlugin{
+		Name:   "shapes",
+		Shapes: []PolyShape{PolyCircle{Radius: 1.5}, &PolySquare{Side: 2}},
+	}
+	document, err := ce.MarshalCTEToDocument(expected, marshalOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := ce.UnmarshalCTEFromDocument(document, PolyPlugin{}, unmarshalOpts)
+	if err != nil {
+		t.Fatalf("Error unmarshaling [%v]: %v", string(document), err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v but got %v from document [%v]", describe.D(expected), describe.D(actual), string(document))
+	}
+}
+
+func TestMarshalUnmarshalInterfaceTypes(t *testing.T) {
+	unmarshalOpts := options.DefaultCTEUnmarshalerOptions()
+	unmarshalOpts.Builder.InterfaceIntegerType = options.InterfaceIntegerTypeNumber
+	unmarshalOpts.Builder.InterfaceMapType = options.InterfaceMapTypeStringKeys
+
+	expected := map[string]interface{}{
+		"small": ce.Number("-1"),
+		"large": ce.Number("100000000000000000000"),
+		"list":  []interface{}{ce.Number("18446744073709551615")},
+	}
+	document, err := ce.MarshalCTEToDocument(expected, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := ce.UnmarshalCTEFromDocument(document, nil, unmarshalOpts)
+	if err != nil {
+		t.Fatalf("Error unmarshaling [%v]: %v", string(document), err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected %v but got %v from document [%v]", describe.D(expected), describe.D(actual), string(document))
+	}
+}
+
+func TestMarshalUnmarshalOrderedMap(t *testing.T) {
+	unmarshalOpts := options.DefaultCTEUnmarshalerOptions()
+	unmarshalOpts.Builder.InterfaceMapType = options.InterfaceMapTypeOrdered
+
+	document := "c0\n{\n    zeta = 1\n    alpha = {\n        y = 2\n        b = 3\n    }\n    mid = 4\n}"
+	decoded, err := ce.UnmarshalCTEFromDocument([]byte(document), (*ce.OrderedMap)(nil), unmarshalOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := decoded.(*ce.OrderedMap)
+	m.Delete("mid")
+	m.Set("zeta", 10)
+	m.Set("new", 5)
+
+	expected := "c0\n{\n    zeta = 10\n    alpha = {\n        y = 2\n        b = 3\n    }\n    new = 5\n}"
+	actual, err := ce.MarshalCTEToDocument(m, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(actual) != expected {
+		t.Errorf("Expected [%v] but got [%v]", expected, string(actual))
+	}
+}
+
+type GraphNode struct {
+	Value    int
+	Prev     *GraphNode
+	Next     *GraphNode
+	Parent   interface{}
+	Children []*GraphNode
+}
+
+type GraphHolder struct {
+	Head   *GraphNode
+	Tail   *GraphNode
+	Name   *string
+	ByName map[*string]*GraphNode
+}
+
+func TestMarshalUnmarshalSharedPointers(t *testing.T) {
+	name := "head"
+	a := &GraphNode{Value: 1}
+	b := &GraphNode{Value: 2, Prev: a, Parent: a}
+	a.Next = b
+	a.Children = []*GraphNode{b, b}
+	holder := &GraphHolder{Head: a, Tail: b, Name: &name, ByName: map[*string]*GraphNode{&name: a}}
+
+	marshalOpts := options.DefaultCTEMarshalerOptions()
+	marshalOpts.Iterator.RecursionSupport = true
+	document, err := ce.MarshalCTEToDocument(holder, marshalOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ce.UnmarshalCTEFromDocument(document, holder, nil)
+	if err != nil {
+		t.Fatalf("%v\n- While unmarshaling %v", err, string(document))
+	}
+
+	actual := decoded.(*GraphHolder)
+	head, tail := actual.Head, actual.Tail
+	if head.Next != tail || tail.Prev != head || tail.Parent != interface{}(head) {
+		t.Errorf("Expected linked nodes to share pointers in document %v", string(document))
+	}
+	if len(head.Children) != 2 || head.Children[0] != tail || head.Children[1] != tail {
+		t.Errorf("Expected children to share pointers in document %v", string(document))
+	}
+	if actual.ByName[actual.Name] != head {
+		t.Errorf("Expected map key to share a pointer in document %v", string(document))
+	}
+}
+
+func TestMarshalCycleWithoutRecursionSupport(t *testing.T) {
+	node := &GraphNode{Value: 1}
+	node.Next = node
+
+	opts := options.DefaultCTEMarshalerOptions()
+	opts.Iterator.RecursionSupport = false
+	if _, err := ce.MarshalCTEToDocument(node, opts); err == nil {
+		t.Errorf("Expected marshaling cyclic data without recursion support to fail")
+	}
+}
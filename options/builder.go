@@ -49,8 +49,30 @@ type BuilderSessionOptions struct {
 
 	// Build function to use when building from a custom text source.
 	CustomTextBuildFunction CustomBuildFunction
+
+	// Specifies additional types to be built from UUIDs. Each type must be
+	// an array of 16 bytes (ce.UUID is always built from a UUID).
+	UUIDTypes []reflect.Type
+
+	// If true, struct fields that have no "ce" tag will use their "json" tag
+	// instead (if present). The name, "-", "omitempty", and "string" parts of
+	// json tags are recognized.
+	FallbackToJSONTags bool
+
+	// Concrete types (structs or pointers to structs) that can be built into
+	// an interface, mapped by discriminator name. A map whose first entry has
+	// the key PolymorphicTypeKey and a registered name as its value will be
+	// built as that type when the destination is an interface.
+	PolymorphicTypes map[string]reflect.Type
+
+	// The map key that type discriminators are stored under (default "$type").
+	PolymorphicTypeKey string
 }
 
+// The default map key that type discriminators are stored under when
+// encoding or decoding polymorphic types.
+const DefaultPolymorphicTypeKey = "$type"
+
 func DefaultBuilderSessionOptions() *BuilderSessionOptions {
 	return &BuilderSessionOptions{
 		CustomBinaryBuildFunction: func(src []byte, dst reflect.Value) error {
@@ -59,6 +81,7 @@ func DefaultBuilderSessionOptions() *BuilderSessionOptions {
 		CustomTextBuildFunction: func(src []byte, dst reflect.Value) error {
 			return fmt.Errorf("no builder has been registered to handle custom text data")
 		},
+		PolymorphicTypeKey: DefaultPolymorphicTypeKey,
 	}
 }
 
@@ -77,6 +100,9 @@ func (_this *BuilderSessionOptions) WithDefaultsApplied() *BuilderSessionOptions
 	if _this.CustomBuiltTypes == nil {
 		_this.CustomBuiltTypes = []reflect.Type{}
 	}
+	if _this.PolymorphicTypeKey == "" {
+		_this.PolymorphicTypeKey = defaults.PolymorphicTypeKey
+	}
 
 	return _this
 }
@@ -88,6 +114,30 @@ func (_this *BuilderSessionOptions) Validate() error {
 // ============================================================================
 // Builder
 
+// Called when a builder coerces a value of one type into another type
+// (see BuilderOptions.AllowLenientCoercion). path is the location of the
+// coerced value within the object being built (such as "Items[2].Price").
+type CoercionCallback func(path string, value interface{}, dstType reflect.Type)
+
+// Loads the documents that resource ID references refer to, so that they can
+// be built in place of the reference (see BuilderOptions.ReferenceResolver).
+type ReferenceResolver interface {
+	// Fetch the CTE document that resourceID refers to. baseID is the
+	// resolved ID of the document containing the reference (or "" for the
+	// top-level document), which relative resource IDs are resolved against.
+	// resolvedID identifies the fetched document when detecting cycles, and
+	// is the baseID of any references within it.
+	ResolveReference(baseID string, resourceID string) (resolvedID string, document []byte, err error)
+}
+
+// The default maximum depth of nested documents loaded via resource ID
+// references.
+const DefaultMaxReferenceDepth = 16
+
+// The default maximum number of documents loaded via resource ID references
+// while building a single object.
+const DefaultMaxReferencedDocumentCount = 1000
+
 type BuilderOptions struct {
 	// Max base-10 exponent allowed when converting from floating point to big integer.
 	// As exponents get very large, it takes geometrically more CPU to convert.
@@ -99,11 +149,74 @@ type BuilderOptions struct {
 	// Match struct field names in a case insensitive manner
 	CaseInsensitiveStructFieldNames bool
 
-	// TODO: If true, don't raise an error on a lossy floating point conversion.
+	// If true, allow floating point conversions that lose precision (such as
+	// float64 to float32, or a decimal float to a binary float that can't
+	// represent it exactly). If false, such conversions raise an error.
 	AllowLossyFloatConversion bool
 
+	// What to do when an integer source value doesn't fit into the
+	// destination integer type.
+	IntegerOverflowPolicy IntegerOverflowPolicy
+
 	// TODO: If true, don't raise an error on unknown fields
 	IgnoreUnknownFields bool
+
+	// Allow lenient coercions between types that are normally rejected, which
+	// can be useful when reading hand-edited documents:
+	// - Numeric strings into int, uint, and float values
+	// - "true" and "false" strings into bools
+	// - RFC 3339 strings into time.Time
+	// - Single values into one-element slices
+	AllowLenientCoercion bool
+
+	// If not nil, this is called whenever a lenient coercion is applied.
+	CoercionCallback CoercionCallback
+
+	// When building a typed array into an interface{}, produce a
+	// []interface{} rather than a slice of the array's natural element type
+	// (such as []int16 or []float64). Byte arrays are always built as []byte.
+	TypedArraysAsInterfaceSlices bool
+
+	// The type to build integers as when the destination is an interface{}.
+	InterfaceIntegerType InterfaceIntegerType
+
+	// The type to build floating point values as when the destination is an
+	// interface{}.
+	InterfaceFloatType InterfaceFloatType
+
+	// The type to build maps as when the destination is an interface{}.
+	InterfaceMapType InterfaceMapType
+
+	// The type to build times as when the destination is an interface{}.
+	InterfaceTimeType InterfaceTimeType
+
+	// Normalize strings and resource IDs to Unicode Normalization Form C
+	// (NFC) before building them, so that canonically equivalent text builds
+	// to the same value.
+	NormalizeToNFC bool
+
+	// Values to build in place of constants that have no explicit value.
+	// When rules are enforced, RuleOptions.AllowUndefinedConstants must also
+	// be set (or the constants resolved via RuleOptions.Constants instead).
+	Constants Constants
+
+	// Loads the documents referred to by resource ID references, which are
+	// then built in place of the reference. Referenced documents may
+	// themselves contain resource ID references. If nil, resource ID
+	// references cause an error.
+	ReferenceResolver ReferenceResolver
+
+	// The maximum depth of nested documents loaded via resource ID references.
+	MaxReferenceDepth int
+
+	// The maximum number of documents loaded via resource ID references
+	// while building a single object. Each reference is loaded separately,
+	// even if the same document was already loaded.
+	MaxReferencedDocumentCount int
+
+	// The maximum total size in bytes of all documents loaded via resource ID
+	// references while building a single object.
+	MaxReferencedDocumentByteCount uint64
 }
 
 func DefaultBuilderOptions() *BuilderOptions {
@@ -114,6 +227,9 @@ func DefaultBuilderOptions() *BuilderOptions {
 		AllowLossyFloatConversion:       true,
 		IgnoreUnknownFields:             true,
 		CaseInsensitiveStructFieldNames: true,
+		MaxReferenceDepth:               DefaultMaxReferenceDepth,
+		MaxReferencedDocumentCount:      DefaultMaxReferencedDocumentCount,
+		MaxReferencedDocumentByteCount:  DefaultMaxDocumentByteCount,
 	}
 }
 
@@ -122,9 +238,182 @@ func (_this *BuilderOptions) WithDefaultsApplied() *BuilderOptions {
 		return DefaultBuilderOptions()
 	}
 
+	if _this.MaxReferenceDepth < 1 {
+		_this.MaxReferenceDepth = DefaultMaxReferenceDepth
+	}
+	if _this.MaxReferencedDocumentCount < 1 {
+		_this.MaxReferencedDocumentCount = DefaultMaxReferencedDocumentCount
+	}
+	if _this.MaxReferencedDocumentByteCount < 1 {
+		_this.MaxReferencedDocumentByteCount = DefaultMaxDocumentByteCount
+	}
+
 	return _this
 }
 
 func (_this *BuilderOptions) Validate() error {
+	if _this.IntegerOverflowPolicy >= integerOverflowPolicyCount {
+		return fmt.Errorf("%v: unknown integer overflow policy", _this.IntegerOverflowPolicy)
+	}
+	if _this.InterfaceIntegerType >= interfaceIntegerTypeCount {
+		return fmt.Errorf("%v: unknown interface integer type", _this.InterfaceIntegerType)
+	}
+	if _this.InterfaceFloatType >= interfaceFloatTypeCount {
+		return fmt.Errorf("%v: unknown interface float type", _this.InterfaceFloatType)
+	}
+	if _this.InterfaceMapType >= interfaceMapTypeCount {
+		return fmt.Errorf("%v: unknown interface map type", _this.InterfaceMapType)
+	}
+	if _this.InterfaceTimeType >= interfaceTimeTypeCount {
+		return fmt.Errorf("%v: unknown interface time type", _this.InterfaceTimeType)
+	}
 	return nil
 }
+
+type IntegerOverflowPolicy uint8
+
+const (
+	// Raise an error if an integer doesn't fit into the destination type.
+	IntegerOverflowPolicyError IntegerOverflowPolicy = iota
+
+	// Clamp the integer to the destination type's minimum or maximum value.
+	IntegerOverflowPolicySaturate
+	integerOverflowPolicyCount
+)
+
+var integerOverflowPolicyStrings = []string{
+	IntegerOverflowPolicyError:    "IntegerOverflowPolicyError",
+	IntegerOverflowPolicySaturate: "IntegerOverflowPolicySaturate",
+}
+
+func (_this IntegerOverflowPolicy) String() string {
+	if _this < integerOverflowPolicyCount {
+		return integerOverflowPolicyStrings[_this]
+	}
+	return fmt.Sprintf("IntegerOverflowPolicy(%d)", _this)
+}
+
+type InterfaceIntegerType uint8
+
+const (
+	// Build integers as they were decoded: int64 for negative values, uint64
+	// for positive values, and *big.Int for values too large for either.
+	InterfaceIntegerTypeNatural InterfaceIntegerType = iota
+
+	// Build all integers as int64 (see IntegerOverflowPolicy).
+	InterfaceIntegerTypeInt64
+
+	// Build all integers as uint64 (see IntegerOverflowPolicy).
+	InterfaceIntegerTypeUint64
+
+	// Build all integers as *big.Int.
+	InterfaceIntegerTypeBigInt
+
+	// Build all integers as ce.Number, which stores the exact decimal text.
+	InterfaceIntegerTypeNumber
+	interfaceIntegerTypeCount
+)
+
+var interfaceIntegerTypeStrings = []string{
+	InterfaceIntegerTypeNatural: "InterfaceIntegerTypeNatural",
+	InterfaceIntegerTypeInt64:   "InterfaceIntegerTypeInt64",
+	InterfaceIntegerTypeUint64:  "InterfaceIntegerTypeUint64",
+	InterfaceIntegerTypeBigInt:  "InterfaceIntegerTypeBigInt",
+	InterfaceIntegerTypeNumber:  "InterfaceIntegerTypeNumber",
+}
+
+func (_this InterfaceIntegerType) String() string {
+	if _this < interfaceIntegerTypeCount {
+		return interfaceIntegerTypeStrings[_this]
+	}
+	return fmt.Sprintf("InterfaceIntegerType(%d)", _this)
+}
+
+type InterfaceFloatType uint8
+
+const (
+	// Build floats as they were decoded: float64, *big.Float,
+	// compact_float.DFloat, or *apd.Decimal.
+	InterfaceFloatTypeNatural InterfaceFloatType = iota
+
+	// Build all floats as float64 (see AllowLossyFloatConversion).
+	InterfaceFloatTypeFloat64
+
+	// Build all floats as compact_float.DFloat.
+	InterfaceFloatTypeDFloat
+
+	// Build all floats as *apd.Decimal.
+	InterfaceFloatTypeBigDecimal
+	interfaceFloatTypeCount
+)
+
+var interfaceFloatTypeStrings = []string{
+	InterfaceFloatTypeNatural:    "InterfaceFloatTypeNatural",
+	InterfaceFloatTypeFloat64:    "InterfaceFloatTypeFloat64",
+	InterfaceFloatTypeDFloat:     "InterfaceFloatTypeDFloat",
+	InterfaceFloatTypeBigDecimal: "InterfaceFloatTypeBigDecimal",
+}
+
+func (_this InterfaceFloatType) String() string {
+	if _this < interfaceFloatTypeCount {
+		return interfaceFloatTypeStrings[_this]
+	}
+	return fmt.Sprintf("InterfaceFloatType(%d)", _this)
+}
+
+type InterfaceMapType uint8
+
+const (
+	// Build maps as map[interface{}]interface{}.
+	InterfaceMapTypeInterfaceKeys InterfaceMapType = iota
+
+	// Build maps as map[string]interface{}. Maps with non-string keys will
+	// fail to build.
+	InterfaceMapTypeStringKeys
+
+	// Build maps as *ce.OrderedMap, preserving the document's key order.
+	InterfaceMapTypeOrdered
+	interfaceMapTypeCount
+)
+
+var interfaceMapTypeStrings = []string{
+	InterfaceMapTypeInterfaceKeys: "InterfaceMapTypeInterfaceKeys",
+	InterfaceMapTypeStringKeys:    "InterfaceMapTypeStringKeys",
+	InterfaceMapTypeOrdered:       "InterfaceMapTypeOrdered",
+}
+
+func (_this InterfaceMapType) String() string {
+	if _this < interfaceMapTypeCount {
+		return interfaceMapTypeStrings[_this]
+	}
+	return fmt.Sprintf("InterfaceMapType(%d)", _this)
+}
+
+type InterfaceTimeType uint8
+
+const (
+	// Build times as they were decoded: time.Time or compact_time.Time.
+	InterfaceTimeTypeNatural InterfaceTimeType = iota
+
+	// Build all times as time.Time. Times that can't be represented as a
+	// time.Time (such as times with lat/long coordinates) will fail
+	// to build.
+	InterfaceTimeTypeGoTime
+
+	// Build all times as compact_time.Time.
+	InterfaceTimeTypeCompactTime
+	interfaceTimeTypeCount
+)
+
+var interfaceTimeTypeStrings = []string{
+	InterfaceTimeTypeNatural:     "InterfaceTimeTypeNatural",
+	InterfaceTimeTypeGoTime:      "InterfaceTimeTypeGoTime",
+	InterfaceTimeTypeCompactTime: "InterfaceTimeTypeCompactTime",
+}
+
+func (_this InterfaceTimeType) String() string {
+	if _this < interfaceTimeTypeCount {
+		return interfaceTimeTypeStrings[_this]
+	}
+	return fmt.Sprintf("InterfaceTimeType(%d)", _this)
+}
@@ -33,12 +33,17 @@ type CBEDecoderOptions struct {
 
 	// Concise encoding spec version to adhere to. Uses latest if set to 0.
 	ConciseEncodingVersion uint64
+
+	// The maximum number of bytes to read from a document before giving up
+	// with an error.
+	MaxDocumentByteCount uint64
 }
 
 func DefaultCBEDecoderOptions() *CBEDecoderOptions {
 	return &CBEDecoderOptions{
 		BufferSize:             4096,
 		ConciseEncodingVersion: version.ConciseEncodingVersion,
+		MaxDocumentByteCount:   DefaultMaxDocumentByteCount,
 	}
 }
 
@@ -55,6 +60,10 @@ func (_this *CBEDecoderOptions) WithDefaultsApplied() *CBEDecoderOptions {
 		_this.ConciseEncodingVersion = version.ConciseEncodingVersion
 	}
 
+	if _this.MaxDocumentByteCount == 0 {
+		_this.MaxDocumentByteCount = DefaultMaxDocumentByteCount
+	}
+
 	return _this
 }
 
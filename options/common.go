@@ -21,3 +21,16 @@
 // All options that can be used to fine-tune the behavior of various aspects of
 // this library.
 package options
+
+// The default maximum number of bytes that a decoder will read from a
+// document.
+const DefaultMaxDocumentByteCount = 4000000000
+
+// A table of named constants, mapping each constant name (without the
+// leading '#') to its value. A value can be any go value that an iterator can
+// iterate, or a CTELiteral.
+type Constants map[string]interface{}
+
+// A value written in CTE text format, without a version header
+// (such as `[1 2 3]` or `"some string"`).
+type CTELiteral string
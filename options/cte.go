@@ -54,12 +54,17 @@ type CTEDecoderOptions struct {
 
 	// Concise encoding spec version to adhere to. Uses latest if set to 0.
 	ConciseEncodingVersion uint64
+
+	// The maximum number of bytes to read from a document before giving up
+	// with an error.
+	MaxDocumentByteCount uint64
 }
 
 func DefaultCTEDecoderOptions() *CTEDecoderOptions {
 	return &CTEDecoderOptions{
 		BufferSize:             4096,
 		ConciseEncodingVersion: version.ConciseEncodingVersion,
+		MaxDocumentByteCount:   DefaultMaxDocumentByteCount,
 	}
 }
 
@@ -76,6 +81,10 @@ func (_this *CTEDecoderOptions) WithDefaultsApplied() *CTEDecoderOptions {
 		_this.ConciseEncodingVersion = version.ConciseEncodingVersion
 	}
 
+	if _this.MaxDocumentByteCount == 0 {
+		_this.MaxDocumentByteCount = DefaultMaxDocumentByteCount
+	}
+
 	return _this
 }
 
@@ -95,12 +104,28 @@ type CTEEncoderOptions struct {
 	// Indentation to use when pretty printing
 	Indent string
 
-	// TODO: Max column before forcing a newline (if possible)
+	// If nonzero, the column to keep lines within where possible. Lists, maps
+	// and metadata are written on a single line if they fit, and otherwise
+	// have one object per line. Typed arrays are wrapped between elements,
+	// and quoted strings are split at spaces using continuations. Objects
+	// that can't be split (such as long unquoted strings) may still exceed
+	// this column. If 0, lines are never wrapped.
 	MaxColumn uint
 
 	// TODO: Convert line endings to escapes
 	EscapeLineEndings bool
 
+	// If true, map entries are written in sorted key order, regardless of
+	// the order in which they were received. This buffers each map until
+	// it ends.
+	SortMapKeys bool
+
+	// The comparator to sort map keys with when SortMapKeys is true. If nil,
+	// ce.CompareMapKeys is used. Keys are passed as their natural go values
+	// (such as string, int64, uint64, *big.Int, float64, *url.URL,
+	// types.UUID, time.Time, or compact_time.Time).
+	MapKeyComparator MapKeyComparator
+
 	DefaultFormats struct {
 		Int   CTEEncodingFormat
 		Uint  CTEEncodingFormat
@@ -21,7 +21,9 @@
 package options
 
 import (
+	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/kstenerud/go-concise-encoding/version"
 )
@@ -41,6 +43,11 @@ type IteratorSessionOptions struct {
 	// Use lowercase struct field names
 	LowercaseStructFieldNames bool
 
+	// If true, struct fields that have no "ce" tag will use their "json" tag
+	// instead (if present). The name, "-", "omitempty", and "string" parts of
+	// json tags are recognized.
+	FallbackToJSONTags bool
+
 	// Specifies which types to convert to custom binary data, and how to do it.
 	// Note: You should only fill out one of these maps, depending on your
 	// intended encoding (binary or text). The iterator session will consult
@@ -52,6 +59,25 @@ type IteratorSessionOptions struct {
 	// intended encoding (binary or text). The iterator session will consult
 	// the binary map first and the text map second, choosing the first match.
 	CustomTextConverters map[reflect.Type]ConvertToCustomFunction
+
+	// Specifies additional types to be written as UUIDs. Each type must be
+	// an array of 16 bytes (ce.UUID is always written as a UUID).
+	UUIDTypes []reflect.Type
+
+	// Concrete types (structs or pointers to structs) that will be written
+	// with a type discriminator when stored in an interface, mapped by
+	// discriminator name. The discriminator is written as the first entry of
+	// the struct's map, under PolymorphicTypeKey.
+	PolymorphicTypes map[string]reflect.Type
+
+	// The map key to write type discriminators under (default "$type").
+	PolymorphicTypeKey string
+
+	// Sentinel values that will be written as named constants (without an
+	// explicit value) instead of being iterated. A value matches when it has
+	// the same type as the sentinel and compares equal to it, so sentinels
+	// must be comparable. CTELiteral values are not supported here.
+	Constants Constants
 }
 
 func DefaultIteratorSessionOptions() *IteratorSessionOptions {
@@ -59,6 +85,7 @@ func DefaultIteratorSessionOptions() *IteratorSessionOptions {
 		LowercaseStructFieldNames: true,
 		CustomBinaryConverters:    make(map[reflect.Type]ConvertToCustomFunction),
 		CustomTextConverters:      make(map[reflect.Type]ConvertToCustomFunction),
+		PolymorphicTypeKey:        DefaultPolymorphicTypeKey,
 	}
 }
 
@@ -73,17 +100,43 @@ func (_this *IteratorSessionOptions) WithDefaultsApplied() *IteratorSessionOptio
 	if _this.CustomTextConverters == nil {
 		_this.CustomTextConverters = make(map[reflect.Type]ConvertToCustomFunction)
 	}
+	if _this.PolymorphicTypeKey == "" {
+		_this.PolymorphicTypeKey = DefaultPolymorphicTypeKey
+	}
 
 	return _this
 }
 
 func (_this *IteratorSessionOptions) Validate() error {
+	names := make([]string, 0, len(_this.Constants))
+	for name := range _this.Constants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	namesByValue := make(map[interface{}]string)
+	for _, name := range names {
+		value := _this.Constants[name]
+		t := reflect.TypeOf(value)
+		if t == nil || !t.Comparable() {
+			return fmt.Errorf("constant %v: type %v cannot be used as a sentinel (must be comparable)", name, t)
+		}
+		if existing, ok := namesByValue[value]; ok {
+			return fmt.Errorf("constants %v and %v have the same sentinel value %v", existing, name, value)
+		}
+		namesByValue[value] = name
+	}
 	return nil
 }
 
 // ============================================================================
 // Iterator
 
+// Compares two map keys, returning a negative number if a sorts before b, a
+// positive number if a sorts after b, and 0 if they are equal.
+// See ce.CompareMapKeys for the default order.
+type MapKeyComparator func(a, b interface{}) int
+
 type IteratorOptions struct {
 	ConciseEncodingVersion uint64
 
@@ -95,6 +148,22 @@ type IteratorOptions struct {
 
 	// TODO If true, don't write a nil object when a nil pointer is encountered.
 	OmitNilPointers bool
+
+	// If true, go map entries are written in sorted key order so that the
+	// same map always produces the same document (go doesn't define an
+	// iteration order for maps). ce.OrderedMap entries are always written
+	// in their stored order.
+	SortMapKeys bool
+
+	// The comparator to sort map keys with when SortMapKeys is true. If nil,
+	// ce.CompareMapKeys is used.
+	MapKeyComparator MapKeyComparator
+
+	// The maximum depth of nested pointers and containers (slices, arrays,
+	// maps, structs) to follow. Exceeding it fails with an error describing
+	// the path of types, and whether it contains a cycle. This keeps cyclic
+	// data from recursing forever when RecursionSupport is false.
+	MaxDepth int
 }
 
 func DefaultIteratorOptions() *IteratorOptions {
@@ -102,9 +171,14 @@ func DefaultIteratorOptions() *IteratorOptions {
 		ConciseEncodingVersion: version.ConciseEncodingVersion,
 		RecursionSupport:       true,
 		OmitNilPointers:        true,
+		MaxDepth:               DefaultIteratorMaxDepth,
 	}
 }
 
+// The default maximum depth of nested pointers and containers that an
+// iterator will follow.
+const DefaultIteratorMaxDepth = 10000
+
 func (_this *IteratorOptions) WithDefaultsApplied() *IteratorOptions {
 	if _this == nil {
 		return DefaultIteratorOptions()
@@ -113,6 +187,9 @@ func (_this *IteratorOptions) WithDefaultsApplied() *IteratorOptions {
 	if _this.ConciseEncodingVersion < 1 {
 		_this.ConciseEncodingVersion = DefaultIteratorOptions().ConciseEncodingVersion
 	}
+	if _this.MaxDepth < 1 {
+		_this.MaxDepth = DefaultIteratorMaxDepth
+	}
 
 	return _this
 }
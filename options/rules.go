@@ -20,6 +20,8 @@
 
 package options
 
+import "fmt"
+
 // ============================================================================
 // Rules
 
@@ -32,10 +34,34 @@ type RuleOptions struct {
 	MaxObjectCount          uint64
 	MaxReferenceCount       uint64
 
-	// TODO: Max bytes total for all array types
+	// Limits on the size of individual containers. MaxKeyByteLength applies
+	// to map keys, markup names and attribute keys, and metadata keys.
+	MaxListLength       uint64
+	MaxMapEntries       uint64
+	MaxMarkupAttributes uint64
+	MaxKeyByteLength    uint64
+
+	// The maximum number of objects the document would expand to if every
+	// reference were replaced by a copy of its marked object. This protects
+	// against small documents that reference large objects many times.
+	MaxExpandedObjectCount uint64
+
+	// The maximum number of bytes of array data (strings, resource IDs,
+	// custom types, typed arrays, etc) across the entire document. Chunked
+	// arrays are checked as each chunk arrives.
 	MaxTotalArrayBytes uint64
 
 	AllowUndefinedConstants bool
+
+	// Values to substitute for constants that have no explicit value in the
+	// document. Substituted constants are passed on as explicit constants
+	// followed by their value.
+	Constants Constants
+
+	// Resource IDs are normally validated against the IRI reference syntax
+	// of RFC 3987. When relaxed, only invalid UTF-8, control characters and
+	// malformed percent-encodings are rejected.
+	AllowRelaxedResourceIDs bool
 }
 
 func DefaultRuleOptions() *RuleOptions {
@@ -46,8 +72,13 @@ func DefaultRuleOptions() *RuleOptions {
 		MaxContainerDepth:       1000,
 		MaxObjectCount:          10000000,
 		MaxReferenceCount:       100000,
+		MaxExpandedObjectCount:  100000000,
+		MaxTotalArrayBytes:      2000000000,
+		MaxListLength:           1000000,
+		MaxMapEntries:           1000000,
+		MaxMarkupAttributes:     10000,
+		MaxKeyByteLength:        10000,
 		AllowUndefinedConstants: false,
-		// TODO: References need to check for amplification attacks. Keep count of referenced things and their object counts
 	}
 }
 
@@ -81,10 +112,46 @@ func (_this *RuleOptions) WithDefaultsApplied() *RuleOptions {
 	if _this.MaxReferenceCount < 1 {
 		_this.MaxReferenceCount = defaults.MaxReferenceCount
 	}
+	if _this.MaxExpandedObjectCount < 1 {
+		_this.MaxExpandedObjectCount = defaults.MaxExpandedObjectCount
+	}
+	// Only the default follows MaxObjectCount. Explicit values are validated.
+	if _this.MaxExpandedObjectCount == defaults.MaxExpandedObjectCount &&
+		_this.MaxExpandedObjectCount < _this.MaxObjectCount {
+		_this.MaxExpandedObjectCount = _this.MaxObjectCount
+	}
+	if _this.MaxTotalArrayBytes < 1 {
+		_this.MaxTotalArrayBytes = defaults.MaxTotalArrayBytes
+	}
+	if _this.MaxListLength < 1 {
+		_this.MaxListLength = defaults.MaxListLength
+	}
+	if _this.MaxMapEntries < 1 {
+		_this.MaxMapEntries = defaults.MaxMapEntries
+	}
+	if _this.MaxMarkupAttributes < 1 {
+		_this.MaxMarkupAttributes = defaults.MaxMarkupAttributes
+	}
+	if _this.MaxKeyByteLength < 1 {
+		_this.MaxKeyByteLength = defaults.MaxKeyByteLength
+	}
+	// Only the default follows MaxStringByteLength. Explicit values are validated.
+	if _this.MaxKeyByteLength == defaults.MaxKeyByteLength &&
+		_this.MaxKeyByteLength > _this.MaxStringByteLength {
+		_this.MaxKeyByteLength = _this.MaxStringByteLength
+	}
 
 	return _this
 }
 
 func (_this *RuleOptions) Validate() error {
+	if _this.MaxExpandedObjectCount < _this.MaxObjectCount {
+		return fmt.Errorf("max expanded object count (%v) must be at least the max object count (%v)",
+			_this.MaxExpandedObjectCount, _this.MaxObjectCount)
+	}
+	if _this.MaxKeyByteLength > _this.MaxStringByteLength {
+		return fmt.Errorf("max key byte length (%v) must not exceed the max string byte length (%v)",
+			_this.MaxKeyByteLength, _this.MaxStringByteLength)
+	}
 	return nil
 }
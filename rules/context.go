@@ -34,13 +34,52 @@ import (
 type contextStackEntry struct {
 	Rule     EventRule
 	DataType DataType
+	// The ID of the marker applied to this entry's object, if any. Marked
+	// containers need this because other markers can occur inside them.
+	MarkerID interface{}
+	// The number of list elements, map entries or markup attributes so far
+	ElementCount uint64
+}
+
+type markedObject struct {
+	dataType DataType
+	// The number of objects the marked object expands to, including the
+	// objects of any references within it.
+	weight uint64
+	// Every reference to this object so far. If the object's weight grows
+	// (because a forward reference within it was resolved), each of these
+	// references expands to that many more objects.
+	references []referenceSite
+}
+
+// A place in the document where a marked object was referenced.
+type referenceSite struct {
+	// The markers that were open at the reference, and so contain it.
+	openMarkerIDs []interface{}
+}
+
+type forwardReference struct {
+	allowedDataTypes DataType
+	references       []referenceSite
+}
+
+// Counts that the document limits apply to. Documents loaded via resource ID
+// references share the counts of the document that refers to them, so that the
+// limits apply to the whole build.
+type limitCounts struct {
+	objectCount uint64
+	// The object count including the weights of all referenced objects
+	expandedObjectCount uint64
+	// Bytes of array data across the whole document
+	documentArrayByteCount uint64
+	// Number of marked objects
+	referenceCount uint64
 }
 
 type Context struct {
 	opts            options.RuleOptions
 	ExpectedVersion uint64
-
-	objectCount uint64
+	counts          *limitCounts
 
 	// Stack
 	CurrentEntry   contextStackEntry
@@ -60,11 +99,13 @@ type Context struct {
 	ValidateArrayDataFunc  func(data []byte)
 
 	// Marker/Reference
-	currentMarkerID   interface{}
-	markerObjectRule  EventRule
-	markedObjects     map[interface{}]DataType
-	forwardReferences map[interface{}]DataType
-	referenceCount    uint64
+	currentMarkerID  interface{}
+	markerObjectRule EventRule
+	markedObjects    map[interface{}]*markedObject
+	// The markers whose objects haven't completed yet, and their weights so
+	// far
+	openMarkers       map[interface{}]uint64
+	forwardReferences map[interface{}]forwardReference
 }
 
 func (_this *Context) Init(version uint64, opts *options.RuleOptions) {
@@ -72,19 +113,30 @@ func (_this *Context) Init(version uint64, opts *options.RuleOptions) {
 	_this.opts = *opts
 	_this.ExpectedVersion = version
 	_this.stack = make([]contextStackEntry, 0, 16)
+	_this.counts = &limitCounts{}
 	_this.Reset()
 }
 
+// Initialize a context for a document loaded via a resource ID reference from
+// within parent's document. It uses the same options, and shares parent's
+// counts.
+func (_this *Context) InitReferencedDocument(parent *Context) {
+	_this.Init(parent.ExpectedVersion, &parent.opts)
+	_this.counts = parent.counts
+}
+
 func (_this *Context) Reset() {
-	_this.objectCount = 0
+	*_this.counts = limitCounts{}
 	_this.containerDepth = 0
-	_this.referenceCount = 0
 	_this.stack = _this.stack[:0]
 	if _this.markedObjects == nil || len(_this.markedObjects) > 0 {
-		_this.markedObjects = make(map[interface{}]DataType)
+		_this.markedObjects = make(map[interface{}]*markedObject)
+	}
+	if _this.openMarkers == nil || len(_this.openMarkers) > 0 {
+		_this.openMarkers = make(map[interface{}]uint64)
 	}
 	if _this.forwardReferences == nil || len(_this.forwardReferences) > 0 {
-		_this.forwardReferences = make(map[interface{}]DataType)
+		_this.forwardReferences = make(map[interface{}]forwardReference)
 	}
 	_this.stackRule(&beginDocumentRule, DataTypeInvalid)
 }
@@ -114,10 +166,77 @@ func (_this *Context) ParentRule() EventRule {
 }
 
 func (_this *Context) NotifyNewObject() {
-	_this.objectCount++
-	if _this.objectCount > _this.opts.MaxObjectCount {
+	if _this.isInsideConcatenation() {
+		// A concatenation and its parts count as a single object.
+		return
+	}
+	_this.counts.objectCount++
+	if _this.counts.objectCount > _this.opts.MaxObjectCount {
 		panic(fmt.Errorf("Exceeded max object count of %d", _this.opts.MaxObjectCount))
 	}
+	_this.addExpandedObjects(1)
+}
+
+// Add weight to the expanded object count, and to the weights of all
+// currently open markers (which contain the objects being added).
+func (_this *Context) addExpandedObjects(weight uint64) {
+	_this.chargeExpandedObjects(weight)
+	for id, openWeight := range _this.openMarkers {
+		_this.openMarkers[id] = openWeight + weight
+	}
+}
+
+func (_this *Context) chargeExpandedObjects(weight uint64) {
+	if weight > _this.opts.MaxExpandedObjectCount-_this.counts.expandedObjectCount {
+		panic(fmt.Errorf("Exceeded max expanded object count of %d (references expand to too many objects)", _this.opts.MaxExpandedObjectCount))
+	}
+	_this.counts.expandedObjectCount += weight
+}
+
+// Add weight at a reference site that was recorded earlier: to the expanded
+// object count, and to the markers that contain the site. Markers that have
+// already completed grow by weight, and so every reference to them also
+// expands to weight more objects.
+//
+// markersGrowing holds the markers whose growth led here. Reaching one of
+// them again means the references form a cycle, which doesn't expand.
+func (_this *Context) addExpandedObjectsAtSite(weight uint64, site referenceSite, markersGrowing []interface{}) {
+	_this.chargeExpandedObjects(weight)
+	for _, id := range site.openMarkerIDs {
+		if openWeight, isOpen := _this.openMarkers[id]; isOpen {
+			_this.openMarkers[id] = openWeight + weight
+			continue
+		}
+		if containsID(markersGrowing, id) {
+			continue
+		}
+		marked := _this.markedObjects[id]
+		marked.weight += weight
+		growing := append(markersGrowing, id)
+		for _, reference := range marked.references {
+			_this.addExpandedObjectsAtSite(weight, reference, growing[:len(growing):len(growing)])
+		}
+	}
+}
+
+func containsID(ids []interface{}, id interface{}) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (_this *Context) currentReferenceSite() referenceSite {
+	site := referenceSite{}
+	if len(_this.openMarkers) > 0 {
+		site.openMarkerIDs = make([]interface{}, 0, len(_this.openMarkers))
+		for id := range _this.openMarkers {
+			site.openMarkerIDs = append(site.openMarkerIDs, id)
+		}
+	}
+	return site
 }
 
 func (_this *Context) beginContainer(rule EventRule, dataType DataType) {
@@ -147,6 +266,14 @@ func (_this *Context) BeginList() {
 	_this.beginContainer(&listRule, DataTypeAnyType)
 }
 
+func (_this *Context) NotifyListElement() {
+	_this.CurrentEntry.ElementCount++
+	if _this.CurrentEntry.ElementCount > _this.opts.MaxListLength {
+		panic(fmt.Errorf("Exceeded max list length of %d", _this.opts.MaxListLength))
+	}
+	_this.stack[len(_this.stack)-1] = _this.CurrentEntry
+}
+
 func (_this *Context) BeginMap() {
 	_this.beginContainer(&mapKeyRule, DataTypeAnyType)
 }
@@ -164,7 +291,7 @@ func (_this *Context) BeginComment() {
 }
 
 func (_this *Context) assertReferenceCount() {
-	newReferenceCount := _this.referenceCount + 1
+	newReferenceCount := _this.counts.referenceCount + 1
 	if newReferenceCount > _this.opts.MaxReferenceCount {
 		panic(fmt.Errorf("Too many marked objects (%d). Max is %d", newReferenceCount, _this.opts.MaxReferenceCount))
 	}
@@ -181,15 +308,21 @@ func (_this *Context) BeginMarkerAnyType() {
 }
 
 func (_this *Context) BeginMarkedObjectKeyable(id interface{}) {
-	_this.currentMarkerID = id
+	_this.beginMarkedObject(id)
 	_this.changeRule(&markedObjectKeyableRule)
 }
 
 func (_this *Context) BeginMarkedObjectAnyType(id interface{}) {
-	_this.currentMarkerID = id
+	_this.beginMarkedObject(id)
 	_this.changeRule(&markedObjectAnyTypeRule)
 }
 
+func (_this *Context) beginMarkedObject(id interface{}) {
+	_this.currentMarkerID = id
+	_this.CurrentEntry.MarkerID = id
+	_this.openMarkers[id] = 0
+}
+
 func (_this *Context) BeginReferenceKeyable() {
 	_this.stackRule(&referenceKeyableRule, DataTypeKeyable)
 }
@@ -202,11 +335,36 @@ func (_this *Context) BeginTopLevelReference() {
 	_this.stackRule(&tlReferenceRIDRule, DataTypeKeyable)
 }
 
+// Begin a concatenated resource ID, which is made up of a resource ID followed
+// by a suffix, and which completes as a single keyable object.
+func (_this *Context) BeginConcatenate() {
+	_this.stackRule(&concatRIDRule, DataTypeKeyable)
+}
+
+func (_this *Context) SwitchConcatSuffix() {
+	_this.changeRule(&concatSuffixRule)
+}
+
+func (_this *Context) EndConcatenate() {
+	_this.endContainerLike()
+}
+
+func (_this *Context) isInsideConcatenation() bool {
+	switch _this.CurrentEntry.Rule {
+	case &concatRIDRule, &concatSuffixRule:
+		return true
+	default:
+		return false
+	}
+}
+
 func (_this *Context) BeginConstantKeyable(name []byte, explicitValue bool) {
 	if explicitValue {
 		_this.stackRule(&constantKeyableRule, DataTypeKeyable)
 	} else if !_this.opts.AllowUndefinedConstants {
 		panic(fmt.Errorf("Undefined constants are not allowed"))
+	} else {
+		_this.notifyUndefinedConstant()
 	}
 }
 
@@ -215,9 +373,17 @@ func (_this *Context) BeginConstantAnyType(name []byte, explicitValue bool) {
 		_this.stackRule(&constantAnyTypeRule, DataTypeAnyType)
 	} else if !_this.opts.AllowUndefinedConstants {
 		panic(fmt.Errorf("Undefined constants are not allowed"))
+	} else {
+		_this.notifyUndefinedConstant()
 	}
 }
 
+// An undefined constant stands in for a complete object of unknown type.
+func (_this *Context) notifyUndefinedConstant() {
+	_this.NotifyNewObject()
+	_this.CurrentEntry.Rule.OnKeyableObject(_this)
+}
+
 func (_this *Context) SwitchVersion() {
 	_this.changeRule(&versionRule)
 }
@@ -250,6 +416,10 @@ func (_this *Context) SwitchMapKey() {
 }
 
 func (_this *Context) SwitchMapValue() {
+	_this.CurrentEntry.ElementCount++
+	if _this.CurrentEntry.ElementCount > _this.opts.MaxMapEntries {
+		panic(fmt.Errorf("Exceeded max map entry count of %d", _this.opts.MaxMapEntries))
+	}
 	_this.changeRule(&mapValueRule)
 }
 
@@ -270,6 +440,10 @@ func (_this *Context) SwitchMarkupKey() {
 }
 
 func (_this *Context) SwitchMarkupValue() {
+	_this.CurrentEntry.ElementCount++
+	if _this.CurrentEntry.ElementCount > _this.opts.MaxMarkupAttributes {
+		panic(fmt.Errorf("Exceeded max markup attribute count of %d", _this.opts.MaxMarkupAttributes))
+	}
 	_this.changeRule(&markupValueRule)
 }
 
@@ -281,29 +455,56 @@ func (_this *Context) MarkObject(dataType DataType) {
 	if _, exists := _this.markedObjects[_this.currentMarkerID]; exists {
 		panic(fmt.Errorf("Marker ID [%v] already exists", _this.currentMarkerID))
 	}
-	_this.referenceCount++
-	_this.markedObjects[_this.currentMarkerID] = dataType
-	if allowedDataTypes, exists := _this.forwardReferences[_this.currentMarkerID]; exists {
-		delete(_this.forwardReferences, _this.currentMarkerID)
-		if allowedDataTypes&dataType == 0 {
-			panic(fmt.Errorf("Forward reference to marker ID [%v] cannot accept type %v", _this.currentMarkerID, dataType))
+	_this.counts.referenceCount++
+	id := _this.currentMarkerID
+	marked := &markedObject{
+		dataType: dataType,
+		weight:   _this.openMarkers[id],
+	}
+	_this.markedObjects[id] = marked
+	delete(_this.openMarkers, id)
+	if forwardRef, exists := _this.forwardReferences[id]; exists {
+		delete(_this.forwardReferences, id)
+		if forwardRef.allowedDataTypes&dataType == 0 {
+			panic(fmt.Errorf("Forward reference to marker ID [%v] cannot accept type %v", id, dataType))
+		}
+		growing := []interface{}{id}
+		for _, reference := range forwardRef.references {
+			marked.references = append(marked.references, reference)
+			_this.addExpandedObjectsAtSite(marked.weight, reference, growing)
 		}
 	}
 }
 
+// Mark the container that just ended, using the marker ID stored in the
+// current (marked object) stack entry.
+func (_this *Context) MarkContainerObject(dataType DataType) {
+	_this.currentMarkerID = _this.CurrentEntry.MarkerID
+	_this.MarkObject(dataType)
+}
+
+// Reference a marked object, charging its weight to the expanded object count
+// and to every marker containing the reference. The weight of a forward
+// reference is charged once its marked object is complete. References to a
+// marked container from within itself are cycles, and don't expand.
 func (_this *Context) ReferenceObject(id interface{}, allowedDataTypes DataType) {
-	if dataType, exists := _this.markedObjects[id]; exists {
-		if dataType&allowedDataTypes == 0 {
-			panic(fmt.Errorf("Marked object id [%v] of type %v is not a valid type to be referenced here", id, dataType))
+	if marked, exists := _this.markedObjects[id]; exists {
+		if marked.dataType&allowedDataTypes == 0 {
+			panic(fmt.Errorf("Marked object id [%v] of type %v is not a valid type to be referenced here", id, marked.dataType))
 		}
+		marked.references = append(marked.references, _this.currentReferenceSite())
+		_this.addExpandedObjects(marked.weight)
 		return
 	}
 
-	current := _this.forwardReferences[id]
-	if current == 0 {
-		current = allowedDataTypes
+	current, exists := _this.forwardReferences[id]
+	if !exists {
+		current.allowedDataTypes = allowedDataTypes
 	} else {
-		current &= allowedDataTypes
+		current.allowedDataTypes &= allowedDataTypes
+	}
+	if _, isOpen := _this.openMarkers[id]; !isOpen {
+		current.references = append(current.references, _this.currentReferenceSite())
 	}
 	_this.forwardReferences[id] = current
 }
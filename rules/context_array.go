@@ -56,6 +56,16 @@ func (_this *Context) validateArrayTotalByteCount(byteCount uint64, maxByteCount
 func (_this *Context) markUpcomingChunkByteCount(byteCount uint64) {
 	_this.arrayTotalByteCount += byteCount
 	_this.validateArrayTotalByteCount(_this.arrayTotalByteCount, _this.arrayMaxByteCount)
+	_this.addDocumentArrayBytes(byteCount)
+}
+
+// Add to the number of array bytes in the document, panicking if this exceeds
+// the maximum total.
+func (_this *Context) addDocumentArrayBytes(byteCount uint64) {
+	if byteCount > _this.opts.MaxTotalArrayBytes-_this.counts.documentArrayByteCount {
+		panic(fmt.Errorf("Total array bytes in document exceeds maximum of %d", _this.opts.MaxTotalArrayBytes))
+	}
+	_this.counts.documentArrayByteCount += byteCount
 }
 
 func (_this *Context) MarkCompletedChunkByteCount(byteCount uint64) {
@@ -119,7 +129,7 @@ func (_this *Context) BeginArrayAnyType(arrayType events.ArrayType) {
 	case events.ArrayTypeString:
 		_this.beginArray(arrayType, &stringRule, DataTypeKeyable, _this.opts.MaxStringByteLength, _this.ValidateContentsString)
 	case events.ArrayTypeResourceID:
-		_this.beginArray(arrayType, &stringRule, DataTypeKeyable, _this.opts.MaxResourceIDByteLength, _this.ValidateContentsRID)
+		_this.beginArray(arrayType, &stringBuilderRule, DataTypeKeyable, _this.opts.MaxResourceIDByteLength, _this.ValidateContentsRID)
 	case events.ArrayTypeCustomText:
 		_this.beginArray(arrayType, &stringRule, DataTypeAnyType, _this.opts.MaxArrayByteLength, _this.ValidateContentsCustomText)
 	default:
@@ -130,6 +140,9 @@ func (_this *Context) BeginArrayAnyType(arrayType events.ArrayType) {
 func (_this *Context) BeginArrayKeyable(arrayType events.ArrayType) {
 	_this.AssertArrayTypeKeyable(arrayType)
 	_this.BeginArrayAnyType(arrayType)
+	if _this.arrayMaxByteCount > _this.opts.MaxKeyByteLength {
+		_this.arrayMaxByteCount = _this.opts.MaxKeyByteLength
+	}
 }
 
 func (_this *Context) BeginChunkAnyType(elemCount uint64, moreChunksFollow bool) {
@@ -157,14 +170,16 @@ func (_this *Context) BeginArrayString(arrayType events.ArrayType) {
 	_this.beginArray(arrayType, &stringRule, DataTypeKeyable, _this.opts.MaxStringByteLength, _this.ValidateContentsString)
 }
 
+// Resource IDs can only be validated once complete, so chunked resource IDs
+// are built up before validation.
 func (_this *Context) BeginArrayRID(arrayType events.ArrayType) {
 	_this.AssertArrayTypeRID(arrayType)
-	_this.beginArray(arrayType, &stringRule, DataTypeKeyable, _this.opts.MaxResourceIDByteLength, _this.ValidateContentsRID)
+	_this.beginArray(arrayType, &stringBuilderRule, DataTypeKeyable, _this.opts.MaxResourceIDByteLength, _this.ValidateContentsRID)
 }
 
 func (_this *Context) BeginArrayRIDReference(arrayType events.ArrayType) {
 	_this.AssertArrayTypeRID(arrayType)
-	_this.beginArray(arrayType, &stringRule, DataTypeAnyType, _this.opts.MaxResourceIDByteLength, _this.ValidateContentsRID)
+	_this.beginArray(arrayType, &stringBuilderRule, DataTypeAnyType, _this.opts.MaxResourceIDByteLength, _this.ValidateContentsRID)
 }
 
 func (_this *Context) BeginArrayComment(arrayType events.ArrayType) {
@@ -261,11 +276,13 @@ func (_this *Context) ValidateFullArrayStringlike(arrayType events.ArrayType, da
 
 func (_this *Context) ValidateFullArrayStringlikeKeyable(arrayType events.ArrayType, data string) {
 	_this.AssertArrayTypeKeyable(arrayType)
+	_this.ValidateLengthKey(uint64(len(data)))
 	_this.ValidateFullArrayStringlike(arrayType, data)
 }
 
 func (_this *Context) ValidateFullArrayKeyable(arrayType events.ArrayType, elementCount uint64, data []uint8) {
 	_this.AssertArrayTypeKeyable(arrayType)
+	_this.ValidateLengthKey(uint64(len(data)))
 	_this.ValidateFullArrayAnyType(arrayType, elementCount, data)
 }
 
@@ -359,18 +376,27 @@ func (_this *Context) ValidateLengthAnyType(length uint64) {
 	if length > _this.opts.MaxArrayByteLength && _this.opts.MaxArrayByteLength > 0 {
 		panic(fmt.Errorf("Array byte length %d is greater than the maximum of %d", length, _this.opts.MaxArrayByteLength))
 	}
+	_this.addDocumentArrayBytes(length)
 }
 
 func (_this *Context) ValidateLengthString(length uint64) {
 	if length > _this.opts.MaxStringByteLength && _this.opts.MaxStringByteLength > 0 {
 		panic(fmt.Errorf("String byte length %d is greater than the maximum of %d", length, _this.opts.MaxStringByteLength))
 	}
+	_this.addDocumentArrayBytes(length)
 }
 
 func (_this *Context) ValidateLengthRID(length uint64) {
 	if length > _this.opts.MaxResourceIDByteLength && _this.opts.MaxResourceIDByteLength > 0 {
 		panic(fmt.Errorf("Resource ID byte length %d is greater than the maximum of %d", length, _this.opts.MaxResourceIDByteLength))
 	}
+	_this.addDocumentArrayBytes(length)
+}
+
+func (_this *Context) ValidateLengthKey(length uint64) {
+	if length > _this.opts.MaxKeyByteLength {
+		panic(fmt.Errorf("Key byte length %d is greater than the maximum of %d", length, _this.opts.MaxKeyByteLength))
+	}
 }
 
 func (_this *Context) ValidateLengthMarkerID(length uint64) {
@@ -395,24 +421,31 @@ func (_this *Context) ValidateContentsCustomText(contents []byte) {
 	_this.ValidateContentsString(contents)
 }
 
+// Comments and markup contents can contain any printable character plus tab,
+// carriage return and line feed.
 func (_this *Context) ValidateContentsComment(contents []byte) {
-	// TODO: More specific validation
-	_this.ValidateContentsString(contents)
+	_this.ValidateContentsCommentString(string(contents))
 }
 
 func (_this *Context) ValidateContentsCommentString(contents string) {
-	// TODO: More specific validation
 	_this.ValidateContentsStringlike(contents)
+	for _, ch := range contents {
+		if chars.RuneHasProperty(ch, chars.CharIsCommentUnsafe) {
+			panic(fmt.Errorf("Comment or markup contents contain invalid character %U", ch))
+		}
+	}
 }
 
 func (_this *Context) ValidateContentsRID(contents []byte) {
-	// TODO: More specific validation
-	_this.ValidateContentsString(contents)
+	_this.ValidateContentsRIDString(string(contents))
 }
 
 func (_this *Context) ValidateContentsRIDString(contents string) {
-	// TODO: More specific validation
-	_this.ValidateContentsStringlike(contents)
+	if _this.opts.AllowRelaxedResourceIDs {
+		validateRelaxedIRIReference(contents)
+	} else {
+		validateIRIReference(contents)
+	}
 }
 
 func (_this *Context) ValidateContentsMarkerID(contents []byte) {
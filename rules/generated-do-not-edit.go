@@ -100,6 +100,9 @@ func (_this *BeginDocumentRule) OnMarker(ctx *Context) {
 func (_this *BeginDocumentRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *BeginDocumentRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *BeginDocumentRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -184,6 +187,9 @@ func (_this *EndDocumentRule) OnMarker(ctx *Context) {
 func (_this *EndDocumentRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *EndDocumentRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *EndDocumentRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -271,6 +277,9 @@ func (_this *TerminalRule) OnMarker(ctx *Context) {
 func (_this *TerminalRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *TerminalRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *TerminalRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -355,6 +364,9 @@ func (_this *VersionRule) OnMarker(ctx *Context) {
 func (_this *VersionRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *VersionRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *VersionRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -403,6 +415,9 @@ func (_this *NACatRule) OnVersion(ctx *Context, version uint64) {
 func (_this *NACatRule) OnEnd(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow End", _this))
 }
+func (_this *NACatRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *NACatRule) OnArrayChunk(ctx *Context, length uint64, moreChunksFollow bool) {
 	panic(fmt.Errorf("%v does not allow ArrayChunk", _this))
 }
@@ -613,6 +628,9 @@ func (_this *MarkupContentsRule) OnMarker(ctx *Context) {
 func (_this *MarkupContentsRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *MarkupContentsRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *MarkupContentsRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -679,6 +697,9 @@ func (_this *CommentRule) OnMarker(ctx *Context) {
 func (_this *CommentRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *CommentRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *CommentRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -826,6 +847,9 @@ func (_this *ArrayRule) OnMarker(ctx *Context) {
 func (_this *ArrayRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *ArrayRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *ArrayRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -910,6 +934,9 @@ func (_this *ArrayChunkRule) OnMarker(ctx *Context) {
 func (_this *ArrayChunkRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *ArrayChunkRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *ArrayChunkRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -994,6 +1021,9 @@ func (_this *StringRule) OnMarker(ctx *Context) {
 func (_this *StringRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *StringRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *StringRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -1078,6 +1108,9 @@ func (_this *StringChunkRule) OnMarker(ctx *Context) {
 func (_this *StringChunkRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *StringChunkRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *StringChunkRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -1162,6 +1195,9 @@ func (_this *StringBuilderRule) OnMarker(ctx *Context) {
 func (_this *StringBuilderRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *StringBuilderRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *StringBuilderRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -1246,6 +1282,9 @@ func (_this *StringBuilderChunkRule) OnMarker(ctx *Context) {
 func (_this *StringBuilderChunkRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *StringBuilderChunkRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *StringBuilderChunkRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -1315,6 +1354,9 @@ func (_this *MarkerIDKeyableRule) OnMarker(ctx *Context) {
 func (_this *MarkerIDKeyableRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *MarkerIDKeyableRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *MarkerIDKeyableRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -1378,6 +1420,9 @@ func (_this *MarkerIDAnyTypeRule) OnMarker(ctx *Context) {
 func (_this *MarkerIDAnyTypeRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *MarkerIDAnyTypeRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *MarkerIDAnyTypeRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -1510,6 +1555,9 @@ func (_this *ReferenceKeyableRule) OnMarker(ctx *Context) {
 func (_this *ReferenceKeyableRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *ReferenceKeyableRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *ReferenceKeyableRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -1573,6 +1621,9 @@ func (_this *ReferenceAnyTypeRule) OnMarker(ctx *Context) {
 func (_this *ReferenceAnyTypeRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *ReferenceAnyTypeRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *ReferenceAnyTypeRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -1720,6 +1771,9 @@ func (_this *TLReferenceRIDRule) OnMarker(ctx *Context) {
 func (_this *TLReferenceRIDRule) OnReference(ctx *Context) {
 	panic(fmt.Errorf("%v does not allow Reference", _this))
 }
+func (_this *TLReferenceRIDRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
 func (_this *TLReferenceRIDRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	panic(fmt.Errorf("%v does not allow Constant", _this))
 }
@@ -1729,3 +1783,144 @@ func (_this *TLReferenceRIDRule) OnArrayChunk(ctx *Context, length uint64, moreC
 func (_this *TLReferenceRIDRule) OnArrayData(ctx *Context, data []byte) {
 	panic(fmt.Errorf("%v does not allow ArrayData", _this))
 }
+func (_this *ConcatRIDRule) OnBeginDocument(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow BeginDocument", _this))
+}
+func (_this *ConcatRIDRule) OnEndDocument(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow EndDocument", _this))
+}
+func (_this *ConcatRIDRule) OnVersion(ctx *Context, version uint64) {
+	panic(fmt.Errorf("%v does not allow Version", _this))
+}
+func (_this *ConcatRIDRule) OnKeyableObject(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow KeyableObject", _this))
+}
+func (_this *ConcatRIDRule) OnNonKeyableObject(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow NonKeyableObject", _this))
+}
+func (_this *ConcatRIDRule) OnNA(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow NA", _this))
+}
+func (_this *ConcatRIDRule) OnInt(ctx *Context, value int64) {
+	panic(fmt.Errorf("%v does not allow Int", _this))
+}
+func (_this *ConcatRIDRule) OnPositiveInt(ctx *Context, value uint64) {
+	panic(fmt.Errorf("%v does not allow PositiveInt", _this))
+}
+func (_this *ConcatRIDRule) OnBigInt(ctx *Context, value *big.Int) {
+	panic(fmt.Errorf("%v does not allow BigInt", _this))
+}
+func (_this *ConcatRIDRule) OnFloat(ctx *Context, value float64) {
+	panic(fmt.Errorf("%v does not allow Float", _this))
+}
+func (_this *ConcatRIDRule) OnBigFloat(ctx *Context, value *big.Float) {
+	panic(fmt.Errorf("%v does not allow BigFloat", _this))
+}
+func (_this *ConcatRIDRule) OnDecimalFloat(ctx *Context, value compact_float.DFloat) {
+	panic(fmt.Errorf("%v does not allow DecimalFloat", _this))
+}
+func (_this *ConcatRIDRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
+	panic(fmt.Errorf("%v does not allow BigDecimalFloat", _this))
+}
+func (_this *ConcatRIDRule) OnList(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow List", _this))
+}
+func (_this *ConcatRIDRule) OnMap(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Map", _this))
+}
+func (_this *ConcatRIDRule) OnMarkup(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Markup", _this))
+}
+func (_this *ConcatRIDRule) OnMetadata(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Metadata", _this))
+}
+func (_this *ConcatRIDRule) OnComment(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Comment", _this))
+}
+func (_this *ConcatRIDRule) OnEnd(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow End", _this))
+}
+func (_this *ConcatRIDRule) OnMarker(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Marker", _this))
+}
+func (_this *ConcatRIDRule) OnReference(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Reference", _this))
+}
+func (_this *ConcatRIDRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
+func (_this *ConcatRIDRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
+	panic(fmt.Errorf("%v does not allow Constant", _this))
+}
+func (_this *ConcatRIDRule) OnArrayChunk(ctx *Context, length uint64, moreChunksFollow bool) {
+	panic(fmt.Errorf("%v does not allow ArrayChunk", _this))
+}
+func (_this *ConcatRIDRule) OnArrayData(ctx *Context, data []byte) {
+	panic(fmt.Errorf("%v does not allow ArrayData", _this))
+}
+func (_this *ConcatSuffixRule) OnBeginDocument(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow BeginDocument", _this))
+}
+func (_this *ConcatSuffixRule) OnEndDocument(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow EndDocument", _this))
+}
+func (_this *ConcatSuffixRule) OnVersion(ctx *Context, version uint64) {
+	panic(fmt.Errorf("%v does not allow Version", _this))
+}
+func (_this *ConcatSuffixRule) OnKeyableObject(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow KeyableObject", _this))
+}
+func (_this *ConcatSuffixRule) OnNonKeyableObject(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow NonKeyableObject", _this))
+}
+func (_this *ConcatSuffixRule) OnNA(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow NA", _this))
+}
+func (_this *ConcatSuffixRule) OnFloat(ctx *Context, value float64) {
+	panic(fmt.Errorf("%v does not allow Float", _this))
+}
+func (_this *ConcatSuffixRule) OnBigFloat(ctx *Context, value *big.Float) {
+	panic(fmt.Errorf("%v does not allow BigFloat", _this))
+}
+func (_this *ConcatSuffixRule) OnDecimalFloat(ctx *Context, value compact_float.DFloat) {
+	panic(fmt.Errorf("%v does not allow DecimalFloat", _this))
+}
+func (_this *ConcatSuffixRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
+	panic(fmt.Errorf("%v does not allow BigDecimalFloat", _this))
+}
+func (_this *ConcatSuffixRule) OnList(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow List", _this))
+}
+func (_this *ConcatSuffixRule) OnMap(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Map", _this))
+}
+func (_this *ConcatSuffixRule) OnMarkup(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Markup", _this))
+}
+func (_this *ConcatSuffixRule) OnMetadata(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Metadata", _this))
+}
+func (_this *ConcatSuffixRule) OnComment(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Comment", _this))
+}
+func (_this *ConcatSuffixRule) OnEnd(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow End", _this))
+}
+func (_this *ConcatSuffixRule) OnMarker(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Marker", _this))
+}
+func (_this *ConcatSuffixRule) OnReference(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Reference", _this))
+}
+func (_this *ConcatSuffixRule) OnConcatenate(ctx *Context) {
+	panic(fmt.Errorf("%v does not allow Concatenate", _this))
+}
+func (_this *ConcatSuffixRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
+	panic(fmt.Errorf("%v does not allow Constant", _this))
+}
+func (_this *ConcatSuffixRule) OnArrayChunk(ctx *Context, length uint64, moreChunksFollow bool) {
+	panic(fmt.Errorf("%v does not allow ArrayChunk", _this))
+}
+func (_this *ConcatSuffixRule) OnArrayData(ctx *Context, data []byte) {
+	panic(fmt.Errorf("%v does not allow ArrayData", _this))
+}
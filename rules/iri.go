@@ -0,0 +1,249 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package rules
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validates an IRI reference (an IRI or a relative reference) according to
+// the syntax in RFC 3987, panicking if it is invalid.
+func validateIRIReference(iri string) {
+	if !utf8.ValidString(iri) {
+		panic(fmt.Errorf("Resource ID is not valid UTF-8: %v", iri))
+	}
+
+	remaining := iri
+	if index := strings.IndexByte(remaining, '#'); index >= 0 {
+		validateIRIComponent(iri, "fragment", remaining[index+1:], isIRIFragmentChar)
+		remaining = remaining[:index]
+	}
+	if index := strings.IndexByte(remaining, '?'); index >= 0 {
+		validateIRIComponent(iri, "query", remaining[index+1:], isIRIQueryChar)
+		remaining = remaining[:index]
+	}
+
+	// A colon before any slash must end a scheme, since a relative path's
+	// first segment can't contain a colon.
+	if index := strings.IndexAny(remaining, ":/"); index >= 0 && remaining[index] == ':' {
+		validateIRIScheme(iri, remaining[:index])
+		remaining = remaining[index+1:]
+	}
+
+	if strings.HasPrefix(remaining, "//") {
+		remaining = remaining[2:]
+		pathStart := strings.IndexByte(remaining, '/')
+		if pathStart < 0 {
+			pathStart = len(remaining)
+		}
+		validateIRIAuthority(iri, remaining[:pathStart])
+		remaining = remaining[pathStart:]
+	}
+
+	validateIRIComponent(iri, "path", remaining, isIRIPathChar)
+}
+
+// Validates a resource ID using only the checks that an IRI parser can't
+// recover from: UTF-8 validity, control characters and percent-encoding.
+func validateRelaxedIRIReference(iri string) {
+	if !utf8.ValidString(iri) {
+		panic(fmt.Errorf("Resource ID is not valid UTF-8: %v", iri))
+	}
+	validateIRIComponent(iri, "resource ID", iri, func(ch rune) bool {
+		return !isIRIControlChar(ch)
+	})
+}
+
+func validateIRIScheme(iri string, scheme string) {
+	if len(scheme) == 0 {
+		panic(fmt.Errorf("Resource ID [%v] has an empty scheme", iri))
+	}
+	for i := 0; i < len(scheme); i++ {
+		ch := scheme[i]
+		switch {
+		case isASCIIAlpha(ch):
+		case i > 0 && (isASCIIDigit(ch) || ch == '+' || ch == '-' || ch == '.'):
+		default:
+			panic(fmt.Errorf("Resource ID [%v] has an invalid scheme [%v]", iri, scheme))
+		}
+	}
+}
+
+func validateIRIAuthority(iri string, authority string) {
+	if index := strings.IndexByte(authority, '@'); index >= 0 {
+		validateIRIComponent(iri, "user info", authority[:index], isIRIUserInfoChar)
+		authority = authority[index+1:]
+	}
+
+	host := authority
+	port := ""
+	if strings.HasPrefix(authority, "[") {
+		end := strings.IndexByte(authority, ']')
+		if end < 0 {
+			panic(fmt.Errorf("Resource ID [%v] has an unterminated IP literal", iri))
+		}
+		validateIRIIPLiteral(iri, authority[1:end])
+		host = ""
+		port = authority[end+1:]
+		if len(port) > 0 {
+			if port[0] != ':' {
+				panic(fmt.Errorf("Resource ID [%v] has unexpected characters after its IP literal", iri))
+			}
+			port = port[1:]
+		}
+	} else if index := strings.LastIndexByte(authority, ':'); index >= 0 {
+		host = authority[:index]
+		port = authority[index+1:]
+	}
+
+	validateIRIComponent(iri, "host", host, isIRIRegNameChar)
+	for i := 0; i < len(port); i++ {
+		if !isASCIIDigit(port[i]) {
+			panic(fmt.Errorf("Resource ID [%v] has an invalid port [%v]", iri, port))
+		}
+	}
+}
+
+func validateIRIIPLiteral(iri string, literal string) {
+	if len(literal) > 0 && (literal[0] == 'v' || literal[0] == 'V') {
+		// IPvFuture = "v" 1*HEXDIG "." 1*( unreserved / sub-delims / ":" )
+		dot := strings.IndexByte(literal, '.')
+		if dot < 2 || dot == len(literal)-1 {
+			panic(fmt.Errorf("Resource ID [%v] has an invalid IP literal [%v]", iri, literal))
+		}
+		for i := 1; i < dot; i++ {
+			if !isASCIIHexDigit(literal[i]) {
+				panic(fmt.Errorf("Resource ID [%v] has an invalid IP literal [%v]", iri, literal))
+			}
+		}
+		for i := dot + 1; i < len(literal); i++ {
+			ch := rune(literal[i])
+			if !isIRIUnreservedASCII(ch) && !isIRISubDelim(ch) && ch != ':' {
+				panic(fmt.Errorf("Resource ID [%v] has an invalid IP literal [%v]", iri, literal))
+			}
+		}
+		return
+	}
+
+	if ip := net.ParseIP(literal); ip == nil || strings.IndexByte(literal, ':') < 0 {
+		panic(fmt.Errorf("Resource ID [%v] has an invalid IPv6 address [%v]", iri, literal))
+	}
+}
+
+// Validates that every character of an IRI component is allowed, and that all
+// percent-encodings are well formed.
+func validateIRIComponent(iri string, componentName string, component string, isAllowed func(rune) bool) {
+	for i := 0; i < len(component); {
+		ch, size := utf8.DecodeRuneInString(component[i:])
+		if ch == '%' {
+			if i+2 >= len(component) || !isASCIIHexDigit(component[i+1]) || !isASCIIHexDigit(component[i+2]) {
+				panic(fmt.Errorf("Resource ID [%v] has an invalid percent-encoding in its %v", iri, componentName))
+			}
+			i += 3
+			continue
+		}
+		if !isAllowed(ch) {
+			panic(fmt.Errorf("Resource ID [%v] contains invalid character %q in its %v", iri, ch, componentName))
+		}
+		i += size
+	}
+}
+
+func isASCIIAlpha(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isASCIIDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isASCIIHexDigit(ch byte) bool {
+	return isASCIIDigit(ch) || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
+
+func isIRIControlChar(ch rune) bool {
+	return ch < 0x20 || (ch >= 0x7f && ch <= 0x9f)
+}
+
+func isIRIUnreservedASCII(ch rune) bool {
+	return ch < 0x80 && (isASCIIAlpha(byte(ch)) || isASCIIDigit(byte(ch)) ||
+		ch == '-' || ch == '.' || ch == '_' || ch == '~')
+}
+
+func isIRISubDelim(ch rune) bool {
+	switch ch {
+	case '!', '$', '&', '\'', '(', ')', '*', '+', ',', ';', '=':
+		return true
+	}
+	return false
+}
+
+// ucschar from RFC 3987
+func isIRIUCSChar(ch rune) bool {
+	switch {
+	case ch >= 0xa0 && ch <= 0xd7ff,
+		ch >= 0xf900 && ch <= 0xfdcf,
+		ch >= 0xfdf0 && ch <= 0xffef:
+		return true
+	case ch >= 0x10000 && ch <= 0xefffd:
+		// All planes from 1 to 14, except for the last two code points in each
+		return ch&0xfffe != 0xfffe
+	}
+	return false
+}
+
+// iprivate from RFC 3987
+func isIRIPrivateChar(ch rune) bool {
+	return (ch >= 0xe000 && ch <= 0xf8ff) ||
+		(ch >= 0xf0000 && ch <= 0xffffd) ||
+		(ch >= 0x100000 && ch <= 0x10fffd)
+}
+
+func isIRIUnreserved(ch rune) bool {
+	return isIRIUnreservedASCII(ch) || isIRIUCSChar(ch)
+}
+
+func isIRIRegNameChar(ch rune) bool {
+	return isIRIUnreserved(ch) || isIRISubDelim(ch)
+}
+
+func isIRIUserInfoChar(ch rune) bool {
+	return isIRIRegNameChar(ch) || ch == ':'
+}
+
+func isIRIPChar(ch rune) bool {
+	return isIRIUserInfoChar(ch) || ch == '@'
+}
+
+func isIRIPathChar(ch rune) bool {
+	return isIRIPChar(ch) || ch == '/'
+}
+
+func isIRIQueryChar(ch rune) bool {
+	return isIRIPathChar(ch) || ch == '?' || isIRIPrivateChar(ch)
+}
+
+func isIRIFragmentChar(ch rune) bool {
+	return isIRIPathChar(ch) || ch == '?'
+}
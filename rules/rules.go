@@ -58,6 +58,7 @@ type EventRule interface {
 	OnEnd(ctx *Context)
 	OnMarker(ctx *Context)
 	OnReference(ctx *Context)
+	OnConcatenate(ctx *Context)
 	OnConstant(ctx *Context, name []byte, explicitValue bool)
 	OnArray(ctx *Context, arrayType events.ArrayType, elementCount uint64, data []uint8)
 	OnStringlikeArray(ctx *Context, arrayType events.ArrayType, data string)
@@ -114,6 +115,8 @@ var (
 	constantKeyableRule     ConstantKeyableRule
 	constantAnyTypeRule     ConstantAnyTypeRule
 	tlReferenceRIDRule      TLReferenceRIDRule
+	concatRIDRule           ConcatRIDRule
+	concatSuffixRule        ConcatSuffixRule
 	stringBuilderRule       StringBuilderRule
 	stringBuilderChunkRule  StringBuilderChunkRule
 )
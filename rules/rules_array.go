@@ -87,13 +87,6 @@ type StringBuilderRule struct{}
 
 func (_this *StringBuilderRule) String() string { return "String Builder Rule" }
 func (_this *StringBuilderRule) OnArrayChunk(ctx *Context, length uint64, moreChunksFollow bool) {
-	if length == 0 {
-		if !moreChunksFollow {
-			ctx.endArray()
-		}
-		return
-	}
-
 	ctx.BeginChunkStringBuilder(length, moreChunksFollow)
 }
 
@@ -106,6 +99,6 @@ func (_this *StringBuilderChunkRule) OnArrayData(ctx *Context, data []byte) {
 	ctx.MarkCompletedChunkByteCount(uint64(len(data)))
 	ctx.AddBuiltArrayBytes(data)
 	if ctx.chunkActualByteCount == ctx.chunkExpectedByteCount {
-		ctx.EndChunkString()
+		ctx.EndChunkStringBuilder()
 	}
 }
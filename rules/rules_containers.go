@@ -33,35 +33,47 @@ import (
 
 type ListRule struct{}
 
-func (_this *ListRule) String() string                                          { return "List Rule" }
-func (_this *ListRule) OnKeyableObject(ctx *Context)                            { /* Nothing to do */ }
-func (_this *ListRule) OnNonKeyableObject(ctx *Context)                         { /* Nothing to do */ }
-func (_this *ListRule) OnNA(ctx *Context)                                       { /* Nothing to do */ }
-func (_this *ListRule) OnChildContainerEnded(ctx *Context, _ DataType)          { /* Nothing to do */ }
-func (_this *ListRule) OnPadding(ctx *Context)                                  { /* Nothing to do */ }
-func (_this *ListRule) OnInt(ctx *Context, value int64)                         { /* Nothing to do */ }
-func (_this *ListRule) OnPositiveInt(ctx *Context, value uint64)                { /* Nothing to do */ }
-func (_this *ListRule) OnBigInt(ctx *Context, value *big.Int)                   { /* Nothing to do */ }
-func (_this *ListRule) OnFloat(ctx *Context, value float64)                     { /* Nothing to do */ }
-func (_this *ListRule) OnBigFloat(ctx *Context, value *big.Float)               { /* Nothing to do */ }
-func (_this *ListRule) OnDecimalFloat(ctx *Context, value compact_float.DFloat) { /* Nothing to do */ }
-func (_this *ListRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal)      { /* Nothing to do */ }
-func (_this *ListRule) OnList(ctx *Context)                                     { ctx.BeginList() }
-func (_this *ListRule) OnMap(ctx *Context)                                      { ctx.BeginMap() }
-func (_this *ListRule) OnMarkup(ctx *Context)                                   { ctx.BeginMarkup() }
-func (_this *ListRule) OnMetadata(ctx *Context)                                 { ctx.BeginMetadata() }
-func (_this *ListRule) OnComment(ctx *Context)                                  { ctx.BeginComment() }
-func (_this *ListRule) OnEnd(ctx *Context)                                      { ctx.EndContainer() }
-func (_this *ListRule) OnMarker(ctx *Context)                                   { ctx.BeginMarkerAnyType() }
-func (_this *ListRule) OnReference(ctx *Context)                                { ctx.BeginReferenceAnyType() }
+func (_this *ListRule) String() string                  { return "List Rule" }
+func (_this *ListRule) OnKeyableObject(ctx *Context)    { ctx.NotifyListElement() }
+func (_this *ListRule) OnNonKeyableObject(ctx *Context) { ctx.NotifyListElement() }
+func (_this *ListRule) OnNA(ctx *Context)               { ctx.NotifyListElement() }
+func (_this *ListRule) OnChildContainerEnded(ctx *Context, cType DataType) {
+	// Comments and metadata are stacked as DataTypeInvalid, and aren't elements
+	if cType != DataTypeInvalid {
+		ctx.NotifyListElement()
+	}
+}
+func (_this *ListRule) OnPadding(ctx *Context)                    { /* Nothing to do */ }
+func (_this *ListRule) OnInt(ctx *Context, value int64)           { ctx.NotifyListElement() }
+func (_this *ListRule) OnPositiveInt(ctx *Context, value uint64)  { ctx.NotifyListElement() }
+func (_this *ListRule) OnBigInt(ctx *Context, value *big.Int)     { ctx.NotifyListElement() }
+func (_this *ListRule) OnFloat(ctx *Context, value float64)       { ctx.NotifyListElement() }
+func (_this *ListRule) OnBigFloat(ctx *Context, value *big.Float) { ctx.NotifyListElement() }
+func (_this *ListRule) OnDecimalFloat(ctx *Context, value compact_float.DFloat) {
+	ctx.NotifyListElement()
+}
+func (_this *ListRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
+	ctx.NotifyListElement()
+}
+func (_this *ListRule) OnList(ctx *Context)        { ctx.BeginList() }
+func (_this *ListRule) OnMap(ctx *Context)         { ctx.BeginMap() }
+func (_this *ListRule) OnMarkup(ctx *Context)      { ctx.BeginMarkup() }
+func (_this *ListRule) OnMetadata(ctx *Context)    { ctx.BeginMetadata() }
+func (_this *ListRule) OnComment(ctx *Context)     { ctx.BeginComment() }
+func (_this *ListRule) OnEnd(ctx *Context)         { ctx.EndContainer() }
+func (_this *ListRule) OnMarker(ctx *Context)      { ctx.BeginMarkerAnyType() }
+func (_this *ListRule) OnReference(ctx *Context)   { ctx.BeginReferenceAnyType() }
+func (_this *ListRule) OnConcatenate(ctx *Context) { ctx.BeginConcatenate() }
 func (_this *ListRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	ctx.BeginConstantAnyType(name, explicitValue)
 }
 func (_this *ListRule) OnArray(ctx *Context, arrayType events.ArrayType, elementCount uint64, data []uint8) {
 	ctx.ValidateFullArrayAnyType(arrayType, elementCount, data)
+	ctx.NotifyListElement()
 }
 func (_this *ListRule) OnStringlikeArray(ctx *Context, arrayType events.ArrayType, data string) {
 	ctx.ValidateFullArrayStringlike(arrayType, data)
+	ctx.NotifyListElement()
 }
 func (_this *ListRule) OnArrayBegin(ctx *Context, arrayType events.ArrayType) {
 	ctx.BeginArrayAnyType(arrayType)
@@ -86,11 +98,12 @@ func (_this *MapKeyRule) OnDecimalFloat(ctx *Context, value compact_float.DFloat
 func (_this *MapKeyRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
 	ctx.SwitchMapValue()
 }
-func (_this *MapKeyRule) OnMetadata(ctx *Context)  { ctx.BeginMetadata() }
-func (_this *MapKeyRule) OnComment(ctx *Context)   { ctx.BeginComment() }
-func (_this *MapKeyRule) OnEnd(ctx *Context)       { ctx.EndContainer() }
-func (_this *MapKeyRule) OnMarker(ctx *Context)    { ctx.BeginMarkerKeyable() }
-func (_this *MapKeyRule) OnReference(ctx *Context) { ctx.BeginReferenceKeyable() }
+func (_this *MapKeyRule) OnMetadata(ctx *Context)    { ctx.BeginMetadata() }
+func (_this *MapKeyRule) OnComment(ctx *Context)     { ctx.BeginComment() }
+func (_this *MapKeyRule) OnEnd(ctx *Context)         { ctx.EndContainer() }
+func (_this *MapKeyRule) OnMarker(ctx *Context)      { ctx.BeginMarkerKeyable() }
+func (_this *MapKeyRule) OnReference(ctx *Context)   { ctx.BeginReferenceKeyable() }
+func (_this *MapKeyRule) OnConcatenate(ctx *Context) { ctx.BeginConcatenate() }
 func (_this *MapKeyRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	ctx.BeginConstantKeyable(name, explicitValue)
 }
@@ -127,13 +140,14 @@ func (_this *MapValueRule) OnDecimalFloat(ctx *Context, value compact_float.DFlo
 func (_this *MapValueRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
 	ctx.SwitchMapKey()
 }
-func (_this *MapValueRule) OnList(ctx *Context)      { ctx.BeginList() }
-func (_this *MapValueRule) OnMap(ctx *Context)       { ctx.BeginMap() }
-func (_this *MapValueRule) OnMarkup(ctx *Context)    { ctx.BeginMarkup() }
-func (_this *MapValueRule) OnMetadata(ctx *Context)  { ctx.BeginMetadata() }
-func (_this *MapValueRule) OnComment(ctx *Context)   { ctx.BeginComment() }
-func (_this *MapValueRule) OnMarker(ctx *Context)    { ctx.BeginMarkerAnyType() }
-func (_this *MapValueRule) OnReference(ctx *Context) { ctx.BeginReferenceAnyType() }
+func (_this *MapValueRule) OnList(ctx *Context)        { ctx.BeginList() }
+func (_this *MapValueRule) OnMap(ctx *Context)         { ctx.BeginMap() }
+func (_this *MapValueRule) OnMarkup(ctx *Context)      { ctx.BeginMarkup() }
+func (_this *MapValueRule) OnMetadata(ctx *Context)    { ctx.BeginMetadata() }
+func (_this *MapValueRule) OnComment(ctx *Context)     { ctx.BeginComment() }
+func (_this *MapValueRule) OnMarker(ctx *Context)      { ctx.BeginMarkerAnyType() }
+func (_this *MapValueRule) OnReference(ctx *Context)   { ctx.BeginReferenceAnyType() }
+func (_this *MapValueRule) OnConcatenate(ctx *Context) { ctx.BeginConcatenate() }
 func (_this *MapValueRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	ctx.BeginConstantAnyType(name, explicitValue)
 }
@@ -168,8 +182,9 @@ func (_this *MarkupNameRule) OnDecimalFloat(ctx *Context, value compact_float.DF
 func (_this *MarkupNameRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
 	ctx.SwitchMarkupKey()
 }
-func (_this *MarkupNameRule) OnMarker(ctx *Context)    { ctx.BeginMarkerKeyable() }
-func (_this *MarkupNameRule) OnReference(ctx *Context) { ctx.BeginReferenceKeyable() }
+func (_this *MarkupNameRule) OnMarker(ctx *Context)      { ctx.BeginMarkerKeyable() }
+func (_this *MarkupNameRule) OnReference(ctx *Context)   { ctx.BeginReferenceKeyable() }
+func (_this *MarkupNameRule) OnConcatenate(ctx *Context) { ctx.BeginConcatenate() }
 func (_this *MarkupNameRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	ctx.BeginConstantKeyable(name, explicitValue)
 }
@@ -204,11 +219,12 @@ func (_this *MarkupKeyRule) OnDecimalFloat(ctx *Context, value compact_float.DFl
 func (_this *MarkupKeyRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
 	ctx.SwitchMarkupValue()
 }
-func (_this *MarkupKeyRule) OnMetadata(ctx *Context)  { ctx.BeginMetadata() }
-func (_this *MarkupKeyRule) OnComment(ctx *Context)   { ctx.BeginComment() }
-func (_this *MarkupKeyRule) OnEnd(ctx *Context)       { ctx.SwitchMarkupContents() }
-func (_this *MarkupKeyRule) OnMarker(ctx *Context)    { ctx.BeginMarkerKeyable() }
-func (_this *MarkupKeyRule) OnReference(ctx *Context) { ctx.BeginReferenceKeyable() }
+func (_this *MarkupKeyRule) OnMetadata(ctx *Context)    { ctx.BeginMetadata() }
+func (_this *MarkupKeyRule) OnComment(ctx *Context)     { ctx.BeginComment() }
+func (_this *MarkupKeyRule) OnEnd(ctx *Context)         { ctx.SwitchMarkupContents() }
+func (_this *MarkupKeyRule) OnMarker(ctx *Context)      { ctx.BeginMarkerKeyable() }
+func (_this *MarkupKeyRule) OnReference(ctx *Context)   { ctx.BeginReferenceKeyable() }
+func (_this *MarkupKeyRule) OnConcatenate(ctx *Context) { ctx.BeginConcatenate() }
 func (_this *MarkupKeyRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	ctx.BeginConstantKeyable(name, explicitValue)
 }
@@ -245,13 +261,14 @@ func (_this *MarkupValueRule) OnDecimalFloat(ctx *Context, value compact_float.D
 func (_this *MarkupValueRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
 	ctx.SwitchMarkupKey()
 }
-func (_this *MarkupValueRule) OnList(ctx *Context)      { ctx.BeginList() }
-func (_this *MarkupValueRule) OnMap(ctx *Context)       { ctx.BeginMap() }
-func (_this *MarkupValueRule) OnMarkup(ctx *Context)    { ctx.BeginMarkup() }
-func (_this *MarkupValueRule) OnMetadata(ctx *Context)  { ctx.BeginMetadata() }
-func (_this *MarkupValueRule) OnComment(ctx *Context)   { ctx.BeginComment() }
-func (_this *MarkupValueRule) OnMarker(ctx *Context)    { ctx.BeginMarkerAnyType() }
-func (_this *MarkupValueRule) OnReference(ctx *Context) { ctx.BeginReferenceAnyType() }
+func (_this *MarkupValueRule) OnList(ctx *Context)        { ctx.BeginList() }
+func (_this *MarkupValueRule) OnMap(ctx *Context)         { ctx.BeginMap() }
+func (_this *MarkupValueRule) OnMarkup(ctx *Context)      { ctx.BeginMarkup() }
+func (_this *MarkupValueRule) OnMetadata(ctx *Context)    { ctx.BeginMetadata() }
+func (_this *MarkupValueRule) OnComment(ctx *Context)     { ctx.BeginComment() }
+func (_this *MarkupValueRule) OnMarker(ctx *Context)      { ctx.BeginMarkerAnyType() }
+func (_this *MarkupValueRule) OnReference(ctx *Context)   { ctx.BeginReferenceAnyType() }
+func (_this *MarkupValueRule) OnConcatenate(ctx *Context) { ctx.BeginConcatenate() }
 func (_this *MarkupValueRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	ctx.BeginConstantAnyType(name, explicitValue)
 }
@@ -325,11 +342,12 @@ func (_this *MetaKeyRule) OnDecimalFloat(ctx *Context, value compact_float.DFloa
 func (_this *MetaKeyRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
 	ctx.SwitchMetadataValue()
 }
-func (_this *MetaKeyRule) OnMetadata(ctx *Context)  { ctx.BeginMetadata() }
-func (_this *MetaKeyRule) OnComment(ctx *Context)   { ctx.BeginComment() }
-func (_this *MetaKeyRule) OnEnd(ctx *Context)       { ctx.SwitchMetadataCompletion() }
-func (_this *MetaKeyRule) OnMarker(ctx *Context)    { ctx.BeginMarkerKeyable() }
-func (_this *MetaKeyRule) OnReference(ctx *Context) { ctx.BeginReferenceKeyable() }
+func (_this *MetaKeyRule) OnMetadata(ctx *Context)    { ctx.BeginMetadata() }
+func (_this *MetaKeyRule) OnComment(ctx *Context)     { ctx.BeginComment() }
+func (_this *MetaKeyRule) OnEnd(ctx *Context)         { ctx.SwitchMetadataCompletion() }
+func (_this *MetaKeyRule) OnMarker(ctx *Context)      { ctx.BeginMarkerKeyable() }
+func (_this *MetaKeyRule) OnReference(ctx *Context)   { ctx.BeginReferenceKeyable() }
+func (_this *MetaKeyRule) OnConcatenate(ctx *Context) { ctx.BeginConcatenate() }
 func (_this *MetaKeyRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	ctx.BeginConstantKeyable(name, explicitValue)
 }
@@ -366,13 +384,14 @@ func (_this *MetaValueRule) OnDecimalFloat(ctx *Context, value compact_float.DFl
 func (_this *MetaValueRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
 	ctx.SwitchMetadataKey()
 }
-func (_this *MetaValueRule) OnList(ctx *Context)      { ctx.BeginList() }
-func (_this *MetaValueRule) OnMap(ctx *Context)       { ctx.BeginMap() }
-func (_this *MetaValueRule) OnMarkup(ctx *Context)    { ctx.BeginMarkup() }
-func (_this *MetaValueRule) OnMetadata(ctx *Context)  { ctx.BeginMetadata() }
-func (_this *MetaValueRule) OnComment(ctx *Context)   { ctx.BeginComment() }
-func (_this *MetaValueRule) OnMarker(ctx *Context)    { ctx.BeginMarkerAnyType() }
-func (_this *MetaValueRule) OnReference(ctx *Context) { ctx.BeginReferenceAnyType() }
+func (_this *MetaValueRule) OnList(ctx *Context)        { ctx.BeginList() }
+func (_this *MetaValueRule) OnMap(ctx *Context)         { ctx.BeginMap() }
+func (_this *MetaValueRule) OnMarkup(ctx *Context)      { ctx.BeginMarkup() }
+func (_this *MetaValueRule) OnMetadata(ctx *Context)    { ctx.BeginMetadata() }
+func (_this *MetaValueRule) OnComment(ctx *Context)     { ctx.BeginComment() }
+func (_this *MetaValueRule) OnMarker(ctx *Context)      { ctx.BeginMarkerAnyType() }
+func (_this *MetaValueRule) OnReference(ctx *Context)   { ctx.BeginReferenceAnyType() }
+func (_this *MetaValueRule) OnConcatenate(ctx *Context) { ctx.BeginConcatenate() }
 func (_this *MetaValueRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	ctx.BeginConstantAnyType(name, explicitValue)
 }
@@ -462,6 +481,10 @@ func (_this *MetaCompletionRule) OnReference(ctx *Context) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnReference(ctx)
 }
+func (_this *MetaCompletionRule) OnConcatenate(ctx *Context) {
+	ctx.UnstackRule()
+	ctx.CurrentEntry.Rule.OnConcatenate(ctx)
+}
 func (_this *MetaCompletionRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnConstant(ctx, name, explicitValue)
@@ -49,6 +49,8 @@ import (
 type RulesEventReceiver struct {
 	context  Context
 	receiver events.DataEventReceiver
+	// Reported when the document begins, so that decoders can return it
+	optionsError error
 }
 
 // Create a new rules set.
@@ -60,13 +62,24 @@ func NewRules(nextReceiver events.DataEventReceiver, opts *options.RuleOptions)
 }
 
 // Initialize a rules set.
-// If opts = nil, defaults are used.
+// If opts = nil, defaults are used. Invalid options are reported (by panicking)
+// when the document begins, which decoders return as an error.
 func (_this *RulesEventReceiver) Init(nextReceiver events.DataEventReceiver, opts *options.RuleOptions) {
 	opts = opts.WithDefaultsApplied()
+	_this.optionsError = opts.Validate()
 	_this.receiver = nextReceiver
 	_this.context.Init(version.ConciseEncodingVersion, opts)
 }
 
+// Create a rules set for a document loaded via a resource ID reference from
+// within the document that these rules are checking. The new rules set uses
+// the same options, and its limits apply to both documents combined.
+func (_this *RulesEventReceiver) NewReferencedDocumentRules(nextReceiver events.DataEventReceiver) events.DataEventReceiver {
+	rules := &RulesEventReceiver{receiver: nextReceiver}
+	rules.context.InitReferencedDocument(&_this.context)
+	return rules
+}
+
 // Reset the rules set back to its initial state.
 func (_this *RulesEventReceiver) Reset() {
 	_this.context.Reset()
@@ -77,6 +90,9 @@ func (_this *RulesEventReceiver) SetNextReceiver(nextReceiver events.DataEventRe
 }
 
 func (_this *RulesEventReceiver) OnBeginDocument() {
+	if _this.optionsError != nil {
+		panic(_this.optionsError)
+	}
 	_this.context.CurrentEntry.Rule.OnBeginDocument(&_this.context)
 	_this.receiver.OnBeginDocument()
 }
@@ -222,22 +238,32 @@ func (_this *RulesEventReceiver) OnCompactTime(value compact_time.Time) {
 
 func (_this *RulesEventReceiver) OnArray(arrayType events.ArrayType, elementCount uint64, value []byte) {
 	_this.context.NotifyNewObject()
-	_this.context.CurrentEntry.Rule.OnArray(&_this.context, arrayType, elementCount, value)
+	_this.context.CurrentEntry.Rule.OnArray(&_this.context, _this.beginConcatIfRIDCat(arrayType), elementCount, value)
 	_this.receiver.OnArray(arrayType, elementCount, value)
 }
 
 func (_this *RulesEventReceiver) OnStringlikeArray(arrayType events.ArrayType, value string) {
 	_this.context.NotifyNewObject()
-	_this.context.CurrentEntry.Rule.OnStringlikeArray(&_this.context, arrayType, value)
+	_this.context.CurrentEntry.Rule.OnStringlikeArray(&_this.context, _this.beginConcatIfRIDCat(arrayType), value)
 	_this.receiver.OnStringlikeArray(arrayType, value)
 }
 
 func (_this *RulesEventReceiver) OnArrayBegin(arrayType events.ArrayType) {
 	_this.context.NotifyNewObject()
-	_this.context.CurrentEntry.Rule.OnArrayBegin(&_this.context, arrayType)
+	_this.context.CurrentEntry.Rule.OnArrayBegin(&_this.context, _this.beginConcatIfRIDCat(arrayType))
 	_this.receiver.OnArrayBegin(arrayType)
 }
 
+// A concatenated resource ID array is validated as a concatenation followed
+// by a regular resource ID.
+func (_this *RulesEventReceiver) beginConcatIfRIDCat(arrayType events.ArrayType) events.ArrayType {
+	if arrayType == events.ArrayTypeResourceIDConcat {
+		_this.context.CurrentEntry.Rule.OnConcatenate(&_this.context)
+		return events.ArrayTypeResourceID
+	}
+	return arrayType
+}
+
 func (_this *RulesEventReceiver) OnArrayChunk(length uint64, moreChunksFollow bool) {
 	_this.context.CurrentEntry.Rule.OnArrayChunk(&_this.context, length, moreChunksFollow)
 	_this.receiver.OnArrayChunk(length, moreChunksFollow)
@@ -249,7 +275,9 @@ func (_this *RulesEventReceiver) OnArrayData(data []byte) {
 }
 
 func (_this *RulesEventReceiver) OnConcatenate() {
-	panic("TODO: Rules.OnConcatenate")
+	_this.context.NotifyNewObject()
+	_this.context.CurrentEntry.Rule.OnConcatenate(&_this.context)
+	_this.receiver.OnConcatenate()
 }
 
 func (_this *RulesEventReceiver) OnList() {
@@ -299,6 +327,13 @@ func (_this *RulesEventReceiver) OnReference() {
 }
 
 func (_this *RulesEventReceiver) OnConstant(name []byte, explicitValue bool) {
+	if !explicitValue {
+		if value, ok := _this.context.opts.Constants[string(name)]; ok {
+			_this.OnConstant(name, true)
+			events.IterateConstantValue(value, _this)
+			return
+		}
+	}
 	_this.context.CurrentEntry.Rule.OnConstant(&_this.context, name, explicitValue)
 	_this.receiver.OnConstant(name, explicitValue)
 }
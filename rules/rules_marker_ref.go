@@ -155,6 +155,9 @@ func (_this *MarkedObjectKeyableRule) OnReference(ctx *Context) {
 	ctx.CurrentEntry.Rule.OnReference(ctx)
 	ctx.MarkObject(DataTypeKeyable)
 }
+func (_this *MarkedObjectKeyableRule) OnConcatenate(ctx *Context) {
+	ctx.BeginConcatenate()
+}
 func (_this *MarkedObjectKeyableRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnConstant(ctx, name, explicitValue)
@@ -174,6 +177,7 @@ func (_this *MarkedObjectKeyableRule) OnArrayBegin(ctx *Context, arrayType event
 	ctx.BeginArrayKeyable(arrayType)
 }
 func (_this *MarkedObjectKeyableRule) OnChildContainerEnded(ctx *Context, _ DataType) {
+	ctx.MarkContainerObject(DataTypeKeyable)
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnChildContainerEnded(ctx, DataTypeKeyable)
 }
@@ -248,6 +252,9 @@ func (_this *MarkedObjectAnyTypeRule) OnReference(ctx *Context) {
 	ctx.CurrentEntry.Rule.OnReference(ctx)
 	ctx.MarkObject(DataTypeAnyType)
 }
+func (_this *MarkedObjectAnyTypeRule) OnConcatenate(ctx *Context) {
+	ctx.BeginConcatenate()
+}
 func (_this *MarkedObjectAnyTypeRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnConstant(ctx, name, explicitValue)
@@ -256,18 +263,26 @@ func (_this *MarkedObjectAnyTypeRule) OnConstant(ctx *Context, name []byte, expl
 func (_this *MarkedObjectAnyTypeRule) OnArray(ctx *Context, arrayType events.ArrayType, elementCount uint64, data []uint8) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnArray(ctx, arrayType, elementCount, data)
-	ctx.MarkObject(DataTypeAnyType)
+	if isKeyableType(arrayType) {
+		ctx.MarkObject(DataTypeKeyable)
+	} else {
+		ctx.MarkObject(DataTypeAnyType)
+	}
 }
 func (_this *MarkedObjectAnyTypeRule) OnStringlikeArray(ctx *Context, arrayType events.ArrayType, data string) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnStringlikeArray(ctx, arrayType, data)
-	ctx.MarkObject(DataTypeAnyType)
+	if isKeyableType(arrayType) {
+		ctx.MarkObject(DataTypeKeyable)
+	} else {
+		ctx.MarkObject(DataTypeAnyType)
+	}
 }
 func (_this *MarkedObjectAnyTypeRule) OnArrayBegin(ctx *Context, arrayType events.ArrayType) {
 	ctx.ParentRule().OnArrayBegin(ctx, arrayType)
 }
 func (_this *MarkedObjectAnyTypeRule) OnChildContainerEnded(ctx *Context, cType DataType) {
-	ctx.MarkObject(cType)
+	ctx.MarkContainerObject(cType)
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnChildContainerEnded(ctx, cType)
 }
@@ -427,5 +442,6 @@ func (_this *TLReferenceRIDRule) OnStringlikeArray(ctx *Context, arrayType event
 	_this.OnArray(ctx, arrayType, uint64(len(data)), []byte(data))
 }
 func (_this *TLReferenceRIDRule) OnChildContainerEnded(ctx *Context, _ DataType) {
-	// Toss out the result because it's a resource ID
+	// Toss out the result because it's a resource ID (the builder loads the
+	// referenced document via BuilderOptions.ReferenceResolver)
 }
@@ -83,13 +83,14 @@ func (_this *TopLevelRule) OnDecimalFloat(ctx *Context, value compact_float.DFlo
 func (_this *TopLevelRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
 	ctx.SwitchEndDocument()
 }
-func (_this *TopLevelRule) OnList(ctx *Context)      { ctx.BeginList() }
-func (_this *TopLevelRule) OnMap(ctx *Context)       { ctx.BeginMap() }
-func (_this *TopLevelRule) OnMarkup(ctx *Context)    { ctx.BeginMarkup() }
-func (_this *TopLevelRule) OnMetadata(ctx *Context)  { ctx.BeginMetadata() }
-func (_this *TopLevelRule) OnComment(ctx *Context)   { ctx.BeginComment() }
-func (_this *TopLevelRule) OnMarker(ctx *Context)    { ctx.BeginMarkerAnyType() }
-func (_this *TopLevelRule) OnReference(ctx *Context) { ctx.BeginTopLevelReference() }
+func (_this *TopLevelRule) OnList(ctx *Context)        { ctx.BeginList() }
+func (_this *TopLevelRule) OnMap(ctx *Context)         { ctx.BeginMap() }
+func (_this *TopLevelRule) OnMarkup(ctx *Context)      { ctx.BeginMarkup() }
+func (_this *TopLevelRule) OnMetadata(ctx *Context)    { ctx.BeginMetadata() }
+func (_this *TopLevelRule) OnComment(ctx *Context)     { ctx.BeginComment() }
+func (_this *TopLevelRule) OnMarker(ctx *Context)      { ctx.BeginMarkerAnyType() }
+func (_this *TopLevelRule) OnReference(ctx *Context)   { ctx.BeginTopLevelReference() }
+func (_this *TopLevelRule) OnConcatenate(ctx *Context) { ctx.BeginConcatenate() }
 func (_this *TopLevelRule) OnConstant(ctx *Context, name []byte, explicitValue bool) {
 	ctx.BeginConstantAnyType(name, explicitValue)
 }
@@ -153,57 +154,49 @@ func (_this *ConstantKeyableRule) OnPadding(ctx *Context) { /* Nothing to do */
 func (_this *ConstantKeyableRule) OnKeyableObject(ctx *Context) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnKeyableObject(ctx)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantKeyableRule) OnInt(ctx *Context, value int64) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnInt(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantKeyableRule) OnPositiveInt(ctx *Context, value uint64) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnPositiveInt(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantKeyableRule) OnBigInt(ctx *Context, value *big.Int) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnBigInt(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantKeyableRule) OnFloat(ctx *Context, value float64) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnFloat(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantKeyableRule) OnBigFloat(ctx *Context, value *big.Float) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnBigFloat(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantKeyableRule) OnDecimalFloat(ctx *Context, value compact_float.DFloat) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnDecimalFloat(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantKeyableRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnBigDecimalFloat(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantKeyableRule) OnReference(ctx *Context) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnReference(ctx)
-	ctx.MarkObject(DataTypeKeyable)
+}
+func (_this *ConstantKeyableRule) OnConcatenate(ctx *Context) {
+	ctx.BeginConcatenate()
 }
 func (_this *ConstantKeyableRule) OnArray(ctx *Context, arrayType events.ArrayType, elementCount uint64, data []uint8) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnArray(ctx, arrayType, elementCount, data)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantKeyableRule) OnStringlikeArray(ctx *Context, arrayType events.ArrayType, data string) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnStringlikeArray(ctx, arrayType, data)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantKeyableRule) OnArrayBegin(ctx *Context, arrayType events.ArrayType) {
 	ctx.BeginArrayKeyable(arrayType)
@@ -221,53 +214,43 @@ func (_this *ConstantAnyTypeRule) String() string         { return "Constant Rul
 func (_this *ConstantAnyTypeRule) OnPadding(ctx *Context) { /* Nothing to do */ }
 func (_this *ConstantAnyTypeRule) OnNonKeyableObject(ctx *Context) {
 	ctx.UnstackRule()
-	ctx.CurrentEntry.Rule.OnKeyableObject(ctx)
-	ctx.MarkObject(DataTypeAnyType)
+	ctx.CurrentEntry.Rule.OnNonKeyableObject(ctx)
 }
 func (_this *ConstantAnyTypeRule) OnNA(ctx *Context) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnNA(ctx)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantAnyTypeRule) OnKeyableObject(ctx *Context) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnKeyableObject(ctx)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantAnyTypeRule) OnInt(ctx *Context, value int64) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnInt(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantAnyTypeRule) OnPositiveInt(ctx *Context, value uint64) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnPositiveInt(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantAnyTypeRule) OnBigInt(ctx *Context, value *big.Int) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnBigInt(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantAnyTypeRule) OnFloat(ctx *Context, value float64) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnFloat(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantAnyTypeRule) OnBigFloat(ctx *Context, value *big.Float) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnBigFloat(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantAnyTypeRule) OnDecimalFloat(ctx *Context, value compact_float.DFloat) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnDecimalFloat(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantAnyTypeRule) OnBigDecimalFloat(ctx *Context, value *apd.Decimal) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnBigDecimalFloat(ctx, value)
-	ctx.MarkObject(DataTypeKeyable)
 }
 func (_this *ConstantAnyTypeRule) OnList(ctx *Context) {
 	ctx.ParentRule().OnList(ctx)
@@ -281,23 +264,81 @@ func (_this *ConstantAnyTypeRule) OnMarkup(ctx *Context) {
 func (_this *ConstantAnyTypeRule) OnReference(ctx *Context) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnReference(ctx)
-	ctx.MarkObject(DataTypeAnyType)
+}
+func (_this *ConstantAnyTypeRule) OnConcatenate(ctx *Context) {
+	ctx.BeginConcatenate()
 }
 func (_this *ConstantAnyTypeRule) OnArray(ctx *Context, arrayType events.ArrayType, elementCount uint64, data []uint8) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnArray(ctx, arrayType, elementCount, data)
-	ctx.MarkObject(DataTypeAnyType)
 }
 func (_this *ConstantAnyTypeRule) OnStringlikeArray(ctx *Context, arrayType events.ArrayType, data string) {
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnStringlikeArray(ctx, arrayType, data)
-	ctx.MarkObject(DataTypeAnyType)
 }
 func (_this *ConstantAnyTypeRule) OnArrayBegin(ctx *Context, arrayType events.ArrayType) {
 	ctx.ParentRule().OnArrayBegin(ctx, arrayType)
 }
 func (_this *ConstantAnyTypeRule) OnChildContainerEnded(ctx *Context, cType DataType) {
-	ctx.MarkObject(cType)
 	ctx.UnstackRule()
 	ctx.CurrentEntry.Rule.OnChildContainerEnded(ctx, cType)
 }
+
+// =============================================================================
+
+type ConcatRIDRule struct{}
+
+func (_this *ConcatRIDRule) String() string         { return "Concatenated Resource ID Rule" }
+func (_this *ConcatRIDRule) OnPadding(ctx *Context) { /* Nothing to do */ }
+func (_this *ConcatRIDRule) OnArray(ctx *Context, arrayType events.ArrayType, elementCount uint64, data []uint8) {
+	ctx.ValidateFullArrayRID(arrayType, elementCount, data)
+	ctx.SwitchConcatSuffix()
+}
+func (_this *ConcatRIDRule) OnStringlikeArray(ctx *Context, arrayType events.ArrayType, data string) {
+	ctx.AssertArrayTypeRID(arrayType)
+	ctx.ValidateFullArrayStringlike(arrayType, data)
+	ctx.SwitchConcatSuffix()
+}
+func (_this *ConcatRIDRule) OnArrayBegin(ctx *Context, arrayType events.ArrayType) {
+	ctx.BeginArrayRID(arrayType)
+}
+func (_this *ConcatRIDRule) OnChildContainerEnded(ctx *Context, _ DataType) {
+	ctx.SwitchConcatSuffix()
+}
+
+// =============================================================================
+
+type ConcatSuffixRule struct{}
+
+func (_this *ConcatSuffixRule) String() string         { return "Concatenation Suffix Rule" }
+func (_this *ConcatSuffixRule) OnPadding(ctx *Context) { /* Nothing to do */ }
+func (_this *ConcatSuffixRule) OnInt(ctx *Context, value int64) {
+	if value < 0 {
+		panic(fmt.Errorf("Concatenation suffix (%v) cannot be negative", value))
+	}
+	ctx.EndConcatenate()
+}
+func (_this *ConcatSuffixRule) OnPositiveInt(ctx *Context, value uint64) {
+	ctx.EndConcatenate()
+}
+func (_this *ConcatSuffixRule) OnBigInt(ctx *Context, value *big.Int) {
+	if value.Sign() < 0 {
+		panic(fmt.Errorf("Concatenation suffix (%v) cannot be negative", value))
+	}
+	ctx.EndConcatenate()
+}
+func (_this *ConcatSuffixRule) OnArray(ctx *Context, arrayType events.ArrayType, elementCount uint64, data []uint8) {
+	ctx.ValidateFullArrayString(arrayType, elementCount, data)
+	ctx.EndConcatenate()
+}
+func (_this *ConcatSuffixRule) OnStringlikeArray(ctx *Context, arrayType events.ArrayType, data string) {
+	ctx.AssertArrayTypeString(arrayType)
+	ctx.ValidateFullArrayStringlike(arrayType, data)
+	ctx.EndConcatenate()
+}
+func (_this *ConcatSuffixRule) OnArrayBegin(ctx *Context, arrayType events.ArrayType) {
+	ctx.BeginArrayString(arrayType)
+}
+func (_this *ConcatSuffixRule) OnChildContainerEnded(ctx *Context, _ DataType) {
+	ctx.EndConcatenate()
+}
@@ -0,0 +1,288 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package schema
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/kstenerud/go-concise-encoding/cte"
+	"github.com/kstenerud/go-concise-encoding/debug"
+	"github.com/kstenerud/go-concise-encoding/internal/common"
+	"github.com/kstenerud/go-concise-encoding/iterator"
+	"github.com/kstenerud/go-concise-encoding/options"
+)
+
+// Generate a schema describing the documents that an iterator session with
+// the given options produces from values of type t. Struct fields are named
+// and omitted the same way the iterators do it, fields with an id tag are
+// keyed by their decimal ID, and fields without omitempty are required.
+// Pointers, slices of non-array types, maps, and interfaces are nullable.
+//
+// Recursive types produce cyclic schemas. Since a recursive reference is
+// always made through a pointer, slice, or map, the recursive struct's schema
+// is marked nullable if it's referenced through a pointer.
+//
+// If opts is nil, default options will be used.
+func Generate(t reflect.Type, opts *options.IteratorSessionOptions) (schema *Schema, err error) {
+	if !debug.DebugOptions.PassThroughPanics {
+		defer func() {
+			if r := recover(); r != nil {
+				switch v := r.(type) {
+				case error:
+					err = v
+				default:
+					err = fmt.Errorf("%v", r)
+				}
+			}
+		}()
+	}
+
+	var gen generator
+	gen.Init(opts)
+	schema = gen.schemaForType(t)
+	return
+}
+
+// Generate a CTE schema document describing the documents that an iterator
+// session with the given options produces from values of type t.
+// See Generate.
+//
+// If opts is nil, default options will be used.
+func GenerateDocument(t reflect.Type, opts *options.IteratorSessionOptions) (document []byte, err error) {
+	schema, err := Generate(t, opts)
+	if err != nil {
+		return
+	}
+	return schema.Document()
+}
+
+// Encode this schema as a CTE document.
+func (_this *Schema) Document() (document []byte, err error) {
+	opts := options.DefaultCTEMarshalerOptions()
+	opts.Encoder.Indent = "    "
+	opts.Iterator.SortMapKeys = true
+	return cte.NewMarshaler(opts).MarshalToDocument(_this)
+}
+
+// ============================================================================
+// Internal
+
+type generator struct {
+	opts    options.IteratorSessionOptions
+	session *iterator.Session
+	// Structs currently being generated (for recursive types)
+	inProgress map[reflect.Type]*Schema
+}
+
+func (_this *generator) Init(opts *options.IteratorSessionOptions) {
+	opts = opts.WithDefaultsApplied()
+	_this.opts = *opts
+	_this.session = iterator.NewSession(nil, &_this.opts)
+	_this.inProgress = make(map[reflect.Type]*Schema)
+}
+
+func (_this *generator) schemaForType(t reflect.Type) *Schema {
+	if _, ok := _this.opts.CustomBinaryConverters[t]; ok {
+		return &Schema{Type: TypeCustom}
+	}
+	if _, ok := _this.opts.CustomTextConverters[t]; ok {
+		return &Schema{Type: TypeCustom}
+	}
+	for _, uuidType := range _this.opts.UUIDTypes {
+		if t == uuidType {
+			return &Schema{Type: TypeUUID}
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: TypeBool}
+	case reflect.String:
+		if t == common.TypeNumber {
+			return &Schema{Type: TypeFloat}
+		}
+		return &Schema{Type: TypeString}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bitSize := uint(t.Bits())
+		return &Schema{
+			Type: TypeInt,
+			Min:  int64(-1) << (bitSize - 1),
+			Max:  int64(math.MaxInt64 >> (64 - bitSize)),
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch t {
+		case common.TypeFloat16, common.TypeBFloat16:
+			return &Schema{Type: TypeFloat}
+		}
+		return &Schema{
+			Type: TypeInt,
+			Min:  uint64(0),
+			Max:  uint64(math.MaxUint64 >> (64 - uint(t.Bits()))),
+		}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: TypeFloat}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Array:
+		if t == common.TypeUUID {
+			return &Schema{Type: TypeUUID}
+		}
+		length := uint64(t.Len())
+		schema := _this.schemaForSliceOrArray(t)
+		schema.MinLength = &length
+		schema.MaxLength = &length
+		return schema
+	case reflect.Slice:
+		schema := _this.schemaForSliceOrArray(t)
+		if schema.Type == TypeList {
+			schema.Nullable = true
+		}
+		return schema
+	case reflect.Map:
+		return &Schema{
+			Type:     TypeMap,
+			Nullable: true,
+			Keys:     _this.schemaForType(t.Key()),
+			Values:   _this.schemaForType(t.Elem()),
+		}
+	case reflect.Struct:
+		switch t {
+		case common.TypeTime, common.TypeCompactTime:
+			return &Schema{Type: TypeTime}
+		case common.TypeDFloat, common.TypeBigFloat, common.TypeBigDecimalFloat:
+			return &Schema{Type: TypeFloat}
+		case common.TypeBigInt:
+			return &Schema{Type: TypeInt}
+		case common.TypeURL:
+			return &Schema{Type: TypeResourceID}
+		case common.TypeOrderedMap:
+			return &Schema{Type: TypeMap}
+		default:
+			return _this.schemaForStruct(t)
+		}
+	case reflect.Ptr:
+		// Copy, because the schema may belong to a struct that's still being
+		// generated, which must not become nullable itself.
+		schema := *_this.schemaForType(t.Elem())
+		schema.Nullable = true
+		return &schema
+	default:
+		panic(fmt.Errorf("cannot generate a schema for type %v", t))
+	}
+}
+
+func (_this *generator) schemaForSliceOrArray(t reflect.Type) *Schema {
+	elemType := t.Elem()
+	if elementType := arrayElementTypeFor(elemType); elementType != "" {
+		return &Schema{Type: TypeArray, ElementType: elementType}
+	}
+	return &Schema{
+		Type:     TypeList,
+		Elements: _this.schemaForType(elemType),
+	}
+}
+
+// Get the element type that a slice or array of elemType is written as, or ""
+// if it's written as a list.
+func arrayElementTypeFor(elemType reflect.Type) string {
+	switch elemType.Kind() {
+	case reflect.Bool:
+		return ElementTypeBit
+	case reflect.Uint8:
+		return ElementTypeU8
+	case reflect.Uint16:
+		switch elemType {
+		case common.TypeFloat16, common.TypeBFloat16:
+			return ElementTypeF16
+		default:
+			return ElementTypeU16
+		}
+	case reflect.Uint32:
+		return ElementTypeU32
+	case reflect.Uint64:
+		return ElementTypeU64
+	case reflect.Uint:
+		if common.Is64BitArch() {
+			return ElementTypeU64
+		}
+		return ElementTypeU32
+	case reflect.Int8:
+		return ElementTypeI8
+	case reflect.Int16:
+		return ElementTypeI16
+	case reflect.Int32:
+		return ElementTypeI32
+	case reflect.Int64:
+		return ElementTypeI64
+	case reflect.Int:
+		if common.Is64BitArch() {
+			return ElementTypeI64
+		}
+		return ElementTypeI32
+	case reflect.Float32:
+		return ElementTypeF32
+	case reflect.Float64:
+		return ElementTypeF64
+	default:
+		return ""
+	}
+}
+
+func (_this *generator) schemaForStruct(t reflect.Type) *Schema {
+	if schema, ok := _this.inProgress[t]; ok {
+		return schema
+	}
+
+	schema := &Schema{
+		Type:        TypeMap,
+		Description: t.String(),
+		Fields:      make(map[string]*Schema),
+	}
+	_this.inProgress[t] = schema
+	defer delete(_this.inProgress, t)
+
+	// Fill in everything but the field schemas first, so that copies made of
+	// this schema while generating its fields are complete.
+	fields := _this.session.GetStructFields(t)
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+		if field.HasID {
+			names[i] = strconv.FormatUint(field.ID, 10)
+		}
+		if !field.OmitEmpty {
+			schema.Required = append(schema.Required, names[i])
+		}
+	}
+
+	for i, field := range fields {
+		name := names[i]
+		if field.AsString {
+			schema.Fields[name] = &Schema{Type: TypeString}
+		} else {
+			schema.Fields[name] = _this.schemaForType(field.Type)
+		}
+	}
+	return schema
+}
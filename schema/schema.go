@@ -0,0 +1,343 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+// Schemas describe the expected structure and contents of a document, and are
+// themselves written in CTE.
+//
+// A schema is a map describing the top-level object, with nested schemas
+// describing the contents of lists and maps:
+//
+//	c0
+//	{
+//	    type = "map"
+//	    fields = {
+//	        name = {type="string" pattern="^[a-z]+$" max_length=20}
+//	        port = {type="int" min=1 max=65535}
+//	        mode = {type="string" enum=["fast" "safe"]}
+//	        tags = {type="list" elements={type="string"}}
+//	        data = {type="array" element_type="u8"}
+//	    }
+//	    required = ["name" "port"]
+//	}
+//
+// See Schema for the full list of schema keys.
+package schema
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+
+	"github.com/kstenerud/go-concise-encoding/cte"
+
+	"github.com/cockroachdb/apd/v2"
+	"github.com/kstenerud/go-compact-float"
+)
+
+// Schema types
+const (
+	TypeAny        = "any"
+	TypeNull       = "null"
+	TypeBool       = "bool"
+	TypeInt        = "int"
+	TypeFloat      = "float" // Also accepts integers
+	TypeString     = "string"
+	TypeResourceID = "resource_id"
+	TypeUUID       = "uuid"
+	TypeTime       = "time"
+	TypeList       = "list"
+	TypeMap        = "map"
+	TypeArray      = "array"
+	TypeMarkup     = "markup"
+	TypeCustom     = "custom"
+)
+
+// Array element types
+const (
+	ElementTypeBit  = "bit"
+	ElementTypeU8   = "u8"
+	ElementTypeU16  = "u16"
+	ElementTypeU32  = "u32"
+	ElementTypeU64  = "u64"
+	ElementTypeI8   = "i8"
+	ElementTypeI16  = "i16"
+	ElementTypeI32  = "i32"
+	ElementTypeI64  = "i64"
+	ElementTypeF16  = "f16"
+	ElementTypeF32  = "f32"
+	ElementTypeF64  = "f64"
+	ElementTypeUUID = "uuid"
+)
+
+// Schema describes the expected type and contents of a value. Constraints
+// that don't apply to a value's type are ignored.
+type Schema struct {
+	// The expected type of the value (one of the Type constants). An empty
+	// type is the same as TypeAny.
+	Type string `ce:"name=type,omitempty"`
+
+	// If true, the value may also be null (NA).
+	Nullable bool `ce:"name=nullable,omitempty"`
+
+	// Human readable description of the value. It's not used in validation.
+	Description string `ce:"name=description,omitempty"`
+
+	// The inclusive range of numeric values.
+	Min interface{} `ce:"name=min,omitempty"`
+	Max interface{} `ce:"name=max,omitempty"`
+
+	// The inclusive range of lengths. Strings and resource IDs are measured
+	// in characters, arrays and lists in elements, and maps in entries.
+	MinLength *uint64 `ce:"name=min_length,omitempty"`
+	MaxLength *uint64 `ce:"name=max_length,omitempty"`
+
+	// A regular expression (RE2 syntax) that strings and resource IDs must
+	// match.
+	Pattern string `ce:"name=pattern,omitempty"`
+
+	// If not empty, the value must be one of these (strings, resource IDs,
+	// numbers, or bools).
+	Enum []interface{} `ce:"name=enum,omitempty"`
+
+	// The element type of arrays (one of the ElementType constants). If
+	// empty, any typed array is accepted.
+	ElementType string `ce:"name=element_type,omitempty"`
+
+	// The schema of all list elements.
+	Elements *Schema `ce:"name=elements,omitempty"`
+
+	// The schemas of map values by key. Non-negative integer keys are looked
+	// up by their decimal representation. Keys not listed here use the Values
+	// schema.
+	Fields map[string]*Schema `ce:"name=fields,omitempty"`
+
+	// Map keys that must be present.
+	Required []string `ce:"name=required,omitempty"`
+
+	// If true, a map may only contain the keys listed in Fields.
+	Closed bool `ce:"name=closed,omitempty"`
+
+	// The schema of all map keys.
+	Keys *Schema `ce:"name=keys,omitempty"`
+
+	// The schema of map values whose keys aren't listed in Fields.
+	Values *Schema `ce:"name=values,omitempty"`
+}
+
+// Parse a schema from a CTE document.
+func Parse(document []byte) (schema *Schema, err error) {
+	result, err := cte.NewUnmarshaler(nil).UnmarshalFromDocument(document, &Schema{})
+	if err != nil {
+		return
+	}
+	schema = result.(*Schema)
+	err = schema.Validate()
+	return
+}
+
+// Check that this schema (and all schemas it contains) is well formed.
+func (_this *Schema) Validate() error {
+	_, err := compileSchema(_this)
+	return err
+}
+
+// ============================================================================
+// Internal
+
+var validTypes = map[string]bool{
+	"":             true,
+	TypeAny:        true,
+	TypeNull:       true,
+	TypeBool:       true,
+	TypeInt:        true,
+	TypeFloat:      true,
+	TypeString:     true,
+	TypeResourceID: true,
+	TypeUUID:       true,
+	TypeTime:       true,
+	TypeList:       true,
+	TypeMap:        true,
+	TypeArray:      true,
+	TypeMarkup:     true,
+	TypeCustom:     true,
+}
+
+var validElementTypes = map[string]bool{
+	"":              true,
+	ElementTypeBit:  true,
+	ElementTypeU8:   true,
+	ElementTypeU16:  true,
+	ElementTypeU32:  true,
+	ElementTypeU64:  true,
+	ElementTypeI8:   true,
+	ElementTypeI16:  true,
+	ElementTypeI32:  true,
+	ElementTypeI64:  true,
+	ElementTypeF16:  true,
+	ElementTypeF32:  true,
+	ElementTypeF64:  true,
+	ElementTypeUUID: true,
+}
+
+// The parts of a schema that must be prepared before validating.
+type compiledSchema struct {
+	min      *big.Float
+	max      *big.Float
+	pattern  *regexp.Regexp
+	enum     []interface{}
+	required map[string]bool
+}
+
+// Compile a schema and all schemas it contains. Schemas may contain cycles.
+func compileSchema(schema *Schema) (compiled map[*Schema]*compiledSchema, err error) {
+	compiled = make(map[*Schema]*compiledSchema)
+	err = compileSchemaInto(compiled, schema, "top-level schema")
+	return
+}
+
+func compileSchemaInto(compiled map[*Schema]*compiledSchema, schema *Schema, path string) (err error) {
+	if schema == nil || compiled[schema] != nil {
+		return
+	}
+
+	c := &compiledSchema{}
+	compiled[schema] = c
+
+	if !validTypes[schema.Type] {
+		return fmt.Errorf("%v: unknown type [%v]", path, schema.Type)
+	}
+	if !validElementTypes[schema.ElementType] {
+		return fmt.Errorf("%v: unknown element type [%v]", path, schema.ElementType)
+	}
+	if schema.Min != nil {
+		if c.min = numberToBigFloat(schema.Min); c.min == nil {
+			return fmt.Errorf("%v: min %v is not a number", path, schema.Min)
+		}
+	}
+	if schema.Max != nil {
+		if c.max = numberToBigFloat(schema.Max); c.max == nil {
+			return fmt.Errorf("%v: max %v is not a number", path, schema.Max)
+		}
+	}
+	if schema.Pattern != "" {
+		if c.pattern, err = regexp.Compile(schema.Pattern); err != nil {
+			return fmt.Errorf("%v: invalid pattern: %v", path, err)
+		}
+	}
+	for _, value := range schema.Enum {
+		normalized := normalizeEnumValue(value)
+		if normalized == nil {
+			return fmt.Errorf("%v: enum value %v must be a string, number, or bool", path, value)
+		}
+		c.enum = append(c.enum, normalized)
+	}
+	c.required = make(map[string]bool)
+	for _, key := range schema.Required {
+		c.required[key] = true
+	}
+
+	if err = compileSchemaInto(compiled, schema.Elements, path+".elements"); err != nil {
+		return
+	}
+	if err = compileSchemaInto(compiled, schema.Keys, path+".keys"); err != nil {
+		return
+	}
+	if err = compileSchemaInto(compiled, schema.Values, path+".values"); err != nil {
+		return
+	}
+	for key, field := range schema.Fields {
+		if err = compileSchemaInto(compiled, field, path+".fields."+key); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Convert a numeric value to a big float, or return nil if it's not a
+// number (or is NaN).
+func numberToBigFloat(value interface{}) *big.Float {
+	switch v := value.(type) {
+	case *big.Float:
+		return v
+	case *big.Int:
+		return new(big.Float).SetInt(v)
+	case compact_float.DFloat:
+		if v.IsNan() {
+			return nil
+		}
+		return v.BigFloat()
+	case *apd.Decimal:
+		f, _, err := big.ParseFloat(v.String(), 10, 256, big.ToNearestEven)
+		if err != nil {
+			return nil
+		}
+		return f
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Float).SetUint64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(rv.Float()) {
+			return nil
+		}
+		return new(big.Float).SetFloat64(rv.Float())
+	default:
+		return nil
+	}
+}
+
+// Normalize a value so that it can be compared to enum values, or return nil
+// if it can't be an enum value.
+func normalizeEnumValue(value interface{}) interface{} {
+	if f := numberToBigFloat(value); f != nil {
+		return f
+	}
+	switch v := value.(type) {
+	case bool, string:
+		return v
+	case fmt.Stringer:
+		// Resource IDs
+		return v.String()
+	default:
+		return nil
+	}
+}
+
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		switch v := value.(type) {
+		case *big.Float:
+			if f, ok := e.(*big.Float); ok && f.Cmp(v) == 0 {
+				return true
+			}
+		default:
+			if e == value {
+				return true
+			}
+		}
+	}
+	return false
+}
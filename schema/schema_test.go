@@ -0,0 +1,427 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kstenerud/go-concise-encoding/cte"
+	"github.com/kstenerud/go-concise-encoding/events"
+	"github.com/kstenerud/go-concise-encoding/options"
+	"github.com/kstenerud/go-concise-encoding/rules"
+)
+
+const serverSchema = `c0
+{
+    type = map
+    fields = {
+        name    = {type=string pattern="^[a-z]+$" max_length=10}
+        mode    = {type=string enum=[fast safe]}
+        ratio   = {type=float min=0 max=1.5}
+        address = {type=resource_id pattern="^https?:"}
+        data    = {type=array element_type=u8 max_length=4}
+        backup  = {type=map nullable=@true}
+        servers = {
+            type = list
+            min_length = 1
+            elements = {
+                type = map
+                fields = {
+                    host = {type=string}
+                    port = {type=int min=1 max=65535}
+                }
+                required = [host port]
+                closed = @true
+            }
+        }
+    }
+    required = [name servers]
+}`
+
+func parseSchema(t *testing.T, document string) *Schema {
+	schema, err := Parse([]byte(document))
+	if err != nil {
+		t.Fatalf("Error parsing schema: %v", err)
+	}
+	return schema
+}
+
+func validate(schema *Schema, document string) error {
+	validator := NewValidator(events.NewNullEventReceiver(), schema)
+	return cte.NewDecoder(nil).DecodeDocument([]byte(document), rules.NewRules(validator, nil))
+}
+
+func assertValid(t *testing.T, schema *Schema, document string) {
+	if err := validate(schema, document); err != nil {
+		t.Errorf("Expected document %v to be valid but got error: %v", document, err)
+	}
+}
+
+func assertViolations(t *testing.T, schema *Schema, document string, expected ...string) {
+	err := validate(schema, document)
+	var validationError *ValidationError
+	if !errors.As(err, &validationError) {
+		t.Errorf("Expected document %v to fail validation but got error %v", document, err)
+		return
+	}
+	actual := []string{}
+	for _, violation := range validationError.Violations {
+		actual = append(actual, violation.String())
+	}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("Document %v: expected violations\n%v\nbut got\n%v",
+			document, strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestSchemaParse(t *testing.T) {
+	schema := parseSchema(t, serverSchema)
+	if schema.Type != TypeMap {
+		t.Errorf("Expected type map but got %v", schema.Type)
+	}
+	port := schema.Fields["servers"].Elements.Fields["port"]
+	if port.Type != TypeInt || fmt.Sprint(port.Max) != "65535" {
+		t.Errorf("Unexpected port schema %+v", port)
+	}
+	if len(schema.Fields["mode"].Enum) != 2 {
+		t.Errorf("Expected 2 enum values but got %v", schema.Fields["mode"].Enum)
+	}
+}
+
+func TestSchemaParseInvalid(t *testing.T) {
+	for _, document := range []string{
+		`c0 {type=blah}`,
+		`c0 {type=array element_type=u7}`,
+		`c0 {type=string pattern="[a-"}`,
+		`c0 {type=int min=abc}`,
+		`c0 {type=list elements={type=map fields={a={type=nope}}}}`,
+		`c0 {type=string enum=[[1]]}`,
+	} {
+		if _, err := Parse([]byte(document)); err == nil {
+			t.Errorf("Expected schema %v to be invalid", document)
+		}
+	}
+}
+
+func TestSchemaInvalidSchemaFailsValidation(t *testing.T) {
+	err := validate(&Schema{Type: "blah"}, `c0 1`)
+	if err == nil || !strings.Contains(err.Error(), "invalid schema") {
+		t.Errorf("Expected an invalid schema error but got %v", err)
+	}
+}
+
+func TestSchemaValid(t *testing.T) {
+	schema := parseSchema(t, serverSchema)
+	assertValid(t, schema, `c0 {name=abc servers=[{host=a port=80}]}`)
+	assertValid(t, schema, `c0 {
+    name = abc
+    mode = safe
+    ratio = 1
+    address = |r https://example.com|
+    data = |u8x 01 02|
+    backup = @na
+    servers = [{host=a port=1} {host=b port=65535}]
+}`)
+	assertValid(t, schema, `c0 {name=abc /* comment */ servers=[{host=a port=80}] extra=[1 2 3]}`)
+}
+
+func TestSchemaViolations(t *testing.T) {
+	schema := parseSchema(t, serverSchema)
+	assertViolations(t, schema, `c0 [1]`, "top level: expected map but got list")
+	assertViolations(t, schema, `c0 {name=abc}`, `top level: missing required key "servers"`)
+	assertViolations(t, schema, `c0 {name=ABC servers=[]}`,
+		`name: "ABC" does not match pattern "^[a-z]+$"`,
+		"servers: length 0 elements is less than the minimum 1")
+	assertViolations(t, schema, `c0 {name=abcdefghijk servers=[{host=a port=1}]}`,
+		"name: length 11 characters is greater than the maximum 10")
+	assertViolations(t, schema, `c0 {name=abc mode=slow servers=[{host=a port=1}]}`,
+		`mode: "slow" is not one of the allowed values [fast safe]`)
+	assertViolations(t, schema, `c0 {name=abc ratio=1.6 servers=[{host=a port=1}]}`,
+		"ratio: 1.6 is greater than the maximum 1.5")
+	assertViolations(t, schema, `c0 {name=abc ratio=-1 servers=[{host=a port=1}]}`,
+		"ratio: -1 is less than the minimum 0")
+	assertViolations(t, schema, `c0 {name=abc ratio=@nan servers=[{host=a port=1}]}`,
+		"ratio: NaN is not within the allowed range")
+	assertViolations(t, schema, `c0 {name=abc address=|r ftp://x.com| servers=[{host=a port=1}]}`,
+		`address: "ftp://x.com" does not match pattern "^https?:"`)
+	assertViolations(t, schema, `c0 {name=abc data=|u16x 1| servers=[{host=a port=1}]}`,
+		"data: expected an array of u8 but got an array of u16")
+	assertViolations(t, schema, `c0 {name=abc data=|u8x 1 2 3 4 5| servers=[{host=a port=1}]}`,
+		"data: length 5 elements is greater than the maximum 4")
+	assertViolations(t, schema, `c0 {name=abc mode=@na servers=[{host=a port=1}]}`,
+		"mode: expected string but got null")
+	assertViolations(t, schema, `c0 {
+    name = abc
+    servers = [
+        {host=a port=1}
+        {host=b port=0}
+        {host=c port=100000 user=x}
+        {port=@na}
+    ]
+}`,
+		"servers[1].port: 0 is less than the minimum 1",
+		"servers[2].port: 100000 is greater than the maximum 65535",
+		`servers[2]: key "user" is not allowed`,
+		"servers[3].port: expected int but got null",
+		`servers[3]: missing required key "host"`)
+}
+
+func TestSchemaNonStringKeys(t *testing.T) {
+	schema := parseSchema(t, `c0 {type=map keys={type=int} values={type=string}}`)
+	assertValid(t, schema, `c0 {1=a 2=b}`)
+	assertViolations(t, schema, `c0 {1=a x=b 3=4}`,
+		"top level: expected int but got string",
+		"[3]: expected string but got int")
+}
+
+func TestSchemaReferencesAndMarkers(t *testing.T) {
+	schema := parseSchema(t, `c0 {type=list elements={type=map fields={a={type=int max=10}}}}`)
+	assertValid(t, schema, `c0 [&1:{a=1} $1]`)
+	assertViolations(t, schema, `c0 [&1:{a=100} $1]`, "[0].a: 100 is greater than the maximum 10")
+
+	// Referenced values are checked against the schema at the reference
+	schema = parseSchema(t, `c0 {type=map fields={port={type=int}}}`)
+	assertValid(t, schema, `c0 {x=&1:5 port=$1}`)
+	assertViolations(t, schema, `c0 {x=&1:"str" port=$1}`, "port: expected int but got string")
+	assertViolations(t, schema, `c0 {port=$1 x=&1:"str"}`, "port: expected int but got string")
+	assertViolations(t, schema, `c0 {port=$|r other.cte|}`, "port: cannot validate referenced document other.cte")
+
+	// Referenced containers must have been validated against the same schema
+	schema = parseSchema(t, `c0 {
+        type=map
+        fields={
+            a={type=map fields={v={type=int}}}
+            b={type=map fields={v={type=string}}}
+        }
+    }`)
+	assertViolations(t, schema, `c0 {a=&1:{v=1} b=$1}`, "b: referenced map was not validated against this schema")
+	assertViolations(t, schema, `c0 {x=&1:{v="s"} a=$1}`, "a: referenced map was not validated against this schema")
+	assertViolations(t, schema, `c0 {a=&1:[] b=$1}`,
+		"a: expected map but got list",
+		"b: expected map but got list")
+
+	// Keys supplied through references count towards required keys
+	schema = parseSchema(t, `c0 {type=list elements={type=map fields={host={type=string}} required=[host]}}`)
+	assertValid(t, schema, `c0 [{&1:"host"="a"} {$1="b"}]`)
+	assertViolations(t, schema, `c0 [{&1:"host"="a"} {$1=1}]`, "[1].host: expected string but got int")
+}
+
+func TestSchemaConcatenation(t *testing.T) {
+	schema := parseSchema(t, `c0 {type=resource_id pattern="^http://x.com/[0-9]+$"}`)
+	assertValid(t, schema, `c0 |r http://x.com/|:100`)
+	assertViolations(t, schema, `c0 |r http://x.com/|:abc`,
+		`top level: "http://x.com/abc" does not match pattern "^http://x.com/[0-9]+$"`)
+}
+
+func TestSchemaMarkupAndComments(t *testing.T) {
+	schema := parseSchema(t, `c0 {type=list elements={type=markup}}`)
+	assertValid(t, schema, `c0 [<a x=1,text <b,more> /* c */> <c>]`)
+	assertViolations(t, schema, `c0 [<a> 1]`, "[1]: expected markup but got int")
+}
+
+func TestSchemaCyclic(t *testing.T) {
+	node := &Schema{Type: TypeMap, Fields: map[string]*Schema{}}
+	node.Fields["children"] = &Schema{Type: TypeList, Elements: node}
+	node.Fields["value"] = &Schema{Type: TypeInt}
+	assertValid(t, node, `c0 {value=1 children=[{value=2 children=[]}]}`)
+	assertViolations(t, node, `c0 {value=1 children=[{value=x}]}`,
+		"children[0].value: expected int but got string")
+}
+
+type GenAddress struct {
+	Host string
+	Port uint16
+}
+
+type GenNode struct {
+	Value    int32
+	Children []*GenNode `ce:"omitempty"`
+	Parent   *GenNode
+}
+
+type GenConfig struct {
+	Name     string      `ce:"name=service_name"`
+	Primary  GenAddress  `ce:"id=1"`
+	Backup   *GenAddress `ce:"omitempty"`
+	Weights  []float32
+	Flags    [4]bool
+	Labels   map[string]string
+	Servers  []GenAddress
+	Created  time.Time
+	Homepage *url.URL
+	Extra    interface{}
+	Count    int    `json:",string"`
+	Internal string `ce:"-"`
+	private  int
+}
+
+func TestSchemaGenerate(t *testing.T) {
+	schema, err := Generate(reflect.TypeOf(GenConfig{}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSchemaFields(t, schema, "service_name", "1", "backup", "weights", "flags", "labels",
+		"servers", "created", "homepage", "extra", "count")
+	if fmt.Sprint(schema.Required) != "[service_name 1 weights flags labels servers created homepage extra count]" {
+		t.Errorf("Unexpected required fields %v", schema.Required)
+	}
+
+	primary := schema.Fields["1"]
+	assertSchemaFields(t, primary, "host", "port")
+	port := primary.Fields["port"]
+	if port.Type != TypeInt || port.Min != uint64(0) || port.Max != uint64(65535) {
+		t.Errorf("Unexpected port schema %+v", port)
+	}
+	if primary.Nullable || !schema.Fields["backup"].Nullable {
+		t.Errorf("Expected only the backup address to be nullable")
+	}
+
+	weights := schema.Fields["weights"]
+	if weights.Type != TypeArray || weights.ElementType != ElementTypeF32 || weights.Nullable {
+		t.Errorf("Unexpected weights schema %+v", weights)
+	}
+	flags := schema.Fields["flags"]
+	if flags.ElementType != ElementTypeBit || *flags.MinLength != 4 || *flags.MaxLength != 4 {
+		t.Errorf("Unexpected flags schema %+v", flags)
+	}
+	labels := schema.Fields["labels"]
+	if labels.Type != TypeMap || labels.Keys.Type != TypeString || labels.Values.Type != TypeString || !labels.Nullable {
+		t.Errorf("Unexpected labels schema %+v", labels)
+	}
+	servers := schema.Fields["servers"]
+	if servers.Type != TypeList || servers.Elements.Fields["host"].Type != TypeString {
+		t.Errorf("Unexpected servers schema %+v", servers)
+	}
+	if schema.Fields["created"].Type != TypeTime ||
+		schema.Fields["homepage"].Type != TypeResourceID ||
+		!schema.Fields["homepage"].Nullable ||
+		schema.Fields["extra"].Type != "" ||
+		schema.Fields["count"].Type != TypeInt {
+		t.Errorf("Unexpected schema %+v", schema)
+	}
+}
+
+func TestSchemaGenerateJSONTags(t *testing.T) {
+	opts := options.DefaultIteratorSessionOptions()
+	opts.FallbackToJSONTags = true
+	schema, err := Generate(reflect.TypeOf(GenConfig{}), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.Fields["count"].Type != TypeString {
+		t.Errorf("Expected count to be a string but got %+v", schema.Fields["count"])
+	}
+}
+
+func TestSchemaGenerateRecursive(t *testing.T) {
+	schema, err := Generate(reflect.TypeOf(GenNode{}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.Nullable {
+		t.Errorf("Expected the top level schema to not be nullable")
+	}
+	if fmt.Sprint(schema.Required) != "[value parent]" {
+		t.Errorf("Unexpected required fields %v", schema.Required)
+	}
+	for _, pointerSchema := range []*Schema{schema.Fields["parent"], schema.Fields["children"].Elements} {
+		if !pointerSchema.Nullable {
+			t.Errorf("Expected pointer schema %+v to be nullable", pointerSchema)
+		}
+		if reflect.ValueOf(pointerSchema.Fields).Pointer() != reflect.ValueOf(schema.Fields).Pointer() {
+			t.Errorf("Expected a cyclic schema but got %+v", pointerSchema)
+		}
+		if fmt.Sprint(pointerSchema.Required) != "[value parent]" {
+			t.Errorf("Unexpected required fields %v", pointerSchema.Required)
+		}
+	}
+}
+
+func TestSchemaGenerateFails(t *testing.T) {
+	if _, err := Generate(reflect.TypeOf(make(chan int)), nil); err == nil {
+		t.Errorf("Expected an error")
+	}
+}
+
+func TestSchemaGeneratedDocumentValidatesEncodedValues(t *testing.T) {
+	document, err := GenerateDocument(reflect.TypeOf(GenConfig{}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := parseSchema(t, string(document))
+
+	config := GenConfig{
+		Name:     "test",
+		Primary:  GenAddress{Host: "a", Port: 80},
+		Weights:  []float32{1.5},
+		Labels:   map[string]string{"a": "b"},
+		Servers:  []GenAddress{{Host: "b", Port: 443}},
+		Created:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Homepage: &url.URL{Scheme: "http", Host: "x.com"},
+		Count:    5,
+	}
+	encoded, err := cte.NewMarshaler(nil).MarshalToDocument(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValid(t, schema, string(encoded))
+
+	assertViolations(t, schema, `c0 {service_name=x 1={host=a port=70000}}`,
+		"[1].port: 70000 is greater than the maximum 65535",
+		`top level: missing required key "weights"`,
+		`top level: missing required key "flags"`,
+		`top level: missing required key "labels"`,
+		`top level: missing required key "servers"`,
+		`top level: missing required key "created"`,
+		`top level: missing required key "homepage"`,
+		`top level: missing required key "extra"`,
+		`top level: missing required key "count"`)
+}
+
+func TestSchemaGeneratedRecursiveDocument(t *testing.T) {
+	document, err := GenerateDocument(reflect.TypeOf(GenNode{}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := parseSchema(t, string(document))
+	assertValid(t, schema, `c0 {value=1 parent=@na children=[{value=2 parent=@na}]}`)
+	assertViolations(t, schema, `c0 {value=1 parent=@na children=[{value=x parent=@na}]}`,
+		"children[0].value: expected int but got string")
+	assertViolations(t, schema, `c0 @na`, "top level: expected map but got null")
+}
+
+func assertSchemaFields(t *testing.T, schema *Schema, expected ...string) {
+	if len(schema.Fields) != len(expected) {
+		t.Errorf("Expected fields %v but got %v", expected, schema.Fields)
+		return
+	}
+	for _, name := range expected {
+		if _, ok := schema.Fields[name]; !ok {
+			t.Errorf("Expected field %v in %v", name, schema.Fields)
+		}
+	}
+}
@@ -0,0 +1,890 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package schema
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kstenerud/go-concise-encoding/events"
+	"github.com/kstenerud/go-concise-encoding/internal/common"
+
+	"github.com/cockroachdb/apd/v2"
+	"github.com/kstenerud/go-compact-float"
+	"github.com/kstenerud/go-compact-time"
+)
+
+// A single place where a document doesn't match its schema.
+type Violation struct {
+	// The path to the offending value, in the form `servers[2].port`. An empty
+	// path refers to the top-level object.
+	Path    string
+	Message string
+}
+
+func (_this Violation) String() string {
+	return fmt.Sprintf("%v: %v", describePath(_this.Path), _this.Message)
+}
+
+// ValidationError is reported at the end of a document that doesn't match its
+// schema.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (_this *ValidationError) Error() string {
+	messages := make([]string, 0, len(_this.Violations))
+	for _, violation := range _this.Violations {
+		messages = append(messages, violation.String())
+	}
+	return fmt.Sprintf("document does not match schema: %v", strings.Join(messages, "; "))
+}
+
+// Validator validates the data events it receives against a schema, passing
+// them on to the next receiver. It should be placed after a
+// RulesEventReceiver, since it expects the events to be structurally valid.
+//
+// Violations are collected over the whole document, and then reported all at
+// once by panicking with a *ValidationError on OnEndDocument (before it's
+// passed on to the next receiver). Decoders convert this to an error.
+//
+// A reference is validated against the schema at its position using the
+// value it refers to. A referenced list or map is only accepted where a schema
+// with the same constraints as the one at its marker (or no schema) is
+// expected, since its contents were validated there. References to other
+// documents, and undefined constants, are not validated.
+type Validator struct {
+	next       events.DataEventReceiver
+	schema     *Schema
+	compiled   map[*Schema]*compiledSchema
+	compileErr error
+	violations []Violation
+	frames     []validatorFrame
+	pending    pendingMode
+	concatBase string
+
+	// Marked objects by marker ID, and references to markers that haven't
+	// been seen yet.
+	markerID          string
+	hasMarkerID       bool
+	markedObjects     map[string]markedObject
+	forwardReferences map[string][]forwardReference
+
+	chunkedType  events.ArrayType
+	chunkedCount uint64
+	chunkedData  []byte
+	chunkBitSize int
+	chunkRemains uint64
+	moreChunks   bool
+}
+
+// Create a new validator that validates against schema. If schema is not
+// well formed, the validator will panic on the first event it receives.
+func NewValidator(next events.DataEventReceiver, schema *Schema) *Validator {
+	_this := &Validator{}
+	_this.Init(next, schema)
+	return _this
+}
+
+// Initialize a validator that validates against schema. If schema is not
+// well formed, the validator will panic on the first event it receives.
+func (_this *Validator) Init(next events.DataEventReceiver, schema *Schema) {
+	_this.next = next
+	_this.schema = schema
+	_this.compiled, _this.compileErr = compileSchema(schema)
+	_this.Reset()
+}
+
+// Reset the validator back to its initial state so that it can be reused.
+func (_this *Validator) Reset() {
+	_this.violations = _this.violations[:0]
+	_this.frames = _this.frames[:0]
+	_this.pending = pendingNone
+	_this.concatBase = ""
+	_this.hasMarkerID = false
+	_this.markedObjects = make(map[string]markedObject)
+	_this.forwardReferences = make(map[string][]forwardReference)
+}
+
+func (_this *Validator) SetNextReceiver(next events.DataEventReceiver) {
+	_this.next = next
+}
+
+func (_this *Validator) OnBeginDocument() {
+	if _this.compileErr != nil {
+		panic(fmt.Errorf("invalid schema: %v", _this.compileErr))
+	}
+	_this.Reset()
+	_this.next.OnBeginDocument()
+}
+
+func (_this *Validator) OnEndDocument() {
+	if len(_this.violations) > 0 {
+		violations := make([]Violation, len(_this.violations))
+		copy(violations, _this.violations)
+		panic(&ValidationError{Violations: violations})
+	}
+	_this.next.OnEndDocument()
+}
+
+func (_this *Validator) OnVersion(version uint64) {
+	_this.next.OnVersion(version)
+}
+
+func (_this *Validator) OnPadding(count int) {
+	_this.next.OnPadding(count)
+}
+
+func (_this *Validator) OnNA() {
+	_this.onValue(TypeNull, nil)
+	_this.next.OnNA()
+}
+
+func (_this *Validator) OnBool(value bool) {
+	_this.onValue(TypeBool, value)
+	_this.next.OnBool(value)
+}
+
+func (_this *Validator) OnTrue() {
+	_this.onValue(TypeBool, true)
+	_this.next.OnTrue()
+}
+
+func (_this *Validator) OnFalse() {
+	_this.onValue(TypeBool, false)
+	_this.next.OnFalse()
+}
+
+func (_this *Validator) OnPositiveInt(value uint64) {
+	_this.onValue(TypeInt, value)
+	_this.next.OnPositiveInt(value)
+}
+
+func (_this *Validator) OnNegativeInt(value uint64) {
+	_this.onValue(TypeInt, new(big.Int).Neg(new(big.Int).SetUint64(value)))
+	_this.next.OnNegativeInt(value)
+}
+
+func (_this *Validator) OnInt(value int64) {
+	_this.onValue(TypeInt, value)
+	_this.next.OnInt(value)
+}
+
+func (_this *Validator) OnBigInt(value *big.Int) {
+	_this.onValue(TypeInt, value)
+	_this.next.OnBigInt(value)
+}
+
+func (_this *Validator) OnFloat(value float64) {
+	_this.onValue(TypeFloat, value)
+	_this.next.OnFloat(value)
+}
+
+func (_this *Validator) OnBigFloat(value *big.Float) {
+	_this.onValue(TypeFloat, value)
+	_this.next.OnBigFloat(value)
+}
+
+func (_this *Validator) OnDecimalFloat(value compact_float.DFloat) {
+	_this.onValue(TypeFloat, value)
+	_this.next.OnDecimalFloat(value)
+}
+
+func (_this *Validator) OnBigDecimalFloat(value *apd.Decimal) {
+	_this.onValue(TypeFloat, value)
+	_this.next.OnBigDecimalFloat(value)
+}
+
+func (_this *Validator) OnNan(signaling bool) {
+	_this.onValue(TypeFloat, math.NaN())
+	_this.next.OnNan(signaling)
+}
+
+func (_this *Validator) OnTime(value time.Time) {
+	_this.onValue(TypeTime, value)
+	_this.next.OnTime(value)
+}
+
+func (_this *Validator) OnCompactTime(value compact_time.Time) {
+	_this.onValue(TypeTime, value)
+	_this.next.OnCompactTime(value)
+}
+
+func (_this *Validator) OnUUID(value []byte) {
+	_this.onValue(TypeUUID, nil)
+	_this.next.OnUUID(value)
+}
+
+func (_this *Validator) OnList() {
+	_this.onContainer(TypeList)
+	_this.next.OnList()
+}
+
+func (_this *Validator) OnMap() {
+	_this.onContainer(TypeMap)
+	_this.next.OnMap()
+}
+
+func (_this *Validator) OnMarkup() {
+	_this.onContainer(TypeMarkup)
+	_this.next.OnMarkup()
+}
+
+func (_this *Validator) OnMetadata() {
+	_this.beginSkip(1, false)
+	_this.next.OnMetadata()
+}
+
+func (_this *Validator) OnComment() {
+	_this.beginSkip(1, false)
+	_this.next.OnComment()
+}
+
+func (_this *Validator) OnEnd() {
+	_this.onEnd()
+	_this.next.OnEnd()
+}
+
+func (_this *Validator) OnMarker() {
+	if !_this.isSkipping() {
+		_this.pending = pendingMarkerID
+	}
+	_this.next.OnMarker()
+}
+
+func (_this *Validator) OnReference() {
+	if !_this.isSkipping() {
+		_this.pending = pendingReferenceID
+	}
+	_this.next.OnReference()
+}
+
+func (_this *Validator) OnConcatenate() {
+	if !_this.isSkipping() {
+		_this.pending = pendingConcatBase
+	}
+	_this.next.OnConcatenate()
+}
+
+func (_this *Validator) OnConstant(name []byte, explicitValue bool) {
+	if !explicitValue && !_this.isSkipping() {
+		// The value is unknown, so there's nothing to validate.
+		_this.completeValue("#" + string(name))
+	}
+	_this.next.OnConstant(name, explicitValue)
+}
+
+func (_this *Validator) OnArray(arrayType events.ArrayType, elementCount uint64, data []uint8) {
+	_this.onArray(arrayType, elementCount, data)
+	_this.next.OnArray(arrayType, elementCount, data)
+}
+
+func (_this *Validator) OnStringlikeArray(arrayType events.ArrayType, data string) {
+	_this.onStringlikeArray(arrayType, data)
+	_this.next.OnStringlikeArray(arrayType, data)
+}
+
+func (_this *Validator) OnArrayBegin(arrayType events.ArrayType) {
+	_this.chunkedType = arrayType
+	_this.chunkedCount = 0
+	_this.chunkedData = _this.chunkedData[:0]
+	_this.chunkBitSize = arrayType.ElementSize()
+	_this.next.OnArrayBegin(arrayType)
+}
+
+func (_this *Validator) OnArrayChunk(length uint64, moreChunksFollow bool) {
+	_this.chunkRemains = common.ElementCountToByteCount(_this.chunkBitSize, length)
+	_this.chunkedCount += length
+	_this.moreChunks = moreChunksFollow
+	if !moreChunksFollow && _this.chunkRemains == 0 {
+		_this.completeChunkedArray()
+	}
+	_this.next.OnArrayChunk(length, moreChunksFollow)
+}
+
+func (_this *Validator) OnArrayData(data []byte) {
+	_this.chunkedData = append(_this.chunkedData, data...)
+	_this.chunkRemains -= uint64(len(data))
+	if !_this.moreChunks && _this.chunkRemains == 0 {
+		_this.completeChunkedArray()
+	}
+	_this.next.OnArrayData(data)
+}
+
+// ============================================================================
+// Internal
+
+type pendingMode int
+
+const (
+	pendingNone pendingMode = iota
+	pendingMarkerID
+	pendingReferenceID
+	pendingConcatBase
+	pendingConcatSuffix
+)
+
+type frameType int
+
+const (
+	frameTypeList frameType = iota
+	frameTypeMap
+	frameTypeSkip
+)
+
+type validatorFrame struct {
+	frameType frameType
+	schema    *Schema
+	path      string
+	count     uint64
+
+	// Maps
+	isKey      bool
+	keyPath    string
+	keyName    string
+	isNamedKey bool
+	seenKeys   map[string]bool
+
+	// Skipped containers
+	remainingEnds  int
+	completesValue bool
+}
+
+func (_this *Validator) topFrame() *validatorFrame {
+	if len(_this.frames) == 0 {
+		return nil
+	}
+	return &_this.frames[len(_this.frames)-1]
+}
+
+func (_this *Validator) isSkipping() bool {
+	frame := _this.topFrame()
+	return frame != nil && frame.frameType == frameTypeSkip
+}
+
+// Get the schema and path of the next value to be received.
+func (_this *Validator) nextSchema() (schema *Schema, path string) {
+	frame := _this.topFrame()
+	if frame == nil {
+		return _this.schema, ""
+	}
+	if frame.schema == nil {
+		return nil, ""
+	}
+	switch frame.frameType {
+	case frameTypeList:
+		return frame.schema.Elements, fmt.Sprintf("%v[%v]", frame.path, frame.count)
+	case frameTypeMap:
+		if frame.isKey {
+			return frame.schema.Keys, frame.path
+		}
+		if frame.isNamedKey {
+			if field, ok := frame.schema.Fields[frame.keyName]; ok {
+				return field, frame.keyPath
+			}
+		}
+		return frame.schema.Values, frame.keyPath
+	default:
+		return nil, ""
+	}
+}
+
+// Mark the current value as complete, moving on to the next value in the
+// current container.
+func (_this *Validator) completeValue(description string) {
+	frame := _this.topFrame()
+	if frame == nil {
+		return
+	}
+	switch frame.frameType {
+	case frameTypeList:
+		frame.count++
+	case frameTypeMap:
+		if frame.isKey {
+			frame.keyPath = fmt.Sprintf("%v[%v]", frame.path, description)
+			frame.isNamedKey = false
+			if frame.schema != nil && frame.schema.Closed {
+				_this.addViolation(frame.path, "key %v is not allowed", description)
+			}
+		} else {
+			frame.count++
+		}
+		frame.isKey = !frame.isKey
+	}
+}
+
+// Complete a value that can be looked up in a schema's fields if it's a map
+// key. Field names are strings or non-negative integers (in decimal).
+func (_this *Validator) completeNamedKey(name string, isString bool) {
+	frame := _this.topFrame()
+	if frame != nil && frame.frameType == frameTypeMap && frame.isKey {
+		switch {
+		case !isString:
+			frame.keyPath = fmt.Sprintf("%v[%v]", frame.path, name)
+		case frame.path == "":
+			frame.keyPath = name
+		default:
+			frame.keyPath = frame.path + "." + name
+		}
+		frame.keyName = name
+		frame.isNamedKey = true
+		frame.seenKeys[name] = true
+		frame.isKey = false
+		return
+	}
+	if isString {
+		name = fmt.Sprintf("%q", name)
+	}
+	_this.completeValue(name)
+}
+
+func (_this *Validator) addViolation(path string, format string, args ...interface{}) {
+	_this.violations = append(_this.violations, Violation{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (_this *Validator) beginSkip(remainingEnds int, completesValue bool) {
+	frame := _this.topFrame()
+	if frame != nil && frame.frameType == frameTypeSkip {
+		frame.remainingEnds += remainingEnds
+		return
+	}
+	_this.frames = append(_this.frames, validatorFrame{
+		frameType:      frameTypeSkip,
+		remainingEnds:  remainingEnds,
+		completesValue: completesValue,
+	})
+}
+
+func (_this *Validator) onValue(valueType string, value interface{}) {
+	if _this.isSkipping() {
+		return
+	}
+
+	switch _this.pending {
+	case pendingMarkerID:
+		// A marker ID precedes the value it marks.
+		_this.pending = pendingNone
+		_this.markerID = fmt.Sprintf("%v", value)
+		_this.hasMarkerID = true
+		return
+	case pendingReferenceID:
+		_this.pending = pendingNone
+		_this.onReference(valueType, value)
+		return
+	case pendingConcatBase:
+		_this.concatBase = fmt.Sprintf("%v", value)
+		_this.pending = pendingConcatSuffix
+		return
+	case pendingConcatSuffix:
+		_this.pending = pendingNone
+		valueType = TypeResourceID
+		value = _this.concatBase + fmt.Sprintf("%v", value)
+	}
+
+	schema, path := _this.nextSchema()
+	_this.checkValue(schema, path, valueType, value)
+	_this.notifyMarkedObject(markedObject{valueType: valueType, value: value})
+	_this.completeScalar(valueType, value)
+}
+
+// Complete a scalar value, which might also be a map key.
+func (_this *Validator) completeScalar(valueType string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		if valueType == TypeString {
+			_this.completeNamedKey(v, true)
+			return
+		}
+	case uint64:
+		_this.completeNamedKey(strconv.FormatUint(v, 10), false)
+		return
+	case int64:
+		if v >= 0 {
+			_this.completeNamedKey(strconv.FormatInt(v, 10), false)
+			return
+		}
+	}
+	_this.completeValue(describeValue(value))
+}
+
+func (_this *Validator) onContainer(valueType string) {
+	if _this.isSkipping() {
+		if valueType == TypeMarkup {
+			// Markup ends twice: once for the attributes, and once for the
+			// contents.
+			_this.beginSkip(2, true)
+		} else {
+			_this.beginSkip(1, true)
+		}
+		return
+	}
+
+	schema, path := _this.nextSchema()
+	if !_this.checkType(schema, path, valueType) {
+		schema = nil
+	}
+	_this.notifyMarkedObject(markedObject{valueType: valueType, isContainer: true, schema: schema})
+
+	switch valueType {
+	case TypeList:
+		_this.frames = append(_this.frames, validatorFrame{
+			frameType: frameTypeList,
+			schema:    schema,
+			path:      path,
+		})
+	case TypeMap:
+		_this.frames = append(_this.frames, validatorFrame{
+			frameType: frameTypeMap,
+			schema:    schema,
+			path:      path,
+			isKey:     true,
+			seenKeys:  make(map[string]bool),
+		})
+	case TypeMarkup:
+		_this.beginSkip(2, true)
+	}
+}
+
+// An object that has been marked, so that references to it can be validated.
+// Lists, maps and markup keep the schema their contents were validated
+// against (if any) rather than their contents.
+type markedObject struct {
+	valueType   string
+	value       interface{}
+	isContainer bool
+	schema      *Schema
+}
+
+// A reference to a marker that hasn't been seen yet, which is validated once
+// the marked object is known.
+type forwardReference struct {
+	schema *Schema
+	path   string
+}
+
+func (_this *Validator) notifyMarkedObject(object markedObject) {
+	if !_this.hasMarkerID {
+		return
+	}
+	id := _this.markerID
+	_this.hasMarkerID = false
+	_this.markedObjects[id] = object
+	for _, reference := range _this.forwardReferences[id] {
+		_this.checkReference(reference.schema, reference.path, object)
+	}
+	delete(_this.forwardReferences, id)
+}
+
+func (_this *Validator) onReference(valueType string, value interface{}) {
+	schema, path := _this.nextSchema()
+	description := fmt.Sprintf("$%v", value)
+	if valueType == TypeResourceID {
+		// The referenced document isn't available to validate
+		if !isUnconstrained(schema) {
+			_this.addViolation(path, "cannot validate referenced document %v", value)
+		}
+		_this.completeValue(description)
+		return
+	}
+
+	id := fmt.Sprintf("%v", value)
+	object, ok := _this.markedObjects[id]
+	if !ok {
+		// The key name of a forward reference isn't known yet, so it doesn't
+		// count towards required keys.
+		_this.forwardReferences[id] = append(_this.forwardReferences[id], forwardReference{schema, path})
+		_this.completeValue(description)
+		return
+	}
+
+	_this.checkReference(schema, path, object)
+	if object.isContainer {
+		_this.completeValue(description)
+		return
+	}
+	_this.completeScalar(object.valueType, object.value)
+}
+
+// Check a referenced object against the schema at the reference's position.
+func (_this *Validator) checkReference(schema *Schema, path string, object markedObject) {
+	if !object.isContainer {
+		_this.checkValue(schema, path, object.valueType, object.value)
+		return
+	}
+	if !_this.checkType(schema, path, object.valueType) || object.valueType == TypeMarkup {
+		return
+	}
+	if object.schema == nil || !hasSameConstraints(schema, object.schema) {
+		_this.addViolation(path, "referenced %v was not validated against this schema", object.valueType)
+	}
+}
+
+// Returns true if a value at a position with this schema isn't checked.
+func isUnconstrained(schema *Schema) bool {
+	return schema == nil || hasSameConstraints(schema, &Schema{Type: TypeAny})
+}
+
+// Returns true if both schemas check values in the same way (ignoring
+// nullability). Nested schemas must be the same instances.
+func hasSameConstraints(a *Schema, b *Schema) bool {
+	if a == b {
+		return true
+	}
+	typeOf := func(schema *Schema) string {
+		if schema.Type == "" {
+			return TypeAny
+		}
+		return schema.Type
+	}
+	return typeOf(a) == typeOf(b) &&
+		reflect.DeepEqual(a.Min, b.Min) &&
+		reflect.DeepEqual(a.Max, b.Max) &&
+		reflect.DeepEqual(a.MinLength, b.MinLength) &&
+		reflect.DeepEqual(a.MaxLength, b.MaxLength) &&
+		a.Pattern == b.Pattern &&
+		reflect.DeepEqual(a.Enum, b.Enum) &&
+		a.ElementType == b.ElementType &&
+		a.Elements == b.Elements &&
+		reflect.ValueOf(a.Fields).Pointer() == reflect.ValueOf(b.Fields).Pointer() &&
+		reflect.DeepEqual(a.Required, b.Required) &&
+		a.Closed == b.Closed &&
+		a.Keys == b.Keys &&
+		a.Values == b.Values
+}
+
+func (_this *Validator) onEnd() {
+	frame := _this.topFrame()
+	if frame == nil {
+		return
+	}
+
+	switch frame.frameType {
+	case frameTypeSkip:
+		frame.remainingEnds--
+		if frame.remainingEnds > 0 {
+			return
+		}
+		completesValue := frame.completesValue
+		_this.frames = _this.frames[:len(_this.frames)-1]
+		if completesValue {
+			_this.completeValue("<markup>")
+		}
+		return
+	case frameTypeList:
+		if frame.schema != nil {
+			_this.checkLength(frame.schema, frame.path, frame.count, "elements")
+		}
+	case frameTypeMap:
+		if frame.schema != nil {
+			_this.checkMap(frame)
+		}
+	}
+	_this.frames = _this.frames[:len(_this.frames)-1]
+	_this.completeValue("")
+}
+
+func (_this *Validator) onStringlikeArray(arrayType events.ArrayType, data string) {
+	switch arrayType {
+	case events.ArrayTypeString:
+		_this.onValue(TypeString, data)
+	case events.ArrayTypeResourceID:
+		_this.onValue(TypeResourceID, data)
+	case events.ArrayTypeResourceIDConcat:
+		if !_this.isSkipping() {
+			_this.pending = pendingConcatBase
+		}
+		_this.onValue(TypeResourceID, data)
+	case events.ArrayTypeCustomText:
+		_this.onValue(TypeCustom, nil)
+	default:
+		_this.onArray(arrayType, uint64(len(data)), []byte(data))
+	}
+}
+
+func (_this *Validator) onArray(arrayType events.ArrayType, elementCount uint64, data []byte) {
+	switch arrayType {
+	case events.ArrayTypeString, events.ArrayTypeResourceID, events.ArrayTypeResourceIDConcat, events.ArrayTypeCustomText:
+		_this.onStringlikeArray(arrayType, string(data))
+	case events.ArrayTypeCustomBinary:
+		_this.onValue(TypeCustom, nil)
+	default:
+		_this.onValue(TypeArray, typedArray{arrayType, elementCount})
+	}
+}
+
+func (_this *Validator) completeChunkedArray() {
+	_this.onArray(_this.chunkedType, _this.chunkedCount, _this.chunkedData)
+	_this.chunkedData = _this.chunkedData[:0]
+}
+
+type typedArray struct {
+	arrayType    events.ArrayType
+	elementCount uint64
+}
+
+var arrayElementTypes = map[events.ArrayType]string{
+	events.ArrayTypeBoolean: ElementTypeBit,
+	events.ArrayTypeUint8:   ElementTypeU8,
+	events.ArrayTypeUint16:  ElementTypeU16,
+	events.ArrayTypeUint32:  ElementTypeU32,
+	events.ArrayTypeUint64:  ElementTypeU64,
+	events.ArrayTypeInt8:    ElementTypeI8,
+	events.ArrayTypeInt16:   ElementTypeI16,
+	events.ArrayTypeInt32:   ElementTypeI32,
+	events.ArrayTypeInt64:   ElementTypeI64,
+	events.ArrayTypeFloat16: ElementTypeF16,
+	events.ArrayTypeFloat32: ElementTypeF32,
+	events.ArrayTypeFloat64: ElementTypeF64,
+	events.ArrayTypeUUID:    ElementTypeUUID,
+}
+
+// Check that a value's type matches the schema. Returns false if the value
+// shouldn't be checked any further.
+func (_this *Validator) checkType(schema *Schema, path string, valueType string) bool {
+	if schema == nil {
+		return false
+	}
+	switch schema.Type {
+	case "", TypeAny, valueType:
+		return true
+	}
+	if valueType == TypeNull && schema.Nullable {
+		return false
+	}
+	if valueType == TypeInt && schema.Type == TypeFloat {
+		return true
+	}
+	_this.addViolation(path, "expected %v but got %v", schema.Type, valueType)
+	return false
+}
+
+func (_this *Validator) checkValue(schema *Schema, path string, valueType string, value interface{}) {
+	if !_this.checkType(schema, path, valueType) {
+		return
+	}
+	compiled := _this.compiled[schema]
+
+	switch valueType {
+	case TypeInt, TypeFloat:
+		_this.checkRange(compiled, path, value)
+	case TypeString, TypeResourceID:
+		str := value.(string)
+		_this.checkLength(schema, path, uint64(utf8.RuneCountInString(str)), "characters")
+		if compiled.pattern != nil && !compiled.pattern.MatchString(str) {
+			_this.addViolation(path, "%q does not match pattern %q", str, schema.Pattern)
+		}
+	case TypeArray:
+		array := value.(typedArray)
+		if schema.ElementType != "" && arrayElementTypes[array.arrayType] != schema.ElementType {
+			_this.addViolation(path, "expected an array of %v but got an array of %v",
+				schema.ElementType, arrayElementTypes[array.arrayType])
+		}
+		_this.checkLength(schema, path, array.elementCount, "elements")
+		return
+	}
+
+	if len(compiled.enum) > 0 {
+		normalized := normalizeEnumValue(value)
+		if normalized == nil || !enumContains(compiled.enum, normalized) {
+			_this.addViolation(path, "%v is not one of the allowed values %v", describeValue(value), schema.Enum)
+		}
+	}
+}
+
+func (_this *Validator) checkRange(compiled *compiledSchema, path string, value interface{}) {
+	if compiled.min == nil && compiled.max == nil {
+		return
+	}
+	number := numberToBigFloat(value)
+	if number == nil {
+		_this.addViolation(path, "NaN is not within the allowed range")
+		return
+	}
+	if compiled.min != nil && number.Cmp(compiled.min) < 0 {
+		_this.addViolation(path, "%v is less than the minimum %v", describeValue(value), compiled.min.Text('g', -1))
+	}
+	if compiled.max != nil && number.Cmp(compiled.max) > 0 {
+		_this.addViolation(path, "%v is greater than the maximum %v", describeValue(value), compiled.max.Text('g', -1))
+	}
+}
+
+func (_this *Validator) checkLength(schema *Schema, path string, length uint64, unit string) {
+	if schema.MinLength != nil && length < *schema.MinLength {
+		_this.addViolation(path, "length %v %v is less than the minimum %v", length, unit, *schema.MinLength)
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		_this.addViolation(path, "length %v %v is greater than the maximum %v", length, unit, *schema.MaxLength)
+	}
+}
+
+func (_this *Validator) checkMap(frame *validatorFrame) {
+	schema := frame.schema
+	_this.checkLength(schema, frame.path, frame.count, "entries")
+	for _, key := range schema.Required {
+		if !frame.seenKeys[key] {
+			_this.addViolation(frame.path, "missing required key %q", key)
+		}
+	}
+	if schema.Closed {
+		keys := make([]string, 0, len(frame.seenKeys))
+		for key := range frame.seenKeys {
+			if _, ok := schema.Fields[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			_this.addViolation(frame.path, "key %q is not allowed", key)
+		}
+	}
+}
+
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("%q", v)
+	case *big.Float:
+		return v.Text('g', -1)
+	case typedArray:
+		return fmt.Sprintf("%v array", v.arrayType)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func describePath(path string) string {
+	if path == "" {
+		return "top level"
+	}
+	return path
+}
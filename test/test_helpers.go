@@ -284,7 +284,7 @@ var (
 	EvREF    = REF()
 	EvCAT    = CAT()
 	EvAC     = AC(1, false)
-	EvAD     = AD([]byte{1})
+	EvAD     = AD([]byte{'a'}) // Not a control character, so valid in comments, markup, and RIDs
 	EvS      = S("a")
 	EvSB     = SB()
 	EvRID    = RID("http://z.com")
@@ -340,7 +340,7 @@ var Completions = map[*TEvent][]*TEvent{
 	EvMUP:   []*TEvent{S("a"), EvE, EvE},
 	EvMARK:  []*TEvent{S("a"), S("m")},
 	EvREF:   []*TEvent{S("a")},
-	EvCAT:   []*TEvent{S("a")},
+	EvCAT:   []*TEvent{RID("http://x.com/"), S("a")},
 	EvPAD:   []*TEvent{S("a")},
 	EvSB:    []*TEvent{AC(0, false)},
 	EvRB:    []*TEvent{AC(0, false)},
@@ -470,26 +470,26 @@ var (
 	}
 
 	ValidTLOValues   = ComplementaryEvents(InvalidTLOValues)
-	InvalidTLOValues = []*TEvent{EvBD, EvED, EvV, EvE, EvAC, EvAD, EvCAT}
+	InvalidTLOValues = []*TEvent{EvBD, EvED, EvV, EvE, EvAC, EvAD}
 
 	ValidMapKeys = []*TEvent{
 		EvPAD, EvB, EvTT, EvFF, EvPI, EvNI, EvI, EvBI, EvF, EvBF, EvDF, EvBDF,
-		EvUUID, EvGT, EvCT, EvMARK, EvS, EvSB, EvRID, EvRB, EvREF, EvMETA, EvCMT, EvE,
+		EvUUID, EvGT, EvCT, EvMARK, EvS, EvSB, EvRID, EvRB, EvREF, EvCAT, EvMETA, EvCMT, EvE,
 	}
 	InvalidMapKeys = ComplementaryEvents(ValidMapKeys)
 
 	ValidMapValues   = ComplementaryEvents(InvalidMapValues)
-	InvalidMapValues = []*TEvent{EvBD, EvED, EvV, EvE, EvAC, EvAD, EvCAT}
+	InvalidMapValues = []*TEvent{EvBD, EvED, EvV, EvE, EvAC, EvAD}
 
 	ValidListValues   = ComplementaryEvents(InvalidListValues)
-	InvalidListValues = []*TEvent{EvBD, EvED, EvV, EvAC, EvAD, EvCAT}
+	InvalidListValues = []*TEvent{EvBD, EvED, EvV, EvAC, EvAD}
 
 	ValidCommentValues   = []*TEvent{EvCMT, EvE, EvS, EvSB, EvPAD}
 	InvalidCommentValues = ComplementaryEvents(ValidCommentValues)
 
 	ValidMarkupNames = []*TEvent{
 		EvPAD, EvB, EvTT, EvFF, EvPI, EvNI, EvI, EvBI, EvF, EvBF, EvDF, EvBDF,
-		EvUUID, EvGT, EvCT, EvMARK, EvREF, EvS, EvSB, EvRID, EvRB,
+		EvUUID, EvGT, EvCT, EvMARK, EvREF, EvS, EvSB, EvRID, EvRB, EvCAT,
 	}
 	InvalidMarkupNames = ComplementaryEvents(ValidMarkupNames)
 
@@ -506,7 +506,7 @@ var (
 	InvalidMarkerIDs = ComplementaryEvents(ValidMarkerIDs)
 
 	ValidMarkerValues   = ComplementaryEvents(InvalidMarkerValues)
-	InvalidMarkerValues = []*TEvent{EvBD, EvED, EvV, EvE, EvAC, EvAD, EvMETA, EvCMT, EvMARK, EvCAT}
+	InvalidMarkerValues = []*TEvent{EvBD, EvED, EvV, EvE, EvAC, EvAD, EvMETA, EvCMT, EvMARK}
 
 	ValidReferenceIDs   = []*TEvent{EvPAD, EvS, EvSB, EvPI, EvI, EvBI, EvRID, EvRB}
 	InvalidReferenceIDs = ComplementaryEvents(ValidReferenceIDs)
@@ -937,7 +937,7 @@ func (_this *TEvent) Invoke(receiver events.DataEventReceiver) {
 		bytes := arrays.Uint64SliceAsBytes(_this.V1.([]uint64))
 		receiver.OnArray(events.ArrayTypeUint64, uint64(len(bytes)/8), bytes)
 	case TEventArrayFloat16:
-		// TODO: How to handle float16 in go code?
+		// Float16 array elements are bfloat16 (see types.BFloat16), passed as raw bytes.
 		bytes := _this.V1.([]byte)
 		receiver.OnArray(events.ArrayTypeFloat16, uint64(len(bytes)/2), bytes)
 	case TEventArrayFloat32:
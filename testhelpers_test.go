@@ -148,6 +148,7 @@ func CMT() *test.TEvent                      { return test.CMT() }
 func E() *test.TEvent                        { return test.E() }
 func MARK() *test.TEvent                     { return test.MARK() }
 func REF() *test.TEvent                      { return test.REF() }
+func CAT() *test.TEvent                      { return test.CAT() }
 func BD() *test.TEvent                       { return test.BD() }
 func ED() *test.TEvent                       { return test.ED() }
 
@@ -440,8 +441,8 @@ func assertCBEMarshalUnmarshal(t *testing.T, expected interface{}) {
 	marshalOptions := options.DefaultCBEMarshalerOptions()
 	unmarshalOptions := options.DefaultCBEUnmarshalerOptions()
 	assertCBEMarshalUnmarshalWithOptions(t, marshalOptions, unmarshalOptions, expected)
-	// marshalOptions.Iterator.RecursionSupport = true
-	// assertCBEMarshalUnmarshalWithOptions(t, marshalOptions, unmarshalOptions, expected)
+	marshalOptions.Iterator.RecursionSupport = true
+	assertCBEMarshalUnmarshalWithOptions(t, marshalOptions, unmarshalOptions, expected)
 }
 
 func assertCBEMarshalUnmarshalWithOptions(t *testing.T,
@@ -0,0 +1,141 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package types
+
+import (
+	"math"
+	"strconv"
+)
+
+// Float16 is an IEEE 754 binary16 (half precision) floating point value,
+// stored as its raw bits.
+//
+// Concise Encoding's float16 arrays use the bfloat16 format, which has the
+// same range as float32 but fewer significant bits than Float16. Iterators
+// therefore widen Float16 arrays to float32 arrays so that no information is
+// lost. Use BFloat16 to produce float16 arrays directly.
+type Float16 uint16
+
+// Convert a float32 to the nearest Float16 value (rounding half to even).
+// Values too large for a Float16 become infinity.
+func Float16FromFloat32(value float32) Float16 {
+	bits := math.Float32bits(value)
+	sign := uint16(bits>>16) & 0x8000
+	exponent := int((bits >> 23) & 0xff)
+	significand := bits & 0x7fffff
+
+	if exponent == 0xff {
+		if significand != 0 {
+			// Keep the quiet bit and the high payload bits.
+			return Float16(sign | 0x7c00 | uint16(significand>>13) | 0x200)
+		}
+		return Float16(sign | 0x7c00)
+	}
+
+	exponent = exponent - 127 + 15
+	if exponent >= 0x1f {
+		return Float16(sign | 0x7c00)
+	}
+
+	if exponent <= 0 {
+		// Subnormal or zero
+		shift := uint(14 - exponent)
+		if shift > 24 {
+			return Float16(sign)
+		}
+		significand |= 0x800000
+		return Float16(sign | uint16(roundShiftHalfEven(significand, shift)))
+	}
+
+	result := (uint32(exponent) << 10) | (significand >> 13)
+	remainder := significand & 0x1fff
+	if remainder > 0x1000 || (remainder == 0x1000 && result&1 == 1) {
+		// A carry into the exponent is correct here (up to and including infinity).
+		result++
+	}
+	return Float16(sign | uint16(result))
+}
+
+func roundShiftHalfEven(value uint32, shift uint) uint32 {
+	half := uint32(1) << (shift - 1)
+	remainder := value & ((1 << shift) - 1)
+	result := value >> shift
+	if remainder > half || (remainder == half && result&1 == 1) {
+		result++
+	}
+	return result
+}
+
+// Convert to a float32. This conversion is always exact.
+func (_this Float16) Float32() float32 {
+	sign := uint32(_this&0x8000) << 16
+	exponent := uint32(_this>>10) & 0x1f
+	significand := uint32(_this & 0x3ff)
+
+	switch exponent {
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | (significand << 13))
+	case 0:
+		if significand == 0 {
+			return math.Float32frombits(sign)
+		}
+		exponent = 127 - 14
+		for significand&0x400 == 0 {
+			significand <<= 1
+			exponent--
+		}
+		significand &= 0x3ff
+		return math.Float32frombits(sign | (exponent << 23) | (significand << 13))
+	default:
+		return math.Float32frombits(sign | ((exponent - 15 + 127) << 23) | (significand << 13))
+	}
+}
+
+func (_this Float16) String() string {
+	return strconv.FormatFloat(float64(_this.Float32()), 'g', -1, 32)
+}
+
+// BFloat16 is a "brain floating point" value: the upper 16 bits of an IEEE 754
+// binary32 (float32), stored as its raw bits. It has the same range as
+// float32, but only 8 significant bits.
+//
+// This is the element format of Concise Encoding float16 arrays.
+type BFloat16 uint16
+
+// Convert a float32 to the nearest BFloat16 value (rounding half to even).
+func BFloat16FromFloat32(value float32) BFloat16 {
+	bits := math.Float32bits(value)
+	if value != value {
+		// Keep NaNs as NaNs, quieting them in case the payload was truncated.
+		return BFloat16((bits >> 16) | 0x40)
+	}
+	bits += 0x7fff + ((bits >> 16) & 1)
+	return BFloat16(bits >> 16)
+}
+
+// Convert to a float32. This conversion is always exact.
+func (_this BFloat16) Float32() float32 {
+	return math.Float32frombits(uint32(_this) << 16)
+}
+
+func (_this BFloat16) String() string {
+	return strconv.FormatFloat(float64(_this.Float32()), 'g', -1, 32)
+}
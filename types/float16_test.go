@@ -0,0 +1,82 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+func assertFloat16Conversion(t *testing.T, value float32, expectedBits uint16, expectedValue float32) {
+	actual := Float16FromFloat32(value)
+	if uint16(actual) != expectedBits {
+		t.Errorf("Float16FromFloat32(%v): expected bits %04x but got %04x", value, expectedBits, uint16(actual))
+	}
+	if actual.Float32() != expectedValue {
+		t.Errorf("Float16(%04x).Float32(): expected %v but got %v", uint16(actual), expectedValue, actual.Float32())
+	}
+}
+
+func TestFloat16(t *testing.T) {
+	assertFloat16Conversion(t, 0, 0x0000, 0)
+	assertFloat16Conversion(t, 1, 0x3c00, 1)
+	assertFloat16Conversion(t, -2, 0xc000, -2)
+	assertFloat16Conversion(t, 0.5, 0x3800, 0.5)
+	assertFloat16Conversion(t, 65504, 0x7bff, 65504)
+	assertFloat16Conversion(t, 65520, 0x7c00, float32(math.Inf(1)))
+	assertFloat16Conversion(t, 1e10, 0x7c00, float32(math.Inf(1)))
+	assertFloat16Conversion(t, float32(math.Inf(-1)), 0xfc00, float32(math.Inf(-1)))
+	assertFloat16Conversion(t, 6.103515625e-05, 0x0400, 6.103515625e-05)
+	assertFloat16Conversion(t, 5.960464477539063e-08, 0x0001, 5.960464477539063e-08)
+	assertFloat16Conversion(t, 3e-08, 0x0001, 5.960464477539063e-08)
+	assertFloat16Conversion(t, 2.9e-08, 0x0000, 0)
+	// Round half to even
+	assertFloat16Conversion(t, 1.00048828125, 0x3c00, 1)
+	assertFloat16Conversion(t, 1.00146484375, 0x3c02, 1.001953125)
+
+	nan := Float16FromFloat32(float32(math.NaN()))
+	if f := nan.Float32(); f == f {
+		t.Errorf("Expected NaN but got %v", f)
+	}
+}
+
+func TestBFloat16(t *testing.T) {
+	assertBFloat16 := func(value float32, expectedBits uint16, expectedValue float32) {
+		actual := BFloat16FromFloat32(value)
+		if uint16(actual) != expectedBits {
+			t.Errorf("BFloat16FromFloat32(%v): expected bits %04x but got %04x", value, expectedBits, uint16(actual))
+		}
+		if actual.Float32() != expectedValue {
+			t.Errorf("BFloat16(%04x).Float32(): expected %v but got %v", uint16(actual), expectedValue, actual.Float32())
+		}
+	}
+	assertBFloat16(0, 0x0000, 0)
+	assertBFloat16(1, 0x3f80, 1)
+	assertBFloat16(-1.5, 0xbfc0, -1.5)
+	assertBFloat16(1e38, 0x7e96, 9.969209968386869e+37)
+	assertBFloat16(1.00390625, 0x3f80, 1)
+	assertBFloat16(1.01171875, 0x3f82, 1.015625)
+
+	nan := BFloat16FromFloat32(float32(math.NaN()))
+	if f := nan.Float32(); f == f {
+		t.Errorf("Expected NaN but got %v", f)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Number is a numeric value stored as its exact decimal text (such as "-100"
+// or "18446744073709551616"), much like json.Number. Builders can produce a
+// Number when building integers into an interface{} so that no precision or
+// type information is lost, and iterators emit a Number as the numeric value
+// it contains.
+type Number string
+
+// Get the value as an int64. An error is returned if the value is not an
+// integer or doesn't fit.
+func (_this Number) Int64() (int64, error) {
+	return strconv.ParseInt(string(_this), 10, 64)
+}
+
+// Get the value as a uint64. An error is returned if the value is not a
+// non-negative integer or doesn't fit.
+func (_this Number) Uint64() (uint64, error) {
+	return strconv.ParseUint(string(_this), 10, 64)
+}
+
+// Get the value as a big integer. An error is returned if the value is not an
+// integer.
+func (_this Number) BigInt() (*big.Int, error) {
+	value, ok := new(big.Int).SetString(string(_this), 10)
+	if !ok {
+		return nil, fmt.Errorf("%v is not an integer", string(_this))
+	}
+	return value, nil
+}
+
+// Get the value as a float64 (rounding if necessary).
+func (_this Number) Float64() (float64, error) {
+	return strconv.ParseFloat(string(_this), 64)
+}
+
+func (_this Number) String() string {
+	return string(_this)
+}
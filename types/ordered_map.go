@@ -0,0 +1,106 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package types
+
+// A key-value pair stored in an OrderedMap.
+type MapEntry struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// OrderedMap is a map that remembers the order in which its keys were
+// inserted. Builders fill an OrderedMap in document order, and iterators emit
+// its entries in order, so key order survives a decode-edit-encode cycle.
+// Builders can also produce an OrderedMap when building a map into an
+// interface{} (including maps nested inside an OrderedMap) if
+// options.BuilderOptions.InterfaceMapType is InterfaceMapTypeOrdered.
+//
+// Keys must be comparable (as with go map keys). The zero value is an empty
+// map ready to use.
+type OrderedMap struct {
+	entries []MapEntry
+	indices map[interface{}]int
+}
+
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{
+		indices: make(map[interface{}]int),
+	}
+}
+
+// Get the number of entries in the map.
+func (_this *OrderedMap) Len() int {
+	return len(_this.entries)
+}
+
+// Get the value stored under key.
+func (_this *OrderedMap) Get(key interface{}) (value interface{}, ok bool) {
+	index, ok := _this.indices[key]
+	if !ok {
+		return nil, false
+	}
+	return _this.entries[index].Value, true
+}
+
+// Store a value under key. Replacing the value of an existing key keeps its
+// position in the map; new keys are added to the end.
+func (_this *OrderedMap) Set(key interface{}, value interface{}) {
+	if _this.indices == nil {
+		_this.indices = make(map[interface{}]int)
+	}
+	if index, ok := _this.indices[key]; ok {
+		_this.entries[index].Value = value
+		return
+	}
+	_this.indices[key] = len(_this.entries)
+	_this.entries = append(_this.entries, MapEntry{Key: key, Value: value})
+}
+
+// Remove key from the map, returning false if it wasn't present. The
+// remaining keys keep their order.
+func (_this *OrderedMap) Delete(key interface{}) bool {
+	index, ok := _this.indices[key]
+	if !ok {
+		return false
+	}
+	delete(_this.indices, key)
+	_this.entries = append(_this.entries[:index], _this.entries[index+1:]...)
+	for i := index; i < len(_this.entries); i++ {
+		_this.indices[_this.entries[i].Key] = i
+	}
+	return true
+}
+
+// Get the map's keys in order.
+func (_this *OrderedMap) Keys() []interface{} {
+	keys := make([]interface{}, len(_this.entries))
+	for i, entry := range _this.entries {
+		keys[i] = entry.Key
+	}
+	return keys
+}
+
+// Get a copy of the map's entries in order.
+func (_this *OrderedMap) Entries() []MapEntry {
+	entries := make([]MapEntry, len(_this.entries))
+	copy(entries, _this.entries)
+	return entries
+}
@@ -0,0 +1,71 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func assertOrderedMapKeys(t *testing.T, m *OrderedMap, expected ...interface{}) {
+	if !reflect.DeepEqual(m.Keys(), expected) {
+		t.Errorf("Expected keys %v but got %v", expected, m.Keys())
+	}
+	if m.Len() != len(expected) {
+		t.Errorf("Expected length %v but got %v", len(expected), m.Len())
+	}
+}
+
+func TestOrderedMap(t *testing.T) {
+	var m OrderedMap
+	m.Set("c", 1)
+	m.Set("a", 2)
+	m.Set(10, 3)
+	assertOrderedMapKeys(t, &m, "c", "a", 10)
+
+	// Replacing a value keeps the key's position
+	m.Set("c", 4)
+	assertOrderedMapKeys(t, &m, "c", "a", 10)
+	if v, ok := m.Get("c"); !ok || v != 4 {
+		t.Errorf("Expected 4 but got %v", v)
+	}
+
+	if !m.Delete("c") {
+		t.Errorf("Expected delete to succeed")
+	}
+	if m.Delete("c") {
+		t.Errorf("Expected second delete to fail")
+	}
+	assertOrderedMapKeys(t, &m, "a", 10)
+	if v, ok := m.Get(10); !ok || v != 3 {
+		t.Errorf("Expected 3 but got %v", v)
+	}
+	if _, ok := m.Get("c"); ok {
+		t.Errorf("Expected c to be absent")
+	}
+
+	m.Set("c", 5)
+	assertOrderedMapKeys(t, &m, "a", 10, "c")
+	expected := []MapEntry{{"a", 2}, {10, 3}, {"c", 5}}
+	if !reflect.DeepEqual(m.Entries(), expected) {
+		t.Errorf("Expected %v but got %v", expected, m.Entries())
+	}
+}
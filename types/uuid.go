@@ -0,0 +1,91 @@
+// Copyright 2019 Karl Stenerud
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE SOFTWARE.
+
+// Package types contains go types for Concise Encoding values that have no
+// natural go equivalent. They are re-exported via the ce package.
+package types
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// UUID is a RFC 4122 universally unique identifier.
+type UUID [16]byte
+
+// Create a new random (version 4) UUID.
+func NewRandomUUID() (uuid UUID, err error) {
+	if _, err = rand.Read(uuid[:]); err != nil {
+		return
+	}
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return
+}
+
+// Parse a UUID in canonical 8-4-4-4-12 hex format
+// (e.g. "123e4567-e89b-12d3-a456-426614174000"). Hex digits may be upper or
+// lower case.
+func ParseUUID(str string) (uuid UUID, err error) {
+	if len(str) != 36 || str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+		err = fmt.Errorf("%v: not a valid UUID", str)
+		return
+	}
+
+	dst := uuid[:]
+	for _, group := range []string{str[0:8], str[9:13], str[14:18], str[19:23], str[24:36]} {
+		if _, err = hex.Decode(dst, []byte(group)); err != nil {
+			err = fmt.Errorf("%v: not a valid UUID: %v", str, err)
+			return
+		}
+		dst = dst[len(group)/2:]
+	}
+	return
+}
+
+// Version returns the UUID version number (the high nybble of byte 6).
+func (_this UUID) Version() int {
+	return int(_this[6] >> 4)
+}
+
+// String returns the UUID in lowercase canonical 8-4-4-4-12 hex format.
+func (_this UUID) String() string {
+	var buff [36]byte
+	hex.Encode(buff[0:8], _this[0:4])
+	buff[8] = '-'
+	hex.Encode(buff[9:13], _this[4:6])
+	buff[13] = '-'
+	hex.Encode(buff[14:18], _this[6:8])
+	buff[18] = '-'
+	hex.Encode(buff[19:23], _this[8:10])
+	buff[23] = '-'
+	hex.Encode(buff[24:36], _this[10:16])
+	return string(buff[:])
+}
+
+func (_this UUID) MarshalText() ([]byte, error) {
+	return []byte(_this.String()), nil
+}
+
+func (_this *UUID) UnmarshalText(text []byte) (err error) {
+	*_this, err = ParseUUID(string(text))
+	return
+}